@@ -2,12 +2,14 @@ CREATE TABLE "user" (
   id TEXT PRIMARY KEY,
   name VARCHAR,
   email VARCHAR UNIQUE,
-  rut VARCHAR UNIQUE,
+  rut VARCHAR,
   uid TEXT,
   type VARCHAR, 
-  created_at TIMESTAMP
+  created_at TIMESTAMPTZ
 );
 
+CREATE UNIQUE INDEX idx_user_rut ON "user" (rut) WHERE rut <> '';
+
 CREATE TABLE customer (
   id TEXT PRIMARY KEY REFERENCES "user"(id),
   type VARCHAR 
@@ -23,7 +25,12 @@ CREATE TABLE parking (
   code VARCHAR,
   location TEXT,
   zone TEXT,
-  is_active BOOLEAN
+  is_active BOOLEAN,
+  spot_type VARCHAR NOT NULL DEFAULT 'car',
+  is_accessible BOOLEAN NOT NULL DEFAULT FALSE,
+  has_ev_charger BOOLEAN NOT NULL DEFAULT FALSE,
+  is_covered BOOLEAN NOT NULL DEFAULT FALSE,
+  is_carpool BOOLEAN NOT NULL DEFAULT FALSE
 );
 
 CREATE TABLE vehicle (
@@ -32,8 +39,16 @@ CREATE TABLE vehicle (
   brand VARCHAR,
   model VARCHAR,
   vehicle_type VARCHAR, 
+  is_electric BOOLEAN NOT NULL DEFAULT FALSE,
   customer_id TEXT REFERENCES customer(id),
-  created_at TIMESTAMP,
+  created_at TIMESTAMPTZ,
+  verification_status TEXT NOT NULL DEFAULT 'pending',
+  verified_by TEXT,
+  verified_at TIMESTAMPTZ,
+  rejection_reason TEXT NOT NULL DEFAULT '',
+  is_temporary BOOLEAN NOT NULL DEFAULT FALSE,
+  valid_from TIMESTAMPTZ,
+  valid_until TIMESTAMPTZ
 );
 
 CREATE TABLE reservation (
@@ -41,19 +56,19 @@ CREATE TABLE reservation (
   customer_id TEXT REFERENCES customer(id),
   parking_id INT REFERENCES parking(id),
   vehicle_id INT REFERENCES vehicle(id),
-  start_time TIMESTAMP,
-  end_time TIMESTAMP,
+  start_time TIMESTAMPTZ,
+  end_time TIMESTAMPTZ,
   status VARCHAR, 
-  created_at TIMESTAMP
+  created_at TIMESTAMPTZ
 );
 
 CREATE TABLE customer_schedule (
   id SERIAL PRIMARY KEY,
   customer_id TEXT REFERENCES customer(id),
-  start_time TIMESTAMP,
-  end_time TIMESTAMP,
+  start_time TIMESTAMPTZ,
+  end_time TIMESTAMPTZ,
   note TEXT, 
-  created_at TIMESTAMP
+  created_at TIMESTAMPTZ
 );
 
 CREATE TABLE parking_usage (
@@ -61,8 +76,8 @@ CREATE TABLE parking_usage (
   reservation_id INT REFERENCES reservation(id),
   vehicle_id INT REFERENCES vehicle(id),
   parking_id INT REFERENCES parking(id),
-  entry_time TIMESTAMP,
-  exit_time TIMESTAMP,
+  entry_time TIMESTAMPTZ,
+  exit_time TIMESTAMPTZ,
   ocr_plate VARCHAR,
   qr_scanned BOOLEAN,
   registered_by TEXT REFERENCES employee(id),
@@ -70,7 +85,9 @@ CREATE TABLE parking_usage (
   visitor_name VARCHAR,
   visitor_rut VARCHAR,
   visitor_contact VARCHAR,
-  zone TEXT
+  zone TEXT,
+  plate_format VARCHAR,
+  exit_type VARCHAR
 );
 
 CREATE TABLE feedback (
@@ -79,15 +96,15 @@ CREATE TABLE feedback (
   comment TEXT,
   response_comment TEXT,
   rating INT, 
-  created_at TIMESTAMP,
-  response_at TIMESTAMP
+  created_at TIMESTAMPTZ,
+  response_at TIMESTAMPTZ
 );
 
 CREATE TABLE notification_template (
   id SERIAL PRIMARY KEY,
   title VARCHAR,
   message TEXT,
-  created_at TIMESTAMP
+  created_at TIMESTAMPTZ
 );
 
 CREATE TABLE user_notification (
@@ -95,19 +112,402 @@ CREATE TABLE user_notification (
   user_id TEXT REFERENCES "user"(id),
   notification_template_id INT REFERENCES notification_template(id),
   is_read BOOLEAN DEFAULT FALSE,
-  read_at TIMESTAMP
+  read_at TIMESTAMPTZ
 );
 
 CREATE TABLE parking_access_log (
   id SERIAL PRIMARY KEY,
   plate VARCHAR,
   parking_id INT REFERENCES parking(id),
-  detected_at TIMESTAMP,
+  detected_at TIMESTAMPTZ,
   type VARCHAR, 
   status VARCHAR, 
   reservation_id INT REFERENCES reservation(id)
 );
 
+CREATE TABLE plate_format (
+  id SERIAL PRIMARY KEY,
+  code VARCHAR UNIQUE,
+  pattern VARCHAR,
+  country VARCHAR,
+  vehicle_type VARCHAR,
+  description TEXT,
+  priority INT DEFAULT 100,
+  is_active BOOLEAN DEFAULT TRUE
+);
+
+CREATE TABLE plate_watchlist (
+  id SERIAL PRIMARY KEY,
+  plate VARCHAR NOT NULL,
+  list_type VARCHAR NOT NULL,
+  reason TEXT,
+  valid_from TIMESTAMPTZ,
+  valid_until TIMESTAMPTZ,
+  added_by TEXT REFERENCES "user"(id),
+  created_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_plate_watchlist_plate ON plate_watchlist (plate);
+
+CREATE TABLE zone_hours (
+  zone TEXT PRIMARY KEY,
+  opening_time TIME NOT NULL,
+  closing_time TIME NOT NULL
+);
+
+CREATE TABLE parking_overstay (
+  id SERIAL PRIMARY KEY,
+  parking_usage_id INT REFERENCES parking_usage(id),
+  reservation_id INT REFERENCES reservation(id),
+  vehicle_id INT REFERENCES vehicle(id),
+  parking_id INT REFERENCES parking(id),
+  customer_id TEXT REFERENCES customer(id),
+  reason VARCHAR NOT NULL,
+  expected_exit_time TIMESTAMPTZ NOT NULL,
+  detected_at TIMESTAMPTZ NOT NULL,
+  exit_time TIMESTAMPTZ,
+  overstay_minutes INT DEFAULT 0,
+  UNIQUE (parking_usage_id, reason)
+);
+
+CREATE TABLE tariff (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR NOT NULL,
+  zone TEXT NOT NULL DEFAULT '',
+  customer_type VARCHAR NOT NULL DEFAULT '',
+  grace_minutes INT DEFAULT 0,
+  daily_cap NUMERIC(12, 2) DEFAULT 0,
+  holiday_rate_per_hour NUMERIC(12, 2) DEFAULT 0,
+  energy_rate_per_kwh NUMERIC(12, 2) NOT NULL DEFAULT 0,
+  currency VARCHAR DEFAULT 'CLP',
+  priority INT DEFAULT 100,
+  is_active BOOLEAN DEFAULT TRUE
+);
+
+CREATE TABLE tariff_band (
+  id SERIAL PRIMARY KEY,
+  tariff_id INT REFERENCES tariff(id) ON DELETE CASCADE,
+  start_time TIME NOT NULL,
+  end_time TIME NOT NULL,
+  rate_per_hour NUMERIC(12, 2) NOT NULL
+);
+
+CREATE TABLE parking_charge (
+  id SERIAL PRIMARY KEY,
+  parking_usage_id INT REFERENCES parking_usage(id),
+  tariff_id INT REFERENCES tariff(id) ON DELETE SET NULL,
+  customer_id TEXT REFERENCES customer(id),
+  visitor_rut VARCHAR,
+  amount NUMERIC(12, 2) NOT NULL,
+  currency VARCHAR DEFAULT 'CLP',
+  billable_minutes INT DEFAULT 0,
+  description TEXT,
+  created_at TIMESTAMPTZ
+);
+
+CREATE TABLE payment_ledger (
+  id SERIAL PRIMARY KEY,
+  customer_id TEXT REFERENCES customer(id),
+  visitor_rut VARCHAR,
+  parking_usage_id INT REFERENCES parking_usage(id),
+  entry_type VARCHAR NOT NULL,
+  amount NUMERIC(12, 2) NOT NULL,
+  currency VARCHAR DEFAULT 'CLP',
+  method VARCHAR,
+  reference VARCHAR,
+  description TEXT,
+  refund_of_id INT REFERENCES payment_ledger(id),
+  created_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_payment_ledger_refund_of ON payment_ledger (refund_of_id);
+
+CREATE TABLE quota_policy (
+  id SERIAL PRIMARY KEY,
+  customer_type VARCHAR UNIQUE NOT NULL,
+  unit VARCHAR NOT NULL,
+  monthly_allowance NUMERIC(10, 2) NOT NULL,
+  refund_cutoff_minutes INT DEFAULT 60,
+  is_active BOOLEAN DEFAULT TRUE
+);
+
+CREATE TABLE quota_movement (
+  id SERIAL PRIMARY KEY,
+  customer_id TEXT REFERENCES customer(id),
+  period VARCHAR(7) NOT NULL,
+  movement_type VARCHAR NOT NULL,
+  amount NUMERIC(10, 2) NOT NULL,
+  unit VARCHAR NOT NULL,
+  reservation_id INT REFERENCES reservation(id) ON DELETE SET NULL,
+  parking_usage_id INT REFERENCES parking_usage(id),
+  description TEXT,
+  created_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_quota_movement_customer_period ON quota_movement (customer_id, period);
+
+CREATE TABLE booking_rule (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR NOT NULL,
+  customer_type VARCHAR DEFAULT '',
+  employee_role VARCHAR DEFAULT '',
+  max_lead_hours INT DEFAULT 0,
+  min_duration_minutes INT DEFAULT 0,
+  max_duration_minutes INT DEFAULT 0,
+  max_concurrent INT DEFAULT 0,
+  max_per_week INT DEFAULT 0,
+  blackout_weekdays INT[] DEFAULT '{}',
+  blackout_dates DATE[] DEFAULT '{}',
+  blackout_day_types TEXT[] NOT NULL DEFAULT '{}',
+  priority INT DEFAULT 100,
+  is_active BOOLEAN DEFAULT TRUE
+);
+
+CREATE TABLE reservation_no_show (
+  id SERIAL PRIMARY KEY,
+  reservation_id INT UNIQUE REFERENCES reservation(id) ON DELETE CASCADE,
+  customer_id TEXT REFERENCES customer(id),
+  parking_id INT REFERENCES parking(id),
+  reservation_start TIMESTAMPTZ,
+  recorded_at TIMESTAMPTZ,
+  waived BOOLEAN DEFAULT FALSE,
+  waived_by TEXT,
+  waived_reason TEXT,
+  waived_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_reservation_no_show_customer ON reservation_no_show (customer_id, recorded_at);
+
+CREATE TABLE booking_suspension (
+  id SERIAL PRIMARY KEY,
+  customer_id TEXT REFERENCES customer(id),
+  starts_at TIMESTAMPTZ NOT NULL,
+  ends_at TIMESTAMPTZ NOT NULL,
+  reason TEXT,
+  created_by TEXT,
+  lifted_at TIMESTAMPTZ,
+  lifted_by TEXT
+);
+
+CREATE TABLE accessibility_permit (
+  id SERIAL PRIMARY KEY,
+  customer_id TEXT REFERENCES customer(id),
+  number VARCHAR,
+  issued_by TEXT,
+  valid_from TIMESTAMPTZ NOT NULL,
+  valid_until TIMESTAMPTZ,
+  created_at TIMESTAMPTZ
+);
+
+CREATE TABLE charging_session (
+  id SERIAL PRIMARY KEY,
+  parking_usage_id INT NOT NULL REFERENCES parking_usage(id),
+  parking_id INT NOT NULL REFERENCES parking(id),
+  customer_id TEXT REFERENCES customer(id),
+  visitor_rut VARCHAR NOT NULL DEFAULT '',
+  energy_source VARCHAR NOT NULL DEFAULT 'manual',
+  status VARCHAR NOT NULL DEFAULT 'active',
+  started_at TIMESTAMPTZ NOT NULL,
+  completed_at TIMESTAMPTZ,
+  ended_at TIMESTAMPTZ,
+  max_minutes INT NOT NULL,
+  energy_kwh NUMERIC(10, 2) NOT NULL DEFAULT 0,
+  tariff_id INT REFERENCES tariff(id) ON DELETE SET NULL,
+  cost NUMERIC(12, 2) NOT NULL DEFAULT 0,
+  currency VARCHAR NOT NULL DEFAULT 'CLP',
+  notified_at TIMESTAMPTZ,
+  escalated_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_charging_session_open ON charging_session (parking_id) WHERE status IN ('active', 'completed');
+
+CREATE TABLE parking_closure (
+  id SERIAL PRIMARY KEY,
+  parking_ids INT[] NOT NULL DEFAULT '{}',
+  code_from TEXT NOT NULL DEFAULT '',
+  code_to TEXT NOT NULL DEFAULT '',
+  zone TEXT NOT NULL DEFAULT '',
+  starts_at TIMESTAMPTZ NOT NULL,
+  ends_at TIMESTAMPTZ NOT NULL,
+  reason TEXT NOT NULL,
+  created_by TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL,
+  cancelled_at TIMESTAMPTZ,
+  cancelled_by TEXT,
+  CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_parking_closure_window ON parking_closure (starts_at, ends_at) WHERE cancelled_at IS NULL;
+
+CREATE TABLE calendar_event (
+  id SERIAL PRIMARY KEY,
+  name TEXT NOT NULL,
+  event_type VARCHAR NOT NULL,
+  start_date DATE NOT NULL,
+  end_date DATE NOT NULL,
+  source VARCHAR NOT NULL DEFAULT 'manual',
+  external_uid TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL,
+  CHECK (end_date >= start_date)
+);
+
+CREATE UNIQUE INDEX idx_calendar_event_external_uid ON calendar_event (external_uid) WHERE external_uid <> '';
+CREATE INDEX idx_calendar_event_dates ON calendar_event (start_date, end_date);
+
+CREATE TABLE reservation_calendar_feed (
+  user_id TEXT PRIMARY KEY REFERENCES "user"(id) ON DELETE CASCADE,
+  token TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE reservation_waitlist (
+  id SERIAL PRIMARY KEY,
+  customer_id TEXT NOT NULL REFERENCES customer(id) ON DELETE CASCADE,
+  vehicle_id INT NOT NULL REFERENCES vehicle(id) ON DELETE CASCADE,
+  zone TEXT NOT NULL DEFAULT '',
+  start_time TIMESTAMPTZ NOT NULL,
+  end_time TIMESTAMPTZ NOT NULL,
+  status TEXT NOT NULL DEFAULT 'waiting',
+  created_at TIMESTAMPTZ NOT NULL,
+  CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_reservation_waitlist_waiting ON reservation_waitlist (start_time, end_time) WHERE status = 'waiting';
+
+CREATE TABLE reservation_transfer (
+  id SERIAL PRIMARY KEY,
+  kind TEXT NOT NULL,
+  reservation_id INT NOT NULL REFERENCES reservation(id) ON DELETE CASCADE,
+  counter_reservation_id INT REFERENCES reservation(id) ON DELETE CASCADE,
+  from_customer_id TEXT NOT NULL REFERENCES customer(id),
+  to_customer_id TEXT REFERENCES customer(id),
+  to_waitlist BOOLEAN NOT NULL DEFAULT FALSE,
+  vehicle_id INT REFERENCES vehicle(id),
+  waitlist_entry_id INT REFERENCES reservation_waitlist(id),
+  message TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT 'pending',
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  responded_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_reservation_transfer_pending ON reservation_transfer (reservation_id) WHERE status = 'pending';
+CREATE INDEX idx_reservation_transfer_customers ON reservation_transfer (from_customer_id, to_customer_id);
+
+CREATE TABLE carpool (
+  id SERIAL PRIMARY KEY,
+  name TEXT NOT NULL,
+  vehicle_id INT NOT NULL REFERENCES vehicle(id) ON DELETE CASCADE,
+  owner_id TEXT NOT NULL REFERENCES customer(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE carpool_member (
+  carpool_id INT NOT NULL REFERENCES carpool(id) ON DELETE CASCADE,
+  customer_id TEXT NOT NULL REFERENCES customer(id) ON DELETE CASCADE,
+  role TEXT NOT NULL DEFAULT 'passenger',
+  added_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (carpool_id, customer_id)
+);
+
+CREATE INDEX idx_carpool_member_customer ON carpool_member (customer_id);
+
+CREATE TABLE carpool_reservation (
+  reservation_id INT PRIMARY KEY REFERENCES reservation(id) ON DELETE CASCADE,
+  carpool_id INT NOT NULL REFERENCES carpool(id) ON DELETE CASCADE,
+  driver_id TEXT NOT NULL REFERENCES customer(id),
+  passenger_ids TEXT[] NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_carpool_reservation_passengers ON carpool_reservation USING GIN (passenger_ids);
+
+CREATE TABLE vehicle_document (
+  id SERIAL PRIMARY KEY,
+  vehicle_id INT NOT NULL REFERENCES vehicle(id) ON DELETE CASCADE,
+  document_type TEXT NOT NULL DEFAULT 'registration_certificate',
+  file_name TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size BIGINT NOT NULL,
+  storage_path TEXT NOT NULL,
+  uploaded_by TEXT NOT NULL,
+  uploaded_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_vehicle_document_vehicle ON vehicle_document (vehicle_id);
+CREATE INDEX idx_vehicle_verification_status ON vehicle (verification_status);
+CREATE INDEX idx_vehicle_plate_length ON vehicle (LENGTH(REGEXP_REPLACE(plate, '[^A-Za-z0-9]', '', 'g')));
+
+CREATE TABLE user_import (
+  id SERIAL PRIMARY KEY,
+  file_name TEXT NOT NULL,
+  dry_run BOOLEAN NOT NULL DEFAULT TRUE,
+  status TEXT NOT NULL DEFAULT 'running',
+  created_by TEXT NOT NULL DEFAULT '',
+  total INT NOT NULL DEFAULT 0,
+  created INT NOT NULL DEFAULT 0,
+  updated INT NOT NULL DEFAULT 0,
+  unchanged INT NOT NULL DEFAULT 0,
+  invalid INT NOT NULL DEFAULT 0,
+  failed INT NOT NULL DEFAULT 0,
+  rows JSONB NOT NULL DEFAULT '[]',
+  started_at TIMESTAMPTZ NOT NULL,
+  finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_user_import_started_at ON user_import (started_at DESC);
+
+INSERT INTO tariff (name, zone, customer_type, grace_minutes, daily_cap, holiday_rate_per_hour, energy_rate_per_kwh, currency, priority, is_active) VALUES
+('Visitas', '', 'visitor', 15, 6000, 1200, 0, 'CLP', 100, TRUE),
+('Carga eléctrica', '', 'ev_charging', 0, 0, 0, 250, 'CLP', 100, TRUE);
+
+INSERT INTO tariff_band (tariff_id, start_time, end_time, rate_per_hour) VALUES
+(1, '07:00', '22:00', 1000),
+(1, '22:00', '07:00', 500);
+
+INSERT INTO calendar_event (name, event_type, start_date, end_date, source, external_uid, created_at) VALUES
+('Año Nuevo', 'holiday', '2026-01-01', '2026-01-01', 'seed', 'cl-2026-01-01', NOW()),
+('Viernes Santo', 'holiday', '2026-04-03', '2026-04-03', 'seed', 'cl-2026-04-03', NOW()),
+('Sábado Santo', 'holiday', '2026-04-04', '2026-04-04', 'seed', 'cl-2026-04-04', NOW()),
+('Día Nacional del Trabajo', 'holiday', '2026-05-01', '2026-05-01', 'seed', 'cl-2026-05-01', NOW()),
+('Día de las Glorias Navales', 'holiday', '2026-05-21', '2026-05-21', 'seed', 'cl-2026-05-21', NOW()),
+('Día Nacional de los Pueblos Indígenas', 'holiday', '2026-06-21', '2026-06-21', 'seed', 'cl-2026-06-21', NOW()),
+('San Pedro y San Pablo', 'holiday', '2026-06-29', '2026-06-29', 'seed', 'cl-2026-06-29', NOW()),
+('Día de la Virgen del Carmen', 'holiday', '2026-07-16', '2026-07-16', 'seed', 'cl-2026-07-16', NOW()),
+('Asunción de la Virgen', 'holiday', '2026-08-15', '2026-08-15', 'seed', 'cl-2026-08-15', NOW()),
+('Independencia Nacional', 'holiday', '2026-09-18', '2026-09-18', 'seed', 'cl-2026-09-18', NOW()),
+('Día de las Glorias del Ejército', 'holiday', '2026-09-19', '2026-09-19', 'seed', 'cl-2026-09-19', NOW()),
+('Encuentro de Dos Mundos', 'holiday', '2026-10-12', '2026-10-12', 'seed', 'cl-2026-10-12', NOW()),
+('Día de las Iglesias Evangélicas y Protestantes', 'holiday', '2026-10-31', '2026-10-31', 'seed', 'cl-2026-10-31', NOW()),
+('Día de Todos los Santos', 'holiday', '2026-11-01', '2026-11-01', 'seed', 'cl-2026-11-01', NOW()),
+('Inmaculada Concepción', 'holiday', '2026-12-08', '2026-12-08', 'seed', 'cl-2026-12-08', NOW()),
+('Navidad', 'holiday', '2026-12-25', '2026-12-25', 'seed', 'cl-2026-12-25', NOW()),
+('Año Nuevo', 'holiday', '2027-01-01', '2027-01-01', 'seed', 'cl-2027-01-01', NOW()),
+('Viernes Santo', 'holiday', '2027-03-26', '2027-03-26', 'seed', 'cl-2027-03-26', NOW()),
+('Sábado Santo', 'holiday', '2027-03-27', '2027-03-27', 'seed', 'cl-2027-03-27', NOW()),
+('Día Nacional del Trabajo', 'holiday', '2027-05-01', '2027-05-01', 'seed', 'cl-2027-05-01', NOW()),
+('Día de las Glorias Navales', 'holiday', '2027-05-21', '2027-05-21', 'seed', 'cl-2027-05-21', NOW()),
+('Día Nacional de los Pueblos Indígenas', 'holiday', '2027-06-21', '2027-06-21', 'seed', 'cl-2027-06-21', NOW()),
+('San Pedro y San Pablo', 'holiday', '2027-06-28', '2027-06-28', 'seed', 'cl-2027-06-28', NOW()),
+('Día de la Virgen del Carmen', 'holiday', '2027-07-16', '2027-07-16', 'seed', 'cl-2027-07-16', NOW()),
+('Asunción de la Virgen', 'holiday', '2027-08-15', '2027-08-15', 'seed', 'cl-2027-08-15', NOW()),
+('Independencia Nacional', 'holiday', '2027-09-18', '2027-09-18', 'seed', 'cl-2027-09-18', NOW()),
+('Día de las Glorias del Ejército', 'holiday', '2027-09-19', '2027-09-19', 'seed', 'cl-2027-09-19', NOW()),
+('Encuentro de Dos Mundos', 'holiday', '2027-10-11', '2027-10-11', 'seed', 'cl-2027-10-11', NOW()),
+('Día de las Iglesias Evangélicas y Protestantes', 'holiday', '2027-10-31', '2027-10-31', 'seed', 'cl-2027-10-31', NOW()),
+('Día de Todos los Santos', 'holiday', '2027-11-01', '2027-11-01', 'seed', 'cl-2027-11-01', NOW()),
+('Inmaculada Concepción', 'holiday', '2027-12-08', '2027-12-08', 'seed', 'cl-2027-12-08', NOW()),
+('Navidad', 'holiday', '2027-12-25', '2027-12-25', 'seed', 'cl-2027-12-25', NOW());
+
+INSERT INTO plate_format (code, pattern, country, vehicle_type, description, priority, is_active) VALUES
+('CL_LLLLNN', 'LLLLNN', 'CL', 'car', 'Chile, vehículos desde 2007', 10, TRUE),
+('CL_LLNNNN', 'LLNNNN', 'CL', 'car', 'Chile, vehículos anteriores a 2007', 20, TRUE),
+('CL_LLLNN', 'LLLNN', 'CL', 'motorcycle', 'Chile, motocicletas', 30, TRUE),
+('CL_LLNNN', 'LLNNN', 'CL', 'motorcycle', 'Chile, motocicletas antiguas', 40, TRUE),
+('CL_LLLNNN', 'LLLNNN', 'CL', 'trailer', 'Chile, remolques', 50, TRUE),
+('AR_LLNNNLL', 'LLNNNLL', 'AR', 'car', 'Argentina, formato Mercosur', 60, TRUE),
+('AR_LNNNLLL', 'LNNNLLL', 'AR', 'motorcycle', 'Argentina, motocicletas Mercosur', 70, TRUE),
+('PE_LALNNN', 'LALNNN', 'PE', 'car', 'Perú, vehículos', 80, TRUE);
 
 INSERT INTO parking (code, location, zone, is_active) VALUES
 ('P0001', 'Zona Estudiantes', 'Frontis Externo', TRUE),
@@ -10,16 +10,41 @@ import (
 	"cloud.google.com/go/firestore"
 	"firebase.google.com/go/v4/auth"
 	"github.com/gonzalohonorato/servercorego/config"
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	bookingRulePersistence "github.com/gonzalohonorato/servercorego/core/bookingrule/infrastructure/persistence"
+	calendarPersistence "github.com/gonzalohonorato/servercorego/core/calendar/infrastructure/persistence"
+	carpoolPersistence "github.com/gonzalohonorato/servercorego/core/carpool/infrastructure/persistence"
+	charging "github.com/gonzalohonorato/servercorego/core/charging/application"
+	chargingGateways "github.com/gonzalohonorato/servercorego/core/charging/domain/gateways"
+	chargingGatewayProviders "github.com/gonzalohonorato/servercorego/core/charging/infrastructure/gateways"
+	chargingPersistence "github.com/gonzalohonorato/servercorego/core/charging/infrastructure/persistence"
+	closurePersistence "github.com/gonzalohonorato/servercorego/core/closure/infrastructure/persistence"
 	feedbackPersistence "github.com/gonzalohonorato/servercorego/core/feedback/infrastructure/persistence"
+	mailGateways "github.com/gonzalohonorato/servercorego/core/mail/domain/gateways"
+	mailGatewayProviders "github.com/gonzalohonorato/servercorego/core/mail/infrastructure/gateways"
+	noShowPersistence "github.com/gonzalohonorato/servercorego/core/noshow/infrastructure/persistence"
 	notificationtemplatePersistence "github.com/gonzalohonorato/servercorego/core/notificationtemplate/infrastructure/persistence"
+	overstay "github.com/gonzalohonorato/servercorego/core/overstay/application"
+	overstayPersistence "github.com/gonzalohonorato/servercorego/core/overstay/infrastructure/persistence"
 	parkingPersistence "github.com/gonzalohonorato/servercorego/core/parking/infrastructure/persistence"
+	parkingUsage "github.com/gonzalohonorato/servercorego/core/parkingusage/application"
 	parkingUsagePersistence "github.com/gonzalohonorato/servercorego/core/parkingusage/infrastructure/persistence"
+	paymentGateways "github.com/gonzalohonorato/servercorego/core/payment/domain/gateways"
+	paymentGatewayProviders "github.com/gonzalohonorato/servercorego/core/payment/infrastructure/gateways"
+	paymentPersistence "github.com/gonzalohonorato/servercorego/core/payment/infrastructure/persistence"
+	platePersistence "github.com/gonzalohonorato/servercorego/core/plate/infrastructure/persistence"
+	quotaPersistence "github.com/gonzalohonorato/servercorego/core/quota/infrastructure/persistence"
 	"github.com/gonzalohonorato/servercorego/core/reservation/application"
 	reservation "github.com/gonzalohonorato/servercorego/core/reservation/application"
 	reservationPersistence "github.com/gonzalohonorato/servercorego/core/reservation/infrastructure/persistence"
+	tariffPersistence "github.com/gonzalohonorato/servercorego/core/tariff/infrastructure/persistence"
+	transferPersistence "github.com/gonzalohonorato/servercorego/core/transfer/infrastructure/persistence"
 	userPersistence "github.com/gonzalohonorato/servercorego/core/user/infrastructure/persistence"
+	userImportPersistence "github.com/gonzalohonorato/servercorego/core/userimport/infrastructure/persistence"
 	usernotification "github.com/gonzalohonorato/servercorego/core/usernotification/infrastructure/persistence"
 	vehiclePersistence "github.com/gonzalohonorato/servercorego/core/vehicle/infrastructure/persistence"
+	vehicleStorage "github.com/gonzalohonorato/servercorego/core/vehicle/infrastructure/storage"
+	watchlistPersistence "github.com/gonzalohonorato/servercorego/core/watchlist/infrastructure/persistence"
 	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -38,8 +63,23 @@ type Container struct {
 	wsService *infrastructure.WebSocketService
 	wsOnce    sync.Once
 
+	reservationUsecase     *reservation.ReservationUsecase
+	reservationUsecaseOnce sync.Once
+
 	reservationScheduler *application.ReservationScheduler
 	schedulerOnce        sync.Once
+
+	overstayScheduler     *overstay.OverstayScheduler
+	overstaySchedulerOnce sync.Once
+
+	staleUsageScheduler     *parkingUsage.StaleUsageScheduler
+	staleUsageSchedulerOnce sync.Once
+
+	chargerClient     chargingGateways.ChargerClient
+	chargerClientOnce sync.Once
+
+	chargingScheduler     *charging.ChargingScheduler
+	chargingSchedulerOnce sync.Once
 }
 
 func NewContainer(ctx context.Context) *Container {
@@ -56,7 +96,15 @@ func (c *Container) InitTimescaleDB() (*pgxpool.Pool, error) {
 			return
 		}
 
-		pool, e := pgxpool.New(c.Ctx, connStr)
+		poolConfig, e := pgxpool.ParseConfig(connStr)
+		if e != nil {
+			err = fmt.Errorf("Error al leer DATABASE_URL: %v", e)
+			return
+		}
+		// Las conversiones de fechas en SQL usan la zona del campus, no la del servidor
+		poolConfig.ConnConfig.RuntimeParams["timezone"] = utils.CampusLocation().String()
+
+		pool, e := pgxpool.NewWithConfig(c.Ctx, poolConfig)
 		if e != nil {
 			err = fmt.Errorf("Error al conectar a TimescaleDB: %v", e)
 			return
@@ -191,6 +239,236 @@ func (c *Container) ProvideNotificationTemplateRepository() *notificationtemplat
 	return notificationtemplatePersistence.NewTimescaleNotificationTemplateRepository(pool)
 }
 
+func (c *Container) ProvidePlateFormatRepository() *platePersistence.TimescalePlateFormatRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return platePersistence.NewTimescalePlateFormatRepository(pool)
+}
+
+func (c *Container) ProvideWatchlistRepository() *watchlistPersistence.TimescaleWatchlistRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return watchlistPersistence.NewTimescaleWatchlistRepository(pool)
+}
+
+func (c *Container) ProvideOverstayRepository() *overstayPersistence.TimescaleOverstayRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return overstayPersistence.NewTimescaleOverstayRepository(pool)
+}
+
+func (c *Container) ProvideZoneHoursRepository() *overstayPersistence.TimescaleZoneHoursRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return overstayPersistence.NewTimescaleZoneHoursRepository(pool)
+}
+
+func (c *Container) ProvideTariffRepository() *tariffPersistence.TimescaleTariffRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return tariffPersistence.NewTimescaleTariffRepository(pool)
+}
+
+func (c *Container) ProvideParkingChargeRepository() *tariffPersistence.TimescaleParkingChargeRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return tariffPersistence.NewTimescaleParkingChargeRepository(pool)
+}
+
+func (c *Container) ProvideLedgerRepository() *paymentPersistence.TimescaleLedgerRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return paymentPersistence.NewTimescaleLedgerRepository(pool)
+}
+
+func (c *Container) ProvidePaymentGateway() paymentGateways.PaymentGateway {
+	provider := os.Getenv("PAYMENT_GATEWAY")
+	switch provider {
+	case "", "local":
+		return paymentGatewayProviders.NewLocalPaymentGateway()
+	default:
+		log.Fatalf("Pasarela de pago no soportada: %s", provider)
+		return nil
+	}
+}
+
+func (c *Container) ProvideQuotaPolicyRepository() *quotaPersistence.TimescaleQuotaPolicyRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return quotaPersistence.NewTimescaleQuotaPolicyRepository(pool)
+}
+
+func (c *Container) ProvideQuotaMovementRepository() *quotaPersistence.TimescaleQuotaMovementRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return quotaPersistence.NewTimescaleQuotaMovementRepository(pool)
+}
+
+func (c *Container) ProvideBookingRuleRepository() *bookingRulePersistence.TimescaleBookingRuleRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return bookingRulePersistence.NewTimescaleBookingRuleRepository(pool)
+}
+
+func (c *Container) ProvideNoShowRepository() *noShowPersistence.TimescaleNoShowRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return noShowPersistence.NewTimescaleNoShowRepository(pool)
+}
+
+func (c *Container) ProvideBookingSuspensionRepository() *noShowPersistence.TimescaleBookingSuspensionRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return noShowPersistence.NewTimescaleBookingSuspensionRepository(pool)
+}
+
+func (c *Container) ProvideAccessibilityPermitRepository() *parkingPersistence.TimescaleAccessibilityPermitRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return parkingPersistence.NewTimescaleAccessibilityPermitRepository(pool)
+}
+
+func (c *Container) ProvideChargingSessionRepository() *chargingPersistence.TimescaleChargingSessionRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return chargingPersistence.NewTimescaleChargingSessionRepository(pool)
+}
+
+func (c *Container) ProvideChargerClient() chargingGateways.ChargerClient {
+	c.chargerClientOnce.Do(func() {
+		provider := os.Getenv("CHARGER_CLIENT")
+		switch provider {
+		case "", "simulated":
+			c.chargerClient = chargingGatewayProviders.NewSimulatedChargerClient()
+		default:
+			log.Fatalf("Cliente de cargadores no soportado: %s", provider)
+		}
+	})
+
+	return c.chargerClient
+}
+
+func (c *Container) ProvideClosureRepository() *closurePersistence.TimescaleClosureRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return closurePersistence.NewTimescaleClosureRepository(pool)
+}
+
+func (c *Container) ProvideCalendarEventRepository() *calendarPersistence.TimescaleCalendarEventRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return calendarPersistence.NewTimescaleCalendarEventRepository(pool)
+}
+
+func (c *Container) ProvideCalendarFeedTokenRepository() *reservationPersistence.TimescaleCalendarFeedTokenRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return reservationPersistence.NewTimescaleCalendarFeedTokenRepository(pool)
+}
+
+func (c *Container) ProvideTransferRepository() *transferPersistence.TimescaleTransferRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return transferPersistence.NewTimescaleTransferRepository(pool)
+}
+
+func (c *Container) ProvideWaitlistRepository() *transferPersistence.TimescaleWaitlistRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return transferPersistence.NewTimescaleWaitlistRepository(pool)
+}
+
+func (c *Container) ProvideCarpoolRepository() *carpoolPersistence.TimescaleCarpoolRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return carpoolPersistence.NewTimescaleCarpoolRepository(pool)
+}
+
+func (c *Container) ProvideCarpoolReservationRepository() *carpoolPersistence.TimescaleCarpoolReservationRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return carpoolPersistence.NewTimescaleCarpoolReservationRepository(pool)
+}
+
+func (c *Container) ProvideVehicleDocumentRepository() *vehiclePersistence.TimescaleVehicleDocumentRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return vehiclePersistence.NewTimescaleVehicleDocumentRepository(pool)
+}
+
+func (c *Container) ProvideVehicleDocumentStorage() *vehicleStorage.LocalDocumentStorage {
+	return vehicleStorage.NewLocalDocumentStorage(os.Getenv("VEHICLE_DOCUMENTS_DIR"))
+}
+
+func (c *Container) ProvideMailer() mailGateways.Mailer {
+	provider := os.Getenv("MAIL_PROVIDER")
+	switch provider {
+	case "", "log":
+		return mailGatewayProviders.NewLogMailer()
+	case "smtp":
+		mailer, err := mailGatewayProviders.NewSMTPMailer()
+		if err != nil {
+			log.Fatalf("Error al configurar SMTP: %v", err)
+		}
+		return mailer
+	default:
+		log.Fatalf("Proveedor de correo no soportado: %s", provider)
+		return nil
+	}
+}
+
+func (c *Container) ProvideUserImportRepository() *userImportPersistence.TimescaleUserImportRepository {
+	pool, err := c.InitTimescaleDB()
+	if err != nil {
+		log.Fatalf("Error al inicializar TimescaleDB: %v", err)
+	}
+	return userImportPersistence.NewTimescaleUserImportRepository(pool)
+}
+
 func (c *Container) ProvideWebSocketService() *infrastructure.WebSocketService {
 	c.wsOnce.Do(func() {
 		c.wsService = infrastructure.NewWebSocketService()
@@ -204,17 +482,102 @@ func (c *Container) ProvideWebSocketService() *infrastructure.WebSocketService {
 
 
 
+func (c *Container) ProvideReservationUsecase() *reservation.ReservationUsecase {
+	c.reservationUsecaseOnce.Do(func() {
+		c.reservationUsecase = reservation.NewReservationUsecase(
+			c.ProvideReservationRepository(),
+			c.ProvideParkingRepository(),
+			c.ProvideUserRepository(),
+			c.ProvideQuotaPolicyRepository(),
+			c.ProvideQuotaMovementRepository(),
+			c.ProvideBookingRuleRepository(),
+			c.ProvideNoShowRepository(),
+			c.ProvideBookingSuspensionRepository(),
+			c.ProvideWebSocketService(),
+			c.ProvideVehicleRepository(),
+			c.ProvideAccessibilityPermitRepository(),
+			c.ProvideClosureRepository(),
+			c.ProvideCalendarEventRepository(),
+		)
+	})
+
+	return c.reservationUsecase
+}
+
 func (c *Container) ProvideReservationScheduler() *application.ReservationScheduler {
 	c.schedulerOnce.Do(func() {
-		reservationRepo := c.ProvideReservationRepository()
-		parkingRepo := c.ProvideParkingRepository()
+		c.reservationScheduler = reservation.NewReservationScheduler(c.ProvideReservationUsecase(), c.ProvideParkingRepository())
+	})
 
-		
-		reservationUsecase := reservation.NewReservationUsecase(reservationRepo, parkingRepo)
+	return c.reservationScheduler
+}
 
-		
-		c.reservationScheduler = reservation.NewReservationScheduler(reservationUsecase, parkingRepo)
+func (c *Container) ProvideOverstayScheduler() *overstay.OverstayScheduler {
+	c.overstaySchedulerOnce.Do(func() {
+		overstayUsecase := overstay.NewOverstayUsecase(
+			c.ProvideOverstayRepository(),
+			c.ProvideZoneHoursRepository(),
+			c.ProvideParkingUsageRepository(),
+			c.ProvideParkingRepository(),
+			c.ProvideReservationRepository(),
+			c.ProvideVehicleRepository(),
+			c.ProvideWebSocketService(),
+		)
+
+		c.overstayScheduler = overstay.NewOverstayScheduler(overstayUsecase)
 	})
 
-	return c.reservationScheduler
+	return c.overstayScheduler
+}
+
+func (c *Container) ProvideStaleUsageScheduler() *parkingUsage.StaleUsageScheduler {
+	c.staleUsageSchedulerOnce.Do(func() {
+		parkingUsageUsecase := parkingUsage.NewParkingUsageUsecase(
+			c.ProvideParkingUsageRepository(),
+			c.ProvideParkingRepository(),
+			c.ProvideVehicleRepository(),
+			c.ProvideReservationRepository(),
+			c.ProvideWebSocketService(),
+			c.ProvidePlateFormatRepository(),
+			c.ProvideWatchlistRepository(),
+			c.ProvideUserRepository(),
+			c.ProvideTariffRepository(),
+			c.ProvideParkingChargeRepository(),
+			c.ProvideLedgerRepository(),
+			c.ProvidePaymentGateway(),
+			c.ProvideQuotaPolicyRepository(),
+			c.ProvideQuotaMovementRepository(),
+			c.ProvideAccessibilityPermitRepository(),
+			c.ProvideClosureRepository(),
+			c.ProvideCalendarEventRepository(),
+			c.ProvideChargingSessionRepository(),
+			c.ProvideChargerClient(),
+		)
+
+		c.staleUsageScheduler = parkingUsage.NewStaleUsageScheduler(parkingUsageUsecase)
+	})
+
+	return c.staleUsageScheduler
+}
+
+func (c *Container) ProvideChargingScheduler() *charging.ChargingScheduler {
+	c.chargingSchedulerOnce.Do(func() {
+		chargingUsecase := charging.NewChargingUsecase(
+			c.ProvideChargingSessionRepository(),
+			c.ProvideParkingUsageRepository(),
+			c.ProvideParkingRepository(),
+			c.ProvideVehicleRepository(),
+			c.ProvideTariffRepository(),
+			c.ProvideParkingChargeRepository(),
+			c.ProvideLedgerRepository(),
+			c.ProvidePaymentGateway(),
+			c.ProvideChargerClient(),
+			c.ProvideWebSocketService(),
+			c.ProvideCalendarEventRepository(),
+		)
+
+		c.chargingScheduler = charging.NewChargingScheduler(chargingUsecase)
+	})
+
+	return c.chargingScheduler
 }
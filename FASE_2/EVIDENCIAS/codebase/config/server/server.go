@@ -9,14 +9,28 @@ import (
 	"syscall"
 
 	"github.com/gonzalohonorato/servercorego/config/injector"
+	bookingRuleRoutes "github.com/gonzalohonorato/servercorego/core/bookingrule/infrastructure/rest/routes"
+	calendarRoutes "github.com/gonzalohonorato/servercorego/core/calendar/infrastructure/rest/routes"
+	carpoolRoutes "github.com/gonzalohonorato/servercorego/core/carpool/infrastructure/rest/routes"
+	chargingRoutes "github.com/gonzalohonorato/servercorego/core/charging/infrastructure/rest/routes"
+	closureRoutes "github.com/gonzalohonorato/servercorego/core/closure/infrastructure/rest/routes"
 	feedbackRoutes "github.com/gonzalohonorato/servercorego/core/feedback/infrastructure/rest/routes"
+	noShowRoutes "github.com/gonzalohonorato/servercorego/core/noshow/infrastructure/rest/routes"
 	notificationtemplateRoutes "github.com/gonzalohonorato/servercorego/core/notificationtemplate/infrastructure/rest/routes"
+	overstayRoutes "github.com/gonzalohonorato/servercorego/core/overstay/infrastructure/rest/routes"
 	parkingRoutes "github.com/gonzalohonorato/servercorego/core/parking/infrastructure/rest/routes"
 	parkingUsageRoutes "github.com/gonzalohonorato/servercorego/core/parkingusage/infrastructure/rest/routes"
+	paymentRoutes "github.com/gonzalohonorato/servercorego/core/payment/infrastructure/rest/routes"
+	plateRoutes "github.com/gonzalohonorato/servercorego/core/plate/infrastructure/rest/routes"
+	quotaRoutes "github.com/gonzalohonorato/servercorego/core/quota/infrastructure/rest/routes"
 	reservationRoutes "github.com/gonzalohonorato/servercorego/core/reservation/infrastructure/rest/routes"
+	tariffRoutes "github.com/gonzalohonorato/servercorego/core/tariff/infrastructure/rest/routes"
+	transferRoutes "github.com/gonzalohonorato/servercorego/core/transfer/infrastructure/rest/routes"
 	userRoutes "github.com/gonzalohonorato/servercorego/core/user/infrastructure/rest/routes"
+	userImportRoutes "github.com/gonzalohonorato/servercorego/core/userimport/infrastructure/rest/routes"
 	usernotificationRoutes "github.com/gonzalohonorato/servercorego/core/usernotification/infrastructure/rest/routes"
 	vehicleRoutes "github.com/gonzalohonorato/servercorego/core/vehicle/infrastructure/rest/routes"
+	watchlistRoutes "github.com/gonzalohonorato/servercorego/core/watchlist/infrastructure/rest/routes"
 	websocketRoutes "github.com/gonzalohonorato/servercorego/core/websocket/infrastructure/rest/routes"
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
@@ -59,6 +73,20 @@ func RunServer() {
 	feedbackRoutes.FeedbackRoutes(router, container)
 	usernotificationRoutes.UserNotificationRoutes(router, container)
 	notificationtemplateRoutes.NotificationTemplateRoutes(router, container)
+	plateRoutes.PlateFormatRoutes(router, container)
+	watchlistRoutes.WatchlistRoutes(router, container)
+	overstayRoutes.OverstayRoutes(router, container)
+	tariffRoutes.TariffRoutes(router, container)
+	paymentRoutes.PaymentRoutes(router, container)
+	quotaRoutes.QuotaRoutes(router, container)
+	bookingRuleRoutes.BookingRuleRoutes(router, container)
+	noShowRoutes.NoShowRoutes(router, container)
+	chargingRoutes.ChargingRoutes(router, container)
+	closureRoutes.ClosureRoutes(router, container)
+	calendarRoutes.CalendarRoutes(router, container)
+	transferRoutes.TransferRoutes(router, container)
+	carpoolRoutes.CarpoolRoutes(router, container)
+	userImportRoutes.UserImportRoutes(router, container)
 	wsService := container.ProvideWebSocketService()
 
 	
@@ -80,6 +108,21 @@ func RunServer() {
 	reservationScheduler := container.ProvideReservationScheduler()
 	reservationScheduler.Start()
 
+	overstayScheduler := container.ProvideOverstayScheduler()
+	overstayScheduler.Start()
+
+	staleUsageScheduler := container.ProvideStaleUsageScheduler()
+	staleUsageScheduler.Start()
+
+	chargingScheduler := container.ProvideChargingScheduler()
+	chargingScheduler.Start()
+
+	if count, err := container.ProvideUserImportRepository().FailRunningUserImports(); err != nil {
+		log.Printf("Error al marcar importaciones interrumpidas: %v", err)
+	} else if count > 0 {
+		log.Printf("%d importaciones interrumpidas por el reinicio quedaron como fallidas", count)
+	}
+
 	
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -87,6 +130,9 @@ func RunServer() {
 		<-c
 		log.Println("Deteniendo servicios...")
 		reservationScheduler.Stop()
+		overstayScheduler.Stop()
+		staleUsageScheduler.Stop()
+		chargingScheduler.Stop()
 		container.CloseTimescaleDB()
 		os.Exit(0)
 	}()
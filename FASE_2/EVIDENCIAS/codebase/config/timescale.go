@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 
 	"github.com/jackc/pgx/v5"
@@ -17,10 +18,10 @@ type TimescaleDB struct {
 
 func InitTimescaleDB() (*TimescaleDB, error) {
 	connStr := os.Getenv("DATABASE_URL")
-	fmt.Println("Connecting to TimescaleDB with connection", connStr)
 	if connStr == "" {
 		log.Fatalf("DATABASE_URL environment variable not set")
 	}
+	fmt.Println("Connecting to TimescaleDB with connection", redactConnString(connStr))
 	ctx := context.Background()
 	conn, err := pgx.Connect(ctx, connStr)
 	if err != nil {
@@ -31,3 +32,11 @@ func InitTimescaleDB() (*TimescaleDB, error) {
 		Conn: conn,
 	}, nil
 }
+
+func redactConnString(connStr string) string {
+	parsed, err := url.Parse(connStr)
+	if err != nil || parsed.User == nil {
+		return "(DATABASE_URL)"
+	}
+	return parsed.Redacted()
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidRut = errors.New("RUT inválido")
+
+var rutPattern = regexp.MustCompile(`^(\d{7,8})([\dK])$`)
+
+// RUT en formato canónico: sin puntos, con guion y dígito verificador en mayúscula (12345678-5)
+type Rut string
+
+// Acepta 12.345.678-5, 12345678-5 o 123456785 y valida el dígito verificador
+func ParseRut(raw string) (Rut, error) {
+	cleaned := strings.ToUpper(strings.NewReplacer(".", "", "-", "", " ", "").Replace(strings.TrimSpace(raw)))
+	match := rutPattern.FindStringSubmatch(cleaned)
+	if match == nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRut, raw)
+	}
+	body, checkDigit := match[1], match[2]
+	if rutCheckDigit(body) != checkDigit {
+		return "", fmt.Errorf("%w: dígito verificador incorrecto en %q", ErrInvalidRut, raw)
+	}
+	return Rut(body + "-" + checkDigit), nil
+}
+
+func (r Rut) String() string {
+	return string(r)
+}
+
+// Formato con separador de miles para mostrar (12.345.678-5)
+func (r Rut) Formatted() string {
+	body, checkDigit, found := strings.Cut(string(r), "-")
+	if !found {
+		return string(r)
+	}
+	var groups []string
+	for len(body) > 3 {
+		groups = append([]string{body[len(body)-3:]}, groups...)
+		body = body[:len(body)-3]
+	}
+	groups = append([]string{body}, groups...)
+	return strings.Join(groups, ".") + "-" + checkDigit
+}
+
+// Módulo 11 con factores 2..7 desde el dígito menos significativo
+func rutCheckDigit(body string) string {
+	sum, factor := 0, 2
+	for i := len(body) - 1; i >= 0; i-- {
+		sum += int(body[i]-'0') * factor
+		factor++
+		if factor > 7 {
+			factor = 2
+		}
+	}
+	switch remainder := 11 - sum%11; remainder {
+	case 11:
+		return "0"
+	case 10:
+		return "K"
+	default:
+		return fmt.Sprint(remainder)
+	}
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRut(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want Rut
+	}{
+		{name: "canónico", raw: "12345678-5", want: "12345678-5"},
+		{name: "dígito K en minúscula", raw: "10000013-k", want: "10000013-K"},
+		{name: "dígito 0", raw: "10000004-0", want: "10000004-0"},
+		{name: "con puntos", raw: "12.345.678-5", want: "12345678-5"},
+		{name: "con espacios", raw: " 12 345 678 - 5 ", want: "12345678-5"},
+		{name: "sin guion", raw: "123456785", want: "12345678-5"},
+		{name: "cuerpo de siete dígitos", raw: "1.000.000-9", want: "1000000-9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRut(tt.raw)
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("se esperaba %s, obtuvo %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseRutRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "dígito verificador incorrecto", raw: "12345678-9"},
+		{name: "K donde corresponde número", raw: "12345678-K"},
+		{name: "vacío", raw: ""},
+		{name: "cuerpo demasiado corto", raw: "123456-7"},
+		{name: "letras en el cuerpo", raw: "1234A678-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := ParseRut(tt.raw); !errors.Is(err, ErrInvalidRut) {
+				t.Fatalf("se esperaba ErrInvalidRut, obtuvo %q, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestRutCheckDigit(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{body: "12345678", want: "5"},
+		{body: "10000013", want: "K"},
+		{body: "10000004", want: "0"},
+		{body: "1000000", want: "9"},
+	}
+
+	for _, tt := range tests {
+		if got := rutCheckDigit(tt.body); got != tt.want {
+			t.Errorf("rutCheckDigit(%s) = %s, se esperaba %s", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestRutFormatted(t *testing.T) {
+	if got := Rut("12345678-5").Formatted(); got != "12.345.678-5" {
+		t.Fatalf("se esperaba 12.345.678-5, obtuvo %s", got)
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+	_ "time/tzdata"
+)
+
+const defaultCampusTimeZone = "America/Santiago"
+
+var (
+	campusLocation     *time.Location
+	campusLocationOnce sync.Once
+)
+
+// Zona horaria del campus, usada para definir qué es "un día" independiente de la zona del contenedor
+func CampusLocation() *time.Location {
+	campusLocationOnce.Do(func() {
+		name := os.Getenv("CAMPUS_TIMEZONE")
+		if name == "" {
+			name = defaultCampusTimeZone
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			log.Printf("Zona horaria %q inválida, se usa %s: %v", name, defaultCampusTimeZone, err)
+			loc, _ = time.LoadLocation(defaultCampusTimeZone)
+		}
+		campusLocation = loc
+	})
+	return campusLocation
+}
+
+func InCampus(t time.Time) time.Time {
+	return t.In(CampusLocation())
+}
+
+// Devuelve el rango [inicio, fin) del día del campus; no siempre dura 24 horas por el horario de verano
+func CampusDayBounds(t time.Time) (time.Time, time.Time) {
+	t = InCampus(t)
+	return campusMidnight(t.Year(), t.Month(), t.Day()), campusMidnight(t.Year(), t.Month(), t.Day()+1)
+}
+
+func ParseCampusDate(dateStr string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return campusMidnight(date.Year(), date.Month(), date.Day()), nil
+}
+
+// En Chile el cambio de hora ocurre a medianoche, así que hay días sin 00:00 local
+func campusMidnight(year int, month time.Month, day int) time.Time {
+	noon := time.Date(year, month, day, 12, 0, 0, 0, CampusLocation())
+	start := time.Date(year, month, day, 0, 0, 0, 0, CampusLocation())
+	for start.Day() != noon.Day() {
+		start = start.Add(time.Hour)
+	}
+	return start
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("fecha de prueba inválida %q: %v", value, err)
+	}
+	return parsed
+}
+
+func TestCampusDayBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		at     string
+		start  string
+		end    string
+		length time.Duration
+	}{
+		{
+			name:   "día normal de invierno",
+			at:     "2024-07-10T12:00:00-04:00",
+			start:  "2024-07-10T00:00:00-04:00",
+			end:    "2024-07-11T00:00:00-04:00",
+			length: 24 * time.Hour,
+		},
+		{
+			name:   "instante UTC que ya es el día siguiente",
+			at:     "2024-07-11T02:00:00Z",
+			start:  "2024-07-10T00:00:00-04:00",
+			end:    "2024-07-11T00:00:00-04:00",
+			length: 24 * time.Hour,
+		},
+		{
+			name:   "víspera del cambio a verano termina a la 01:00",
+			at:     "2024-09-07T20:00:00-04:00",
+			start:  "2024-09-07T00:00:00-04:00",
+			end:    "2024-09-08T01:00:00-03:00",
+			length: 24 * time.Hour,
+		},
+		{
+			name:   "día del cambio a verano sin 00:00 local",
+			at:     "2024-09-08T12:00:00-03:00",
+			start:  "2024-09-08T01:00:00-03:00",
+			end:    "2024-09-09T00:00:00-03:00",
+			length: 23 * time.Hour,
+		},
+		{
+			name:   "día del cambio a invierno con hora repetida",
+			at:     "2024-04-06T23:30:00-04:00",
+			start:  "2024-04-06T00:00:00-03:00",
+			end:    "2024-04-07T00:00:00-04:00",
+			length: 25 * time.Hour,
+		},
+		{
+			name:   "primera 23:00 del cambio a invierno",
+			at:     "2024-04-06T23:30:00-03:00",
+			start:  "2024-04-06T00:00:00-03:00",
+			end:    "2024-04-07T00:00:00-04:00",
+			length: 25 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := CampusDayBounds(mustTime(t, tt.at))
+			if !start.Equal(mustTime(t, tt.start)) {
+				t.Errorf("inicio = %v, se esperaba %s", start, tt.start)
+			}
+			if !end.Equal(mustTime(t, tt.end)) {
+				t.Errorf("fin = %v, se esperaba %s", end, tt.end)
+			}
+			if end.Sub(start) != tt.length {
+				t.Errorf("duración = %v, se esperaba %v", end.Sub(start), tt.length)
+			}
+		})
+	}
+}
+
+func TestParseCampusDate(t *testing.T) {
+	tests := []struct {
+		date    string
+		want    string
+		wantErr bool
+	}{
+		{date: "2024-07-10", want: "2024-07-10T00:00:00-04:00"},
+		{date: "2024-01-15", want: "2024-01-15T00:00:00-03:00"},
+		{date: "2024-09-08", want: "2024-09-08T01:00:00-03:00"},
+		{date: "2024-04-06", want: "2024-04-06T00:00:00-03:00"},
+		{date: "2024-04-07", want: "2024-04-07T00:00:00-04:00"},
+		{date: "2025-09-07", want: "2025-09-07T01:00:00-03:00"},
+		{date: "10-07-2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			got, err := ParseCampusDate(tt.date)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("se esperaba error para %q", tt.date)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if !got.Equal(mustTime(t, tt.want)) {
+				t.Errorf("ParseCampusDate(%q) = %v, se esperaba %s", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "RFC3339 con Z", value: "2024-09-08T04:30:00Z", want: "2024-09-08T04:30:00Z"},
+		{name: "RFC3339 con desfase", value: "2024-04-06T23:30:00-04:00", want: "2024-04-07T03:30:00Z"},
+		{name: "hora local en invierno", value: "2024-07-10T08:00:00", want: "2024-07-10T12:00:00Z"},
+		{name: "hora local en verano", value: "2024-01-15 08:00", want: "2024-01-15T11:00:00Z"},
+		{name: "hora local inexistente al iniciar el verano", value: "2024-09-08T00:30", want: "2024-09-08T04:30:00Z"},
+		{name: "primera hora después del cambio a verano", value: "2024-09-08T01:30:00", want: "2024-09-08T04:30:00Z"},
+		{name: "día después del cambio a verano", value: "2024-09-09T08:00:00", want: "2024-09-09T11:00:00Z"},
+		{name: "hora repetida al terminar el verano usa la primera", value: "2024-04-06T23:30", want: "2024-04-07T02:30:00Z"},
+		{name: "día después del cambio a invierno", value: "2024-04-07T08:00:00", want: "2024-04-07T12:00:00Z"},
+		{name: "formato inválido", value: "08/07/2024 08:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateTime(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("se esperaba error para %q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if !got.Equal(mustTime(t, tt.want)) {
+				t.Errorf("ParseDateTime(%q) = %v, se esperaba %s", tt.value, got, tt.want)
+			}
+			if got.Location() != time.UTC {
+				t.Errorf("ParseDateTime(%q) debe devolver UTC, devolvió %v", tt.value, got.Location())
+			}
+		})
+	}
+}
@@ -1,23 +1,51 @@
 package utils
 
 import (
+	"fmt"
 	"strings"
 	"time"
 )
 
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// Acepta RFC3339 con Z o con desfase; las fechas sin zona se interpretan en la hora del campus
 func ParseDateTime(dateTimeStr string) (time.Time, error) {
-	const layout = "2006-01-02T15:04:05Z"
+	dateTimeStr = strings.TrimSpace(dateTimeStr)
 
-	parsedTime, err := time.Parse(layout, dateTimeStr)
+	for _, layout := range dateTimeLayouts {
+		var parsedTime time.Time
+		var err error
+		if layout == time.RFC3339Nano {
+			parsedTime, err = time.Parse(layout, dateTimeStr)
+		} else {
+			parsedTime, err = parseCampusWallClock(layout, dateTimeStr)
+		}
+		if err == nil {
+			return parsedTime.UTC(), nil
+		}
+	}
 
+	return time.Time{}, fmt.Errorf("fecha y hora inválida %q, use RFC3339 (por ejemplo 2026-03-01T08:00:00-03:00)", dateTimeStr)
+}
+
+// Las horas que no existen al iniciar el horario de verano se corren hacia adelante (00:30 pasa a 01:30)
+func parseCampusWallClock(layout, value string) (time.Time, error) {
+	parsedTime, err := time.ParseInLocation(layout, value, CampusLocation())
 	if err != nil {
 		return time.Time{}, err
 	}
-
-	return parsedTime.UTC(), nil
+	wall, _ := time.Parse(layout, value)
+	local := parsedTime.In(CampusLocation())
+	resolved := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+	return parsedTime.Add(wall.Sub(resolved)), nil
 }
 
-
 func ToCamelCase(s string) string {
 	parts := strings.Split(s, "_")
 	for i, part := range parts {
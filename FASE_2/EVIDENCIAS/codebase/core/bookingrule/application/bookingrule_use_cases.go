@@ -0,0 +1,370 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/bookingrule/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/bookingrule/domain/repositories"
+	calendarApplication "github.com/gonzalohonorato/servercorego/core/calendar/application"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	reservationRepositories "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+)
+
+type BookingRuleError struct {
+	Violations entities.BookingViolations
+}
+
+func (e *BookingRuleError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return "la reserva no cumple las reglas: " + strings.Join(messages, "; ")
+}
+
+type DayClassifier interface {
+	DayType(day time.Time) string
+}
+
+type BookingRuleUsecase struct {
+	BookingRuleRepository repositories.BookingRuleRepository
+	ReservationRepository reservationRepositories.ReservationRepository
+	UserRepository        userRepositories.UserRepository
+	Calendar              DayClassifier
+}
+
+func NewBookingRuleUsecase(
+	bookingRuleRepo repositories.BookingRuleRepository,
+	reservationRepo reservationRepositories.ReservationRepository,
+	userRepo userRepositories.UserRepository,
+	calendarRepo calendarRepositories.CalendarEventRepository,
+) *BookingRuleUsecase {
+	return &BookingRuleUsecase{
+		BookingRuleRepository: bookingRuleRepo,
+		ReservationRepository: reservationRepo,
+		UserRepository:        userRepo,
+		Calendar:              calendarApplication.NewCalendarUsecase(calendarRepo),
+	}
+}
+
+func (uc *BookingRuleUsecase) SearchBookingRuleByID(id int) (*entities.BookingRule, error) {
+	return uc.BookingRuleRepository.SearchBookingRuleByID(id)
+}
+
+func (uc *BookingRuleUsecase) SearchBookingRules() (*entities.BookingRules, error) {
+	return uc.BookingRuleRepository.SearchBookingRules()
+}
+
+func (uc *BookingRuleUsecase) CreateBookingRule(rule *entities.BookingRule) error {
+	if err := validateBookingRule(rule); err != nil {
+		return err
+	}
+	return uc.BookingRuleRepository.CreateBookingRule(rule)
+}
+
+func (uc *BookingRuleUsecase) UpdateBookingRuleByID(rule *entities.BookingRule) error {
+	if err := validateBookingRule(rule); err != nil {
+		return err
+	}
+	return uc.BookingRuleRepository.UpdateBookingRuleByID(rule)
+}
+
+func (uc *BookingRuleUsecase) DeleteBookingRuleByID(id int) error {
+	return uc.BookingRuleRepository.DeleteBookingRuleByID(id)
+}
+
+func (uc *BookingRuleUsecase) RuleForCustomer(customerID string) (*entities.BookingRule, error) {
+	if customerID == "" {
+		return nil, nil
+	}
+
+	user, err := uc.UserRepository.SearchUserByID(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener cliente: %w", err)
+	}
+
+	customerType, employeeRole := "", ""
+	if user.CustomerType != nil {
+		customerType = *user.CustomerType
+	}
+	if user.EmployeeRole != nil {
+		employeeRole = *user.EmployeeRole
+	}
+
+	rules, err := uc.BookingRuleRepository.SearchActiveBookingRules()
+	if err != nil {
+		return nil, err
+	}
+	return SelectBookingRule(*rules, customerType, employeeRole), nil
+}
+
+func (uc *BookingRuleUsecase) ValidateReservation(reservation *reservationEntities.Reservation, now time.Time) error {
+	evaluation, err := uc.EvaluateReservation(reservation, now)
+	if err != nil {
+		return err
+	}
+	if !evaluation.Allowed {
+		return &BookingRuleError{Violations: evaluation.Violations}
+	}
+	return nil
+}
+
+func (uc *BookingRuleUsecase) EvaluateReservation(reservation *reservationEntities.Reservation, now time.Time) (*entities.BookingEvaluation, error) {
+	rule, err := uc.RuleForCustomer(reservation.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation := &entities.BookingEvaluation{
+		Allowed:    true,
+		Rule:       rule,
+		Violations: entities.BookingViolations{},
+	}
+	if rule == nil {
+		return evaluation, nil
+	}
+
+	evaluation.Violations = append(evaluation.Violations, CheckReservationWindow(rule, reservation, now, uc.Calendar)...)
+
+	if rule.MaxConcurrent > 0 || rule.MaxPerWeek > 0 {
+		existing, err := uc.ReservationRepository.SearchReservationsByCustomerIDAndStatus(
+			reservation.CustomerID, []string{"pending", "active", "completed"},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error al obtener reservas del cliente: %w", err)
+		}
+		evaluation.Violations = append(evaluation.Violations, checkReservationCounts(rule, reservation, *existing, now)...)
+	}
+
+	evaluation.Allowed = len(evaluation.Violations) == 0
+	return evaluation, nil
+}
+
+func (uc *BookingRuleUsecase) ValidateExtension(reservation *reservationEntities.Reservation) error {
+	rule, err := uc.RuleForCustomer(reservation.CustomerID)
+	if err != nil || rule == nil {
+		return err
+	}
+
+	violations := CheckReservationSpan(rule, reservation, uc.Calendar)
+	if len(violations) > 0 {
+		return &BookingRuleError{Violations: violations}
+	}
+	return nil
+}
+
+func CheckReservationWindow(
+	rule *entities.BookingRule,
+	reservation *reservationEntities.Reservation,
+	now time.Time,
+	calendar DayClassifier,
+) entities.BookingViolations {
+	violations := entities.BookingViolations{}
+
+	if rule.MaxLeadHours > 0 {
+		lead := reservation.StartTime.Sub(now)
+		if lead > time.Duration(rule.MaxLeadHours)*time.Hour {
+			violations = append(violations, entities.BookingViolation{
+				Code:    entities.ViolationMaxLeadTime,
+				Field:   "startTime",
+				Message: fmt.Sprintf("solo se puede reservar con %d horas de anticipación como máximo", rule.MaxLeadHours),
+				Limit:   rule.MaxLeadHours,
+				Actual:  int(math.Ceil(lead.Hours())),
+			})
+		}
+	}
+
+	return append(violations, CheckReservationSpan(rule, reservation, calendar)...)
+}
+
+func CheckReservationSpan(rule *entities.BookingRule, reservation *reservationEntities.Reservation, calendar DayClassifier) entities.BookingViolations {
+	violations := entities.BookingViolations{}
+
+	minutes := int(reservation.EndTime.Sub(reservation.StartTime).Minutes())
+	if rule.MinDurationMinutes > 0 && minutes < rule.MinDurationMinutes {
+		violations = append(violations, entities.BookingViolation{
+			Code:    entities.ViolationMinDuration,
+			Field:   "endTime",
+			Message: fmt.Sprintf("la reserva debe durar al menos %d minutos", rule.MinDurationMinutes),
+			Limit:   rule.MinDurationMinutes,
+			Actual:  minutes,
+		})
+	}
+	if rule.MaxDurationMinutes > 0 && minutes > rule.MaxDurationMinutes {
+		violations = append(violations, entities.BookingViolation{
+			Code:    entities.ViolationMaxDuration,
+			Field:   "endTime",
+			Message: fmt.Sprintf("la reserva no puede durar más de %d minutos", rule.MaxDurationMinutes),
+			Limit:   rule.MaxDurationMinutes,
+			Actual:  minutes,
+		})
+	}
+
+	for _, day := range reservationDays(reservation.StartTime, reservation.EndTime) {
+		if isBlackoutDay(rule, day, calendar) {
+			violations = append(violations, entities.BookingViolation{
+				Code:    entities.ViolationBlackoutDay,
+				Field:   "startTime",
+				Message: fmt.Sprintf("no se permiten reservas el %s", day.Format("2006-01-02")),
+				Actual:  day.Format("2006-01-02"),
+			})
+		}
+	}
+
+	return violations
+}
+
+func checkReservationCounts(
+	rule *entities.BookingRule,
+	reservation *reservationEntities.Reservation,
+	existing reservationEntities.Reservations,
+	now time.Time,
+) entities.BookingViolations {
+	violations := entities.BookingViolations{}
+	year, week := utils.InCampus(reservation.StartTime).ISOWeek()
+
+	concurrent, weekly := 1, 1
+	for _, other := range existing {
+		if other.ID == reservation.ID {
+			continue
+		}
+		if other.Status != "completed" && other.EndTime.After(now) {
+			concurrent++
+		}
+		if otherYear, otherWeek := utils.InCampus(other.StartTime).ISOWeek(); otherYear == year && otherWeek == week {
+			weekly++
+		}
+	}
+
+	if rule.MaxConcurrent > 0 && concurrent > rule.MaxConcurrent {
+		violations = append(violations, entities.BookingViolation{
+			Code:    entities.ViolationMaxConcurrent,
+			Field:   "customerId",
+			Message: fmt.Sprintf("no puede tener más de %d reservas vigentes", rule.MaxConcurrent),
+			Limit:   rule.MaxConcurrent,
+			Actual:  concurrent,
+		})
+	}
+	if rule.MaxPerWeek > 0 && weekly > rule.MaxPerWeek {
+		violations = append(violations, entities.BookingViolation{
+			Code:    entities.ViolationMaxPerWeek,
+			Field:   "startTime",
+			Message: fmt.Sprintf("no puede tener más de %d reservas en la misma semana", rule.MaxPerWeek),
+			Limit:   rule.MaxPerWeek,
+			Actual:  weekly,
+		})
+	}
+
+	return violations
+}
+
+func SelectBookingRule(rules entities.BookingRules, customerType string, employeeRole string) *entities.BookingRule {
+	var selected *entities.BookingRule
+	bestScore := -1
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.IsActive {
+			continue
+		}
+		if rule.CustomerType != "" && rule.CustomerType != customerType {
+			continue
+		}
+		if rule.EmployeeRole != "" && rule.EmployeeRole != employeeRole {
+			continue
+		}
+
+		score := 0
+		if rule.CustomerType != "" {
+			score++
+		}
+		if rule.EmployeeRole != "" {
+			score++
+		}
+
+		if score > bestScore || (score == bestScore && rule.Priority < selected.Priority) {
+			selected = rule
+			bestScore = score
+		}
+	}
+	return selected
+}
+
+func reservationDays(start, end time.Time) []time.Time {
+	start = utils.InCampus(start)
+	last := end
+	if end.After(start) {
+		last = end.Add(-time.Nanosecond)
+	}
+	lastDate := last.In(start.Location()).Format("2006-01-02")
+
+	days := []time.Time{}
+	for offset := 0; ; offset++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+offset, 12, 0, 0, 0, start.Location())
+		if offset > 0 && day.Format("2006-01-02") > lastDate {
+			break
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+func isBlackoutDay(rule *entities.BookingRule, day time.Time, calendar DayClassifier) bool {
+	for _, weekday := range rule.BlackoutWeekdays {
+		if time.Weekday(weekday) == day.Weekday() {
+			return true
+		}
+	}
+	date := day.Format("2006-01-02")
+	for _, blackout := range rule.BlackoutDates {
+		if blackout == date {
+			return true
+		}
+	}
+	if len(rule.BlackoutDayTypes) > 0 && calendar != nil {
+		dayType := calendar.DayType(day)
+		for _, blackout := range rule.BlackoutDayTypes {
+			if blackout == dayType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validateBookingRule(rule *entities.BookingRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("el nombre de la regla es requerido")
+	}
+	if rule.MaxLeadHours < 0 || rule.MinDurationMinutes < 0 || rule.MaxDurationMinutes < 0 ||
+		rule.MaxConcurrent < 0 || rule.MaxPerWeek < 0 {
+		return fmt.Errorf("los límites de la regla no pueden ser negativos")
+	}
+	if rule.MaxDurationMinutes > 0 && rule.MinDurationMinutes > rule.MaxDurationMinutes {
+		return fmt.Errorf("la duración mínima no puede superar la máxima")
+	}
+	for _, weekday := range rule.BlackoutWeekdays {
+		if weekday < 0 || weekday > 6 {
+			return fmt.Errorf("día de la semana inválido %d, use 0 (domingo) a 6 (sábado)", weekday)
+		}
+	}
+	for _, date := range rule.BlackoutDates {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("fecha bloqueada inválida %q, use YYYY-MM-DD", date)
+		}
+	}
+	if rule.BlackoutDayTypes == nil {
+		rule.BlackoutDayTypes = []string{}
+	}
+	for _, dayType := range rule.BlackoutDayTypes {
+		if !calendarApplication.IsValidDayType(dayType) {
+			return fmt.Errorf("tipo de día bloqueado inválido %q", dayType)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package entities
+
+const (
+	ViolationMaxLeadTime   = "MAX_LEAD_TIME"
+	ViolationMinDuration   = "MIN_DURATION"
+	ViolationMaxDuration   = "MAX_DURATION"
+	ViolationMaxConcurrent = "MAX_CONCURRENT"
+	ViolationMaxPerWeek    = "MAX_PER_WEEK"
+	ViolationBlackoutDay   = "BLACKOUT_DAY"
+)
+
+type BookingRule struct {
+	ID                 int      `json:"id"`
+	Name               string   `json:"name"`
+	CustomerType       string   `json:"customerType"`
+	EmployeeRole       string   `json:"employeeRole"`
+	MaxLeadHours       int      `json:"maxLeadHours"`
+	MinDurationMinutes int      `json:"minDurationMinutes"`
+	MaxDurationMinutes int      `json:"maxDurationMinutes"`
+	MaxConcurrent      int      `json:"maxConcurrent"`
+	MaxPerWeek         int      `json:"maxPerWeek"`
+	BlackoutWeekdays   []int    `json:"blackoutWeekdays"`
+	BlackoutDates      []string `json:"blackoutDates"`
+	BlackoutDayTypes   []string `json:"blackoutDayTypes"`
+	Priority           int      `json:"priority"`
+	IsActive           bool     `json:"isActive"`
+}
+
+type BookingRules []BookingRule
+
+type BookingViolation struct {
+	Code    string      `json:"code"`
+	Field   string      `json:"field"`
+	Message string      `json:"message"`
+	Limit   interface{} `json:"limit,omitempty"`
+	Actual  interface{} `json:"actual,omitempty"`
+}
+
+type BookingViolations []BookingViolation
+
+type BookingEvaluation struct {
+	Allowed    bool              `json:"allowed"`
+	Rule       *BookingRule      `json:"rule"`
+	Violations BookingViolations `json:"violations"`
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/bookingrule/domain/entities"
+
+type BookingRuleRepository interface {
+	SearchBookingRuleByID(id int) (*entities.BookingRule, error)
+	SearchBookingRules() (*entities.BookingRules, error)
+	SearchActiveBookingRules() (*entities.BookingRules, error)
+	CreateBookingRule(rule *entities.BookingRule) error
+	UpdateBookingRuleByID(rule *entities.BookingRule) error
+	DeleteBookingRuleByID(id int) error
+}
@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/bookingrule/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleBookingRuleRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleBookingRuleRepository(pool *pgxpool.Pool) *TimescaleBookingRuleRepository {
+	return &TimescaleBookingRuleRepository{
+		dbPool: pool,
+	}
+}
+
+const bookingRuleColumns = `id, name, customer_type, employee_role, max_lead_hours, min_duration_minutes,
+	max_duration_minutes, max_concurrent, max_per_week, blackout_weekdays, blackout_dates::text[], blackout_day_types,
+	priority, is_active`
+
+func (r *TimescaleBookingRuleRepository) SearchBookingRuleByID(id int) (*entities.BookingRule, error) {
+	rules, err := r.searchBookingRules(`SELECT `+bookingRuleColumns+` FROM booking_rule WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(*rules) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	return &(*rules)[0], nil
+}
+
+func (r *TimescaleBookingRuleRepository) SearchBookingRules() (*entities.BookingRules, error) {
+	return r.searchBookingRules(`SELECT ` + bookingRuleColumns + ` FROM booking_rule ORDER BY priority, id`)
+}
+
+func (r *TimescaleBookingRuleRepository) SearchActiveBookingRules() (*entities.BookingRules, error) {
+	return r.searchBookingRules(`SELECT ` + bookingRuleColumns + ` FROM booking_rule WHERE is_active = TRUE ORDER BY priority, id`)
+}
+
+func (r *TimescaleBookingRuleRepository) searchBookingRules(query string, args ...interface{}) (*entities.BookingRules, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := entities.BookingRules{}
+	for rows.Next() {
+		var b entities.BookingRule
+		if err := rows.Scan(&b.ID, &b.Name, &b.CustomerType, &b.EmployeeRole, &b.MaxLeadHours, &b.MinDurationMinutes,
+			&b.MaxDurationMinutes, &b.MaxConcurrent, &b.MaxPerWeek, &b.BlackoutWeekdays, &b.BlackoutDates,
+			&b.BlackoutDayTypes, &b.Priority, &b.IsActive); err != nil {
+			return nil, err
+		}
+		rules = append(rules, b)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+func (r *TimescaleBookingRuleRepository) CreateBookingRule(rule *entities.BookingRule) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO booking_rule (
+		name, customer_type, employee_role, max_lead_hours, min_duration_minutes, max_duration_minutes,
+		max_concurrent, max_per_week, blackout_weekdays, blackout_dates, blackout_day_types, priority, is_active
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10::date[], $11, $12, $13
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		rule.Name, rule.CustomerType, rule.EmployeeRole, rule.MaxLeadHours, rule.MinDurationMinutes,
+		rule.MaxDurationMinutes, rule.MaxConcurrent, rule.MaxPerWeek, rule.BlackoutWeekdays, rule.BlackoutDates,
+		rule.BlackoutDayTypes, rule.Priority, rule.IsActive).Scan(&rule.ID)
+}
+
+func (r *TimescaleBookingRuleRepository) UpdateBookingRuleByID(rule *entities.BookingRule) error {
+	ctx := context.Background()
+	query := `
+	UPDATE booking_rule SET
+		name = $1, customer_type = $2, employee_role = $3, max_lead_hours = $4, min_duration_minutes = $5,
+		max_duration_minutes = $6, max_concurrent = $7, max_per_week = $8, blackout_weekdays = $9,
+		blackout_dates = $10::date[], blackout_day_types = $11, priority = $12, is_active = $13
+	WHERE id = $14;
+`
+	_, err := r.dbPool.Exec(ctx, query,
+		rule.Name, rule.CustomerType, rule.EmployeeRole, rule.MaxLeadHours, rule.MinDurationMinutes,
+		rule.MaxDurationMinutes, rule.MaxConcurrent, rule.MaxPerWeek, rule.BlackoutWeekdays, rule.BlackoutDates,
+		rule.BlackoutDayTypes, rule.Priority, rule.IsActive, rule.ID)
+	return err
+}
+
+func (r *TimescaleBookingRuleRepository) DeleteBookingRuleByID(id int) error {
+	ctx := context.Background()
+	query := `DELETE FROM booking_rule WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, id)
+	return err
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/bookingrule/application"
+	"github.com/gonzalohonorato/servercorego/core/bookingrule/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/bookingrule/domain/repositories"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	reservationRepositories "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+type BookingRuleController struct {
+	BookingRuleUsecase *application.BookingRuleUsecase
+}
+
+func NewBookingRuleController(
+	bookingRuleRepository repositories.BookingRuleRepository,
+	reservationRepository reservationRepositories.ReservationRepository,
+	userRepository userRepositories.UserRepository,
+	calendarEventRepository calendarRepositories.CalendarEventRepository,
+) *BookingRuleController {
+	bookingRuleUseCase := application.NewBookingRuleUsecase(
+		bookingRuleRepository,
+		reservationRepository,
+		userRepository,
+		calendarEventRepository,
+	)
+
+	return &BookingRuleController{
+		BookingRuleUsecase: bookingRuleUseCase,
+	}
+}
+
+func (uc *BookingRuleController) GetBookingRuleByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	rule, err := uc.BookingRuleUsecase.SearchBookingRuleByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Booking rule not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rule)
+}
+
+func (uc *BookingRuleController) GetBookingRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := uc.BookingRuleUsecase.SearchBookingRules()
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Booking rules not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rules)
+}
+
+func (uc *BookingRuleController) PostBookingRule(w http.ResponseWriter, r *http.Request) {
+	var newRule entities.BookingRule
+	if err := json.NewDecoder(r.Body).Decode(&newRule); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.BookingRuleUsecase.CreateBookingRule(&newRule); err != nil {
+		http.Error(w, "Error creating booking rule: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newRule)
+}
+
+func (uc *BookingRuleController) PutBookingRule(w http.ResponseWriter, r *http.Request) {
+	var rule entities.BookingRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.BookingRuleUsecase.UpdateBookingRuleByID(&rule); err != nil {
+		http.Error(w, "Error update booking rule: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *BookingRuleController) DeleteBookingRuleByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.BookingRuleUsecase.DeleteBookingRuleByID(idInt); err != nil {
+		http.Error(w, "Error deleting booking rule", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *BookingRuleController) PostBookingRuleEvaluation(w http.ResponseWriter, r *http.Request) {
+	var reservation reservationEntities.Reservation
+	if err := json.NewDecoder(r.Body).Decode(&reservation); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	evaluation, err := uc.BookingRuleUsecase.EvaluateReservation(&reservation, time.Now())
+	if err != nil {
+		http.Error(w, "Error evaluating booking rules: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(evaluation)
+}
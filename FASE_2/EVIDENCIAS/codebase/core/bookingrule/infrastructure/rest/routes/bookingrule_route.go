@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/bookingrule/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func BookingRuleRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewBookingRuleController(
+		container.ProvideBookingRuleRepository(),
+		container.ProvideReservationRepository(),
+		container.ProvideUserRepository(),
+		container.ProvideCalendarEventRepository(),
+	)
+
+	router.HandleFunc("/booking-rules", controller.PutBookingRule).Methods("PUT")
+	router.HandleFunc("/booking-rules", controller.GetBookingRules).Methods("GET")
+	router.HandleFunc("/booking-rules/evaluate", controller.PostBookingRuleEvaluation).Methods("POST")
+	router.HandleFunc("/booking-rules/{id}", controller.DeleteBookingRuleByID).Methods("DELETE")
+	router.HandleFunc("/booking-rules/{id}", controller.GetBookingRuleByID).Methods("GET")
+	router.HandleFunc("/booking-rules", controller.PostBookingRule).Methods("POST")
+}
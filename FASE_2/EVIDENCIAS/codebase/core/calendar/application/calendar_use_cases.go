@@ -0,0 +1,229 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/calendar/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+)
+
+const dateLayout = "2006-01-02"
+
+var dayTypePrecedence = []string{
+	entities.DayTypeHoliday,
+	entities.DayTypeRecess,
+	entities.DayTypeExam,
+}
+
+type CalendarUsecase struct {
+	CalendarEventRepository repositories.CalendarEventRepository
+}
+
+func NewCalendarUsecase(calendarEventRepo repositories.CalendarEventRepository) *CalendarUsecase {
+	return &CalendarUsecase{
+		CalendarEventRepository: calendarEventRepo,
+	}
+}
+
+func IsValidEventType(eventType string) bool {
+	switch eventType {
+	case entities.DayTypeHoliday, entities.DayTypeRecess, entities.DayTypeExam, entities.DayTypeClass:
+		return true
+	}
+	return false
+}
+
+func IsValidDayType(dayType string) bool {
+	return IsValidEventType(dayType) || dayType == entities.DayTypeWeekend
+}
+
+func (uc *CalendarUsecase) SearchCalendarEventByID(id int) (*entities.CalendarEvent, error) {
+	return uc.CalendarEventRepository.SearchCalendarEventByID(id)
+}
+
+func (uc *CalendarUsecase) SearchCalendarEvents() (*entities.CalendarEvents, error) {
+	return uc.CalendarEventRepository.SearchCalendarEvents()
+}
+
+func (uc *CalendarUsecase) SearchCalendarEventsBetween(from, to time.Time) (*entities.CalendarEvents, error) {
+	return uc.CalendarEventRepository.SearchCalendarEventsBetween(
+		utils.InCampus(from).Format(dateLayout), utils.InCampus(to).Format(dateLayout))
+}
+
+func (uc *CalendarUsecase) CreateCalendarEvent(event *entities.CalendarEvent) error {
+	if event.Source == "" {
+		event.Source = entities.EventSourceManual
+	}
+	if err := validateCalendarEvent(event); err != nil {
+		return err
+	}
+	event.CreatedAt = time.Now()
+	return uc.CalendarEventRepository.CreateCalendarEvent(event)
+}
+
+func (uc *CalendarUsecase) UpdateCalendarEvent(event *entities.CalendarEvent) error {
+	current, err := uc.CalendarEventRepository.SearchCalendarEventByID(event.ID)
+	if err != nil {
+		return fmt.Errorf("evento de calendario no encontrado: %w", err)
+	}
+	if event.Source == "" {
+		event.Source = current.Source
+	}
+	if event.ExternalUID == "" {
+		event.ExternalUID = current.ExternalUID
+	}
+	if err := validateCalendarEvent(event); err != nil {
+		return err
+	}
+	return uc.CalendarEventRepository.UpdateCalendarEventByID(event)
+}
+
+func (uc *CalendarUsecase) DeleteCalendarEventByID(id int) error {
+	return uc.CalendarEventRepository.DeleteCalendarEventByID(id)
+}
+
+func validateCalendarEvent(event *entities.CalendarEvent) error {
+	if strings.TrimSpace(event.Name) == "" {
+		return fmt.Errorf("el nombre del evento es obligatorio")
+	}
+	if !IsValidEventType(event.EventType) {
+		return fmt.Errorf("tipo de evento inválido: %s", event.EventType)
+	}
+	start, err := time.Parse(dateLayout, event.StartDate)
+	if err != nil {
+		return fmt.Errorf("fecha de inicio inválida, se espera YYYY-MM-DD")
+	}
+	if event.EndDate == "" {
+		event.EndDate = event.StartDate
+	}
+	end, err := time.Parse(dateLayout, event.EndDate)
+	if err != nil {
+		return fmt.Errorf("fecha de término inválida, se espera YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return fmt.Errorf("la fecha de término debe ser igual o posterior a la de inicio")
+	}
+	return nil
+}
+
+func (uc *CalendarUsecase) DayType(day time.Time) string {
+	info, err := uc.DayInfo(day)
+	if err != nil {
+		log.Printf("Error al consultar calendario para %s: %v", utils.InCampus(day).Format(dateLayout), err)
+		return weekdayType(utils.InCampus(day))
+	}
+	return info.DayType
+}
+
+func (uc *CalendarUsecase) IsHoliday(day time.Time) bool {
+	return uc.DayType(day) == entities.DayTypeHoliday
+}
+
+func (uc *CalendarUsecase) DayInfo(day time.Time) (*entities.CalendarDay, error) {
+	days, err := uc.SearchCalendarDays(day, day)
+	if err != nil {
+		return nil, err
+	}
+	return &(*days)[0], nil
+}
+
+func (uc *CalendarUsecase) SearchCalendarDays(from, to time.Time) (*entities.CalendarDays, error) {
+	from, to = utils.InCampus(from), utils.InCampus(to)
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if to.Before(from) {
+		return nil, fmt.Errorf("el rango de fechas es inválido")
+	}
+
+	events, err := uc.CalendarEventRepository.SearchCalendarEventsBetween(from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	days := entities.CalendarDays{}
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format(dateLayout)
+		day := entities.CalendarDay{Date: date, Events: entities.CalendarEvents{}}
+		for _, event := range *events {
+			if event.Covers(date) {
+				day.Events = append(day.Events, event)
+			}
+		}
+		day.DayType = classifyDay(d, day.Events)
+		days = append(days, day)
+	}
+	return &days, nil
+}
+
+func classifyDay(day time.Time, events entities.CalendarEvents) string {
+	for _, dayType := range dayTypePrecedence {
+		for _, event := range events {
+			if event.EventType == dayType {
+				return dayType
+			}
+		}
+	}
+	return weekdayType(day)
+}
+
+func weekdayType(day time.Time) string {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return entities.DayTypeWeekend
+	}
+	return entities.DayTypeClass
+}
+
+func (uc *CalendarUsecase) ImportICS(reader io.Reader, defaultType string) (*entities.ICSImportResult, error) {
+	if defaultType == "" {
+		defaultType = entities.DayTypeHoliday
+	}
+	if !IsValidEventType(defaultType) {
+		return nil, fmt.Errorf("tipo de evento por defecto inválido: %s", defaultType)
+	}
+
+	parsed, err := ParseICS(reader, defaultType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entities.ICSImportResult{Errors: []string{}}
+	for _, event := range parsed {
+		event.Source = entities.EventSourceICS
+		if err := validateCalendarEvent(&event); err != nil {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", event.ExternalUID, err))
+			continue
+		}
+
+		var existing *entities.CalendarEvent
+		if event.ExternalUID != "" {
+			existing, err = uc.CalendarEventRepository.SearchCalendarEventByExternalUID(event.ExternalUID)
+			if err != nil {
+				return result, err
+			}
+		}
+
+		if existing != nil {
+			event.ID = existing.ID
+			if err := uc.CalendarEventRepository.UpdateCalendarEventByID(&event); err != nil {
+				return result, err
+			}
+			result.Updated++
+			continue
+		}
+
+		event.CreatedAt = time.Now()
+		if err := uc.CalendarEventRepository.CreateCalendarEvent(&event); err != nil {
+			return result, err
+		}
+		result.Created++
+	}
+
+	log.Printf("Importación ICS: %d creados, %d actualizados, %d omitidos", result.Created, result.Updated, result.Skipped)
+	return result, nil
+}
@@ -0,0 +1,152 @@
+package application
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/calendar/domain/entities"
+)
+
+var icsTypeKeywords = []struct {
+	keyword   string
+	eventType string
+}{
+	{"feriado", entities.DayTypeHoliday},
+	{"holiday", entities.DayTypeHoliday},
+	{"examen", entities.DayTypeExam},
+	{"exámen", entities.DayTypeExam},
+	{"certamen", entities.DayTypeExam},
+	{"exam", entities.DayTypeExam},
+	{"receso", entities.DayTypeRecess},
+	{"vacaciones", entities.DayTypeRecess},
+	{"recess", entities.DayTypeRecess},
+}
+
+func ParseICS(reader io.Reader, defaultType string) ([]entities.CalendarEvent, error) {
+	lines, err := unfoldICSLines(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []entities.CalendarEvent{}
+	var current map[string]string
+	var dateOnlyEnd bool
+	for _, line := range lines {
+		switch {
+		case line == "BEGIN:VEVENT":
+			current = map[string]string{}
+			dateOnlyEnd = false
+			continue
+		case line == "END:VEVENT":
+			if current == nil {
+				continue
+			}
+			event, err := buildICSEvent(current, dateOnlyEnd, defaultType)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+			current = nil
+			continue
+		case current == nil:
+			continue
+		}
+
+		sep := strings.Index(line, ":")
+		if sep < 0 {
+			continue
+		}
+		name, value := line[:sep], line[sep+1:]
+		params := ""
+		if i := strings.Index(name, ";"); i >= 0 {
+			name, params = name[:i], name[i+1:]
+		}
+		name = strings.ToUpper(name)
+		if name == "DTEND" {
+			dateOnlyEnd = strings.Contains(strings.ToUpper(params), "VALUE=DATE") || len(value) == 8
+		}
+		current[name] = unescapeICSText(value)
+	}
+	return events, nil
+}
+
+func unfoldICSLines(reader io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lines := []string{}
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer archivo ICS: %w", err)
+	}
+	return lines, nil
+}
+
+func buildICSEvent(fields map[string]string, dateOnlyEnd bool, defaultType string) (entities.CalendarEvent, error) {
+	start, err := parseICSDate(fields["DTSTART"])
+	if err != nil {
+		return entities.CalendarEvent{}, fmt.Errorf("DTSTART inválido en evento %q: %w", fields["UID"], err)
+	}
+
+	end := start
+	if fields["DTEND"] != "" {
+		end, err = parseICSDate(fields["DTEND"])
+		if err != nil {
+			return entities.CalendarEvent{}, fmt.Errorf("DTEND inválido en evento %q: %w", fields["UID"], err)
+		}
+		// En eventos de día completo DTEND es exclusivo
+		if dateOnlyEnd && end.After(start) {
+			end = end.AddDate(0, 0, -1)
+		}
+	}
+
+	return entities.CalendarEvent{
+		Name:        fields["SUMMARY"],
+		EventType:   icsEventType(fields["CATEGORIES"]+" "+fields["SUMMARY"], defaultType),
+		StartDate:   start.Format(dateLayout),
+		EndDate:     end.Format(dateLayout),
+		ExternalUID: fields["UID"],
+	}, nil
+}
+
+func parseICSDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("fecha vacía o incompleta")
+	}
+	// Las horas en UTC se llevan a la zona del campus antes de tomar la fecha
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return utils.InCampus(parsed), nil
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+func icsEventType(text, defaultType string) string {
+	text = strings.ToLower(text)
+	for _, k := range icsTypeKeywords {
+		if strings.Contains(text, k.keyword) {
+			return k.eventType
+		}
+	}
+	return defaultType
+}
+
+func unescapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
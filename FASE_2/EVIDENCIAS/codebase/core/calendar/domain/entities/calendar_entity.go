@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+const (
+	DayTypeHoliday = "holiday"
+	DayTypeRecess  = "recess"
+	DayTypeExam    = "exam"
+	DayTypeClass   = "class"
+	DayTypeWeekend = "weekend"
+)
+
+const (
+	EventSourceManual = "manual"
+	EventSourceICS    = "ics"
+	EventSourceSeed   = "seed"
+)
+
+type CalendarEvent struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	EventType   string    `json:"eventType"`
+	StartDate   string    `json:"startDate"`
+	EndDate     string    `json:"endDate"`
+	Source      string    `json:"source"`
+	ExternalUID string    `json:"externalUid"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type CalendarEvents []CalendarEvent
+
+func (e *CalendarEvent) Covers(date string) bool {
+	return e.StartDate <= date && date <= e.EndDate
+}
+
+type CalendarDay struct {
+	Date    string         `json:"date"`
+	DayType string         `json:"dayType"`
+	Events  CalendarEvents `json:"events"`
+}
+
+type CalendarDays []CalendarDay
+
+type ICSImportResult struct {
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors"`
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/calendar/domain/entities"
+
+type CalendarEventRepository interface {
+	SearchCalendarEventByID(id int) (*entities.CalendarEvent, error)
+	SearchCalendarEvents() (*entities.CalendarEvents, error)
+	SearchCalendarEventsBetween(startDate, endDate string) (*entities.CalendarEvents, error)
+	SearchCalendarEventByExternalUID(uid string) (*entities.CalendarEvent, error)
+	CreateCalendarEvent(event *entities.CalendarEvent) error
+	UpdateCalendarEventByID(event *entities.CalendarEvent) error
+	DeleteCalendarEventByID(id int) error
+}
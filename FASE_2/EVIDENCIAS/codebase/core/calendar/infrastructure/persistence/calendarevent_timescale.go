@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/calendar/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleCalendarEventRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleCalendarEventRepository(pool *pgxpool.Pool) *TimescaleCalendarEventRepository {
+	return &TimescaleCalendarEventRepository{
+		dbPool: pool,
+	}
+}
+
+const calendarEventColumns = `id, name, event_type, to_char(start_date, 'YYYY-MM-DD'), to_char(end_date, 'YYYY-MM-DD'),
+	source, external_uid, created_at`
+
+func (r *TimescaleCalendarEventRepository) SearchCalendarEventByID(id int) (*entities.CalendarEvent, error) {
+	query := `SELECT ` + calendarEventColumns + ` FROM calendar_event WHERE id = $1`
+	return r.searchCalendarEvent(query, id)
+}
+
+func (r *TimescaleCalendarEventRepository) SearchCalendarEvents() (*entities.CalendarEvents, error) {
+	return r.searchCalendarEvents(`SELECT ` + calendarEventColumns + ` FROM calendar_event ORDER BY start_date, id`)
+}
+
+func (r *TimescaleCalendarEventRepository) SearchCalendarEventsBetween(startDate, endDate string) (*entities.CalendarEvents, error) {
+	query := `SELECT ` + calendarEventColumns + ` FROM calendar_event
+	WHERE start_date <= $2::date AND end_date >= $1::date ORDER BY start_date, id`
+	return r.searchCalendarEvents(query, startDate, endDate)
+}
+
+func (r *TimescaleCalendarEventRepository) SearchCalendarEventByExternalUID(uid string) (*entities.CalendarEvent, error) {
+	event, err := r.searchCalendarEvent(`SELECT `+calendarEventColumns+` FROM calendar_event WHERE external_uid = $1`, uid)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return event, err
+}
+
+func (r *TimescaleCalendarEventRepository) searchCalendarEvent(query string, args ...interface{}) (*entities.CalendarEvent, error) {
+	ctx := context.Background()
+	var e entities.CalendarEvent
+	err := r.dbPool.QueryRow(ctx, query, args...).Scan(&e.ID, &e.Name, &e.EventType, &e.StartDate, &e.EndDate,
+		&e.Source, &e.ExternalUID, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *TimescaleCalendarEventRepository) searchCalendarEvents(query string, args ...interface{}) (*entities.CalendarEvents, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := entities.CalendarEvents{}
+	for rows.Next() {
+		var e entities.CalendarEvent
+		if err := rows.Scan(&e.ID, &e.Name, &e.EventType, &e.StartDate, &e.EndDate,
+			&e.Source, &e.ExternalUID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
+
+func (r *TimescaleCalendarEventRepository) CreateCalendarEvent(event *entities.CalendarEvent) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO calendar_event (
+		name, event_type, start_date, end_date, source, external_uid, created_at
+	) VALUES (
+		$1, $2, $3::date, $4::date, $5, $6, $7
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		event.Name, event.EventType, event.StartDate, event.EndDate, event.Source, event.ExternalUID, event.CreatedAt,
+	).Scan(&event.ID)
+}
+
+func (r *TimescaleCalendarEventRepository) UpdateCalendarEventByID(event *entities.CalendarEvent) error {
+	ctx := context.Background()
+	query := `UPDATE calendar_event SET
+		name = $2, event_type = $3, start_date = $4::date, end_date = $5::date, source = $6, external_uid = $7
+	WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query,
+		event.ID, event.Name, event.EventType, event.StartDate, event.EndDate, event.Source, event.ExternalUID)
+	return err
+}
+
+func (r *TimescaleCalendarEventRepository) DeleteCalendarEventByID(id int) error {
+	ctx := context.Background()
+	_, err := r.dbPool.Exec(ctx, `DELETE FROM calendar_event WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/calendar/application"
+	"github.com/gonzalohonorato/servercorego/core/calendar/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+const maxICSUploadSize = 5 << 20
+
+type CalendarController struct {
+	CalendarUsecase *application.CalendarUsecase
+}
+
+func NewCalendarController(calendarEventRepository repositories.CalendarEventRepository) *CalendarController {
+	return &CalendarController{
+		CalendarUsecase: application.NewCalendarUsecase(calendarEventRepository),
+	}
+}
+
+func parseDateRange(r *http.Request) (time.Time, time.Time, error) {
+	from, errFrom := utils.ParseCampusDate(r.URL.Query().Get("from"))
+	to, errTo := utils.ParseCampusDate(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil || to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("parámetros from y to inválidos, se espera YYYY-MM-DD")
+	}
+	return from, to, nil
+}
+
+func (uc *CalendarController) GetCalendarEventByID(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	event, err := uc.CalendarUsecase.SearchCalendarEventByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Calendar event not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(event)
+}
+
+func (uc *CalendarController) GetCalendarEvents(w http.ResponseWriter, r *http.Request) {
+	var events *entities.CalendarEvents
+	var err error
+	if r.URL.Query().Get("from") != "" || r.URL.Query().Get("to") != "" {
+		from, to, rangeErr := parseDateRange(r)
+		if rangeErr != nil {
+			http.Error(w, rangeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		events, err = uc.CalendarUsecase.SearchCalendarEventsBetween(from, to)
+	} else {
+		events, err = uc.CalendarUsecase.SearchCalendarEvents()
+	}
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Calendar events not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(events)
+}
+
+func (uc *CalendarController) GetCalendarDays(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if to.Sub(from) > 366*24*time.Hour {
+		http.Error(w, "El rango no puede superar un año", http.StatusBadRequest)
+		return
+	}
+	days, err := uc.CalendarUsecase.SearchCalendarDays(from, to)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Error al consultar calendario", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(days)
+}
+
+func (uc *CalendarController) PostCalendarEvent(w http.ResponseWriter, r *http.Request) {
+	var event entities.CalendarEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.CalendarUsecase.CreateCalendarEvent(&event); err != nil {
+		http.Error(w, "Error creating calendar event: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(event)
+}
+
+func (uc *CalendarController) PutCalendarEvent(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var event entities.CalendarEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	event.ID = idInt
+
+	if err := uc.CalendarUsecase.UpdateCalendarEvent(&event); err != nil {
+		http.Error(w, "Error updating calendar event: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(event)
+}
+
+func (uc *CalendarController) DeleteCalendarEventByID(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	if err := uc.CalendarUsecase.DeleteCalendarEventByID(idInt); err != nil {
+		fmt.Println(err)
+		http.Error(w, "Calendar event not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (uc *CalendarController) PostCalendarImport(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxICSUploadSize)
+
+	var reader io.Reader = r.Body
+	if err := r.ParseMultipartForm(maxICSUploadSize); err == nil {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "Archivo ICS requerido en el campo file", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	result, err := uc.CalendarUsecase.ImportICS(reader, r.URL.Query().Get("defaultType"))
+	if err != nil {
+		http.Error(w, "Error al importar calendario: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
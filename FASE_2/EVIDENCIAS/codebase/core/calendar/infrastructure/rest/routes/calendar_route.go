@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/calendar/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func CalendarRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewCalendarController(container.ProvideCalendarEventRepository())
+
+	router.HandleFunc("/calendar/events", controller.GetCalendarEvents).Methods("GET")
+	router.HandleFunc("/calendar/events", controller.PostCalendarEvent).Methods("POST")
+	router.HandleFunc("/calendar/events/{id}", controller.GetCalendarEventByID).Methods("GET")
+	router.HandleFunc("/calendar/events/{id}", controller.PutCalendarEvent).Methods("PUT")
+	router.HandleFunc("/calendar/events/{id}", controller.DeleteCalendarEventByID).Methods("DELETE")
+	router.HandleFunc("/calendar/import", controller.PostCalendarImport).Methods("POST")
+	router.HandleFunc("/calendar/days", controller.GetCalendarDays).Methods("GET")
+}
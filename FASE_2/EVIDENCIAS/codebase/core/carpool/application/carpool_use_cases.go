@@ -0,0 +1,280 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/carpool/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/carpool/domain/repositories"
+	parkingUsageEntities "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
+	parkingUsageRepositories "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	reservationApplication "github.com/gonzalohonorato/servercorego/core/reservation/application"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+)
+
+var ErrCarpoolNotFound = errors.New("grupo de carpool no encontrado")
+
+type CarpoolUsecase struct {
+	CarpoolRepository            repositories.CarpoolRepository
+	CarpoolReservationRepository repositories.CarpoolReservationRepository
+	ParkingUsageRepository       parkingUsageRepositories.ParkingUsageRepository
+	VehicleRepository            vehicleRepositories.VehicleRepository
+	UserRepository               userRepositories.UserRepository
+	ReservationUsecase           *reservationApplication.ReservationUsecase
+	WebSocketService             *infrastructure.WebSocketService
+}
+
+func NewCarpoolUsecase(
+	carpoolRepo repositories.CarpoolRepository,
+	carpoolReservationRepo repositories.CarpoolReservationRepository,
+	parkingUsageRepo parkingUsageRepositories.ParkingUsageRepository,
+	vehicleRepo vehicleRepositories.VehicleRepository,
+	userRepo userRepositories.UserRepository,
+	reservationUsecase *reservationApplication.ReservationUsecase,
+	wsService *infrastructure.WebSocketService,
+) *CarpoolUsecase {
+	return &CarpoolUsecase{
+		CarpoolRepository:            carpoolRepo,
+		CarpoolReservationRepository: carpoolReservationRepo,
+		ParkingUsageRepository:       parkingUsageRepo,
+		VehicleRepository:            vehicleRepo,
+		UserRepository:               userRepo,
+		ReservationUsecase:           reservationUsecase,
+		WebSocketService:             wsService,
+	}
+}
+
+// Ocupantes mínimos (conductor incluido) para que una reserva cuente como carpool
+func CarpoolMinOccupants() int {
+	if occupants, err := strconv.Atoi(os.Getenv("CARPOOL_MIN_OCCUPANTS")); err == nil && occupants > 1 {
+		return occupants
+	}
+	return 2
+}
+
+func (uc *CarpoolUsecase) SearchCarpoolByID(id int) (*entities.Carpool, error) {
+	carpool, err := uc.CarpoolRepository.SearchCarpoolByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if carpool == nil {
+		return nil, ErrCarpoolNotFound
+	}
+	return carpool, nil
+}
+
+func (uc *CarpoolUsecase) SearchCarpoolsByMemberID(customerID string) (*entities.Carpools, error) {
+	return uc.CarpoolRepository.SearchCarpoolsByMemberID(customerID)
+}
+
+func (uc *CarpoolUsecase) CreateCarpool(carpool *entities.Carpool) error {
+	carpool.Name = strings.TrimSpace(carpool.Name)
+	if carpool.Name == "" {
+		return fmt.Errorf("el nombre del grupo es obligatorio")
+	}
+
+	vehicle, err := uc.VehicleRepository.SearchVehicleByID(carpool.VehicleID)
+	if err != nil || vehicle == nil {
+		return fmt.Errorf("vehículo con ID %d no encontrado", carpool.VehicleID)
+	}
+	if carpool.OwnerID == "" {
+		carpool.OwnerID = vehicle.CustomerID
+	}
+	if vehicle.CustomerID != carpool.OwnerID {
+		return fmt.Errorf("solo el dueño del vehículo %s puede crear un carpool con él", vehicle.Plate)
+	}
+
+	carpool.CreatedAt = time.Now()
+	if err := uc.CarpoolRepository.CreateCarpool(carpool); err != nil {
+		return err
+	}
+
+	owner := entities.CarpoolMember{
+		CarpoolID:  carpool.ID,
+		CustomerID: carpool.OwnerID,
+		Role:       entities.CarpoolRoleDriver,
+		AddedAt:    carpool.CreatedAt,
+	}
+	if err := uc.CarpoolRepository.SaveCarpoolMember(&owner); err != nil {
+		return err
+	}
+	carpool.Members = entities.CarpoolMembers{owner}
+	return nil
+}
+
+func (uc *CarpoolUsecase) UpdateCarpool(carpool *entities.Carpool) error {
+	current, err := uc.SearchCarpoolByID(carpool.ID)
+	if err != nil {
+		return err
+	}
+	carpool.Name = strings.TrimSpace(carpool.Name)
+	if carpool.Name == "" {
+		return fmt.Errorf("el nombre del grupo es obligatorio")
+	}
+
+	current.Name = carpool.Name
+	if err := uc.CarpoolRepository.UpdateCarpoolByID(current); err != nil {
+		return err
+	}
+	*carpool = *current
+	return nil
+}
+
+func (uc *CarpoolUsecase) DeleteCarpoolByID(id int) error {
+	return uc.CarpoolRepository.DeleteCarpoolByID(id)
+}
+
+func (uc *CarpoolUsecase) AddMember(member *entities.CarpoolMember) (*entities.Carpool, error) {
+	carpool, err := uc.SearchCarpoolByID(member.CarpoolID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == "" {
+		member.Role = entities.CarpoolRolePassenger
+	}
+	if member.Role != entities.CarpoolRoleDriver && member.Role != entities.CarpoolRolePassenger {
+		return nil, fmt.Errorf("rol de carpool inválido: %s", member.Role)
+	}
+	if member.CustomerID == carpool.OwnerID && member.Role != entities.CarpoolRoleDriver {
+		return nil, fmt.Errorf("el dueño del vehículo siempre es conductor autorizado")
+	}
+	if _, err := uc.UserRepository.SearchUserByID(member.CustomerID); err != nil {
+		return nil, fmt.Errorf("cliente %s no encontrado", member.CustomerID)
+	}
+
+	member.AddedAt = time.Now()
+	if err := uc.CarpoolRepository.SaveCarpoolMember(member); err != nil {
+		return nil, err
+	}
+
+	uc.notify([]string{member.CustomerID}, "carpool_member_added", map[string]interface{}{
+		"carpoolId": carpool.ID,
+		"role":      member.Role,
+		"message":   fmt.Sprintf("Fuiste agregado al carpool %s", carpool.Name),
+	})
+	return uc.SearchCarpoolByID(carpool.ID)
+}
+
+func (uc *CarpoolUsecase) RemoveMember(carpoolID int, customerID string) (*entities.Carpool, error) {
+	carpool, err := uc.SearchCarpoolByID(carpoolID)
+	if err != nil {
+		return nil, err
+	}
+	if customerID == carpool.OwnerID {
+		return nil, fmt.Errorf("no es posible quitar al dueño del vehículo del carpool")
+	}
+	if carpool.Member(customerID) == nil {
+		return nil, fmt.Errorf("el cliente %s no pertenece al carpool", customerID)
+	}
+
+	if err := uc.CarpoolRepository.DeleteCarpoolMember(carpoolID, customerID); err != nil {
+		return nil, err
+	}
+	return uc.SearchCarpoolByID(carpoolID)
+}
+
+func (uc *CarpoolUsecase) CreateCarpoolReservation(carpoolID int, reservation *reservationEntities.Reservation, passengerIDs []string) (*entities.CarpoolReservation, error) {
+	carpool, err := uc.SearchCarpoolByID(carpoolID)
+	if err != nil {
+		return nil, err
+	}
+	if !carpool.IsDriver(reservation.CustomerID) {
+		return nil, fmt.Errorf("el cliente %s no es conductor autorizado del carpool", reservation.CustomerID)
+	}
+
+	passengers := []string{}
+	seen := map[string]bool{reservation.CustomerID: true}
+	for _, passengerID := range passengerIDs {
+		if seen[passengerID] {
+			continue
+		}
+		if carpool.Member(passengerID) == nil {
+			return nil, fmt.Errorf("el pasajero %s no pertenece al carpool", passengerID)
+		}
+		seen[passengerID] = true
+		passengers = append(passengers, passengerID)
+	}
+	if occupants := len(passengers) + 1; occupants < CarpoolMinOccupants() {
+		return nil, fmt.Errorf("una reserva de carpool requiere al menos %d ocupantes", CarpoolMinOccupants())
+	}
+
+	reservation.VehicleID = carpool.VehicleID
+	if err := uc.ReservationUsecase.CreateCarpoolReservation(reservation); err != nil {
+		return nil, err
+	}
+
+	carpoolReservation := &entities.CarpoolReservation{
+		ReservationID: reservation.ID,
+		CarpoolID:     carpool.ID,
+		DriverID:      reservation.CustomerID,
+		PassengerIDs:  passengers,
+		CreatedAt:     time.Now(),
+	}
+	if err := uc.CarpoolReservationRepository.CreateCarpoolReservation(carpoolReservation); err != nil {
+		description := fmt.Sprintf("Cancelación de reserva %d por error al registrar pasajeros del carpool %d", reservation.ID, carpool.ID)
+		if cancelErr := uc.ReservationUsecase.CancelReservationWithRefund(reservation, description); cancelErr != nil {
+			log.Printf("Error al cancelar la reserva %d sin pasajeros registrados: %v", reservation.ID, cancelErr)
+		}
+		return nil, fmt.Errorf("error al registrar pasajeros de la reserva: %w", err)
+	}
+
+	uc.notify(passengers, "carpool_reservation_created", map[string]interface{}{
+		"reservation": reservation,
+		"carpoolId":   carpool.ID,
+		"message":     fmt.Sprintf("%s reservó estacionamiento para el carpool %s", reservation.CustomerID, carpool.Name),
+	})
+	log.Printf("Reserva ID %d creada para el carpool %d con %d pasajeros", reservation.ID, carpool.ID, len(passengers))
+	return carpoolReservation, nil
+}
+
+func (uc *CarpoolUsecase) SearchCarpoolHistory(carpoolID int) (*entities.CarpoolHistory, error) {
+	carpool, err := uc.SearchCarpoolByID(carpoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := uc.CarpoolReservationRepository.SearchCarpoolReservationsByCarpoolID(carpoolID)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar reservas del carpool: %w", err)
+	}
+
+	history := &entities.CarpoolHistory{
+		Carpool:       *carpool,
+		Reservations:  []entities.CarpoolReservationDetail{},
+		ParkingUsages: []parkingUsageEntities.ParkingUsage{},
+	}
+	for _, link := range *links {
+		reservation, err := uc.ReservationUsecase.SearchReservationByID(link.ReservationID)
+		if err != nil {
+			log.Printf("Error al obtener reserva %d del carpool %d: %v", link.ReservationID, carpoolID, err)
+		}
+		history.Reservations = append(history.Reservations, entities.CarpoolReservationDetail{
+			CarpoolReservation: link,
+			Reservation:        reservation,
+		})
+	}
+
+	usages, err := uc.ParkingUsageRepository.SearchParkingUsagesByVehicleIDs([]int{carpool.VehicleID}, map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar registros de uso: %w", err)
+	}
+	if usages != nil {
+		history.ParkingUsages = *usages
+	}
+	return history, nil
+}
+
+func (uc *CarpoolUsecase) notify(customerIDs []string, notificationType string, payload map[string]interface{}) {
+	if uc.WebSocketService == nil || len(customerIDs) == 0 {
+		return
+	}
+	uc.WebSocketService.NotifyMultipleUsers(customerIDs, notificationType, payload)
+}
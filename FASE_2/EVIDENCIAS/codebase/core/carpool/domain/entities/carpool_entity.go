@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"time"
+
+	parkingUsageEntities "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+)
+
+const (
+	CarpoolRoleDriver    = "driver"
+	CarpoolRolePassenger = "passenger"
+)
+
+type Carpool struct {
+	ID        int            `json:"id"`
+	Name      string         `json:"name"`
+	VehicleID int            `json:"vehicleId"`
+	OwnerID   string         `json:"ownerId"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Members   CarpoolMembers `json:"members"`
+}
+
+type Carpools []Carpool
+
+type CarpoolMember struct {
+	CarpoolID  int       `json:"carpoolId"`
+	CustomerID string    `json:"customerId"`
+	Role       string    `json:"role"`
+	AddedAt    time.Time `json:"addedAt"`
+}
+
+type CarpoolMembers []CarpoolMember
+
+func (c *Carpool) Member(customerID string) *CarpoolMember {
+	for i := range c.Members {
+		if c.Members[i].CustomerID == customerID {
+			return &c.Members[i]
+		}
+	}
+	return nil
+}
+
+func (c *Carpool) IsDriver(customerID string) bool {
+	member := c.Member(customerID)
+	return member != nil && member.Role == CarpoolRoleDriver
+}
+
+type CarpoolReservation struct {
+	ReservationID int       `json:"reservationId"`
+	CarpoolID     int       `json:"carpoolId"`
+	DriverID      string    `json:"driverId"`
+	PassengerIDs  []string  `json:"passengerIds"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type CarpoolReservations []CarpoolReservation
+
+type CarpoolReservationDetail struct {
+	CarpoolReservation
+	Reservation *reservationEntities.Reservation `json:"reservation"`
+}
+
+type CarpoolHistory struct {
+	Carpool       Carpool                             `json:"carpool"`
+	Reservations  []CarpoolReservationDetail          `json:"reservations"`
+	ParkingUsages []parkingUsageEntities.ParkingUsage `json:"parkingUsages"`
+}
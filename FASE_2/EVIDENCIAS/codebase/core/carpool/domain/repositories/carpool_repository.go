@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/carpool/domain/entities"
+
+type CarpoolRepository interface {
+	SearchCarpoolByID(id int) (*entities.Carpool, error)
+	SearchCarpoolsByMemberID(customerID string) (*entities.Carpools, error)
+	CreateCarpool(carpool *entities.Carpool) error
+	UpdateCarpoolByID(carpool *entities.Carpool) error
+	DeleteCarpoolByID(id int) error
+	SaveCarpoolMember(member *entities.CarpoolMember) error
+	DeleteCarpoolMember(carpoolID int, customerID string) error
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/carpool/domain/entities"
+
+type CarpoolReservationRepository interface {
+	SearchCarpoolReservationByReservationID(reservationID int) (*entities.CarpoolReservation, error)
+	SearchCarpoolReservationsByCarpoolID(carpoolID int) (*entities.CarpoolReservations, error)
+	CreateCarpoolReservation(carpoolReservation *entities.CarpoolReservation) error
+}
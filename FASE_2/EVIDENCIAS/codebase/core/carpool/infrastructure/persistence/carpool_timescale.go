@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gonzalohonorato/servercorego/core/carpool/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleCarpoolRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleCarpoolRepository(pool *pgxpool.Pool) *TimescaleCarpoolRepository {
+	return &TimescaleCarpoolRepository{
+		dbPool: pool,
+	}
+}
+
+func (r *TimescaleCarpoolRepository) SearchCarpoolByID(id int) (*entities.Carpool, error) {
+	ctx := context.Background()
+	query := `SELECT id, name, vehicle_id, owner_id, created_at FROM carpool WHERE id = $1`
+	var c entities.Carpool
+	err := r.dbPool.QueryRow(ctx, query, id).Scan(&c.ID, &c.Name, &c.VehicleID, &c.OwnerID, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if c.Members, err = r.searchMembers(ctx, c.ID); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *TimescaleCarpoolRepository) SearchCarpoolsByMemberID(customerID string) (*entities.Carpools, error) {
+	ctx := context.Background()
+	query := `SELECT c.id, c.name, c.vehicle_id, c.owner_id, c.created_at
+	FROM carpool c
+	WHERE EXISTS (SELECT 1 FROM carpool_member m WHERE m.carpool_id = c.id AND m.customer_id = $1)
+	ORDER BY c.created_at`
+	rows, err := r.dbPool.Query(ctx, query, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	carpools := entities.Carpools{}
+	for rows.Next() {
+		var c entities.Carpool
+		if err := rows.Scan(&c.ID, &c.Name, &c.VehicleID, &c.OwnerID, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		carpools = append(carpools, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range carpools {
+		if carpools[i].Members, err = r.searchMembers(ctx, carpools[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return &carpools, nil
+}
+
+func (r *TimescaleCarpoolRepository) searchMembers(ctx context.Context, carpoolID int) (entities.CarpoolMembers, error) {
+	query := `SELECT carpool_id, customer_id, role, added_at FROM carpool_member WHERE carpool_id = $1 ORDER BY added_at`
+	rows, err := r.dbPool.Query(ctx, query, carpoolID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := entities.CarpoolMembers{}
+	for rows.Next() {
+		var m entities.CarpoolMember
+		if err := rows.Scan(&m.CarpoolID, &m.CustomerID, &m.Role, &m.AddedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *TimescaleCarpoolRepository) CreateCarpool(carpool *entities.Carpool) error {
+	ctx := context.Background()
+	query := `INSERT INTO carpool (name, vehicle_id, owner_id, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	return r.dbPool.QueryRow(ctx, query, carpool.Name, carpool.VehicleID, carpool.OwnerID, carpool.CreatedAt).Scan(&carpool.ID)
+}
+
+func (r *TimescaleCarpoolRepository) UpdateCarpoolByID(carpool *entities.Carpool) error {
+	ctx := context.Background()
+	query := `UPDATE carpool SET name = $2 WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, carpool.ID, carpool.Name)
+	return err
+}
+
+func (r *TimescaleCarpoolRepository) DeleteCarpoolByID(id int) error {
+	ctx := context.Background()
+	query := `DELETE FROM carpool WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, id)
+	return err
+}
+
+func (r *TimescaleCarpoolRepository) SaveCarpoolMember(member *entities.CarpoolMember) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO carpool_member (carpool_id, customer_id, role, added_at)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (carpool_id, customer_id) DO UPDATE SET role = EXCLUDED.role
+	RETURNING added_at`
+	return r.dbPool.QueryRow(ctx, query, member.CarpoolID, member.CustomerID, member.Role, member.AddedAt).Scan(&member.AddedAt)
+}
+
+func (r *TimescaleCarpoolRepository) DeleteCarpoolMember(carpoolID int, customerID string) error {
+	ctx := context.Background()
+	query := `DELETE FROM carpool_member WHERE carpool_id = $1 AND customer_id = $2`
+	_, err := r.dbPool.Exec(ctx, query, carpoolID, customerID)
+	return err
+}
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gonzalohonorato/servercorego/core/carpool/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleCarpoolReservationRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleCarpoolReservationRepository(pool *pgxpool.Pool) *TimescaleCarpoolReservationRepository {
+	return &TimescaleCarpoolReservationRepository{
+		dbPool: pool,
+	}
+}
+
+func (r *TimescaleCarpoolReservationRepository) SearchCarpoolReservationByReservationID(reservationID int) (*entities.CarpoolReservation, error) {
+	ctx := context.Background()
+	query := `SELECT reservation_id, carpool_id, driver_id, passenger_ids, created_at FROM carpool_reservation WHERE reservation_id = $1`
+	var c entities.CarpoolReservation
+	err := r.dbPool.QueryRow(ctx, query, reservationID).Scan(&c.ReservationID, &c.CarpoolID, &c.DriverID, &c.PassengerIDs, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *TimescaleCarpoolReservationRepository) SearchCarpoolReservationsByCarpoolID(carpoolID int) (*entities.CarpoolReservations, error) {
+	ctx := context.Background()
+	query := `SELECT reservation_id, carpool_id, driver_id, passenger_ids, created_at
+	FROM carpool_reservation WHERE carpool_id = $1 ORDER BY created_at DESC`
+	rows, err := r.dbPool.Query(ctx, query, carpoolID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := entities.CarpoolReservations{}
+	for rows.Next() {
+		var c entities.CarpoolReservation
+		if err := rows.Scan(&c.ReservationID, &c.CarpoolID, &c.DriverID, &c.PassengerIDs, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &reservations, nil
+}
+
+func (r *TimescaleCarpoolReservationRepository) CreateCarpoolReservation(carpoolReservation *entities.CarpoolReservation) error {
+	ctx := context.Background()
+	if carpoolReservation.PassengerIDs == nil {
+		carpoolReservation.PassengerIDs = []string{}
+	}
+	query := `
+	INSERT INTO carpool_reservation (reservation_id, carpool_id, driver_id, passenger_ids, created_at)
+	VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.dbPool.Exec(ctx, query,
+		carpoolReservation.ReservationID, carpoolReservation.CarpoolID, carpoolReservation.DriverID,
+		carpoolReservation.PassengerIDs, carpoolReservation.CreatedAt,
+	)
+	return err
+}
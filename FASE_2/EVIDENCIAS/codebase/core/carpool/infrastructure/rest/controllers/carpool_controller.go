@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	bookingRuleApplication "github.com/gonzalohonorato/servercorego/core/bookingrule/application"
+	"github.com/gonzalohonorato/servercorego/core/carpool/application"
+	"github.com/gonzalohonorato/servercorego/core/carpool/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/carpool/domain/repositories"
+	noShowApplication "github.com/gonzalohonorato/servercorego/core/noshow/application"
+	parkingUsageRepositories "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	quotaApplication "github.com/gonzalohonorato/servercorego/core/quota/application"
+	reservationApplication "github.com/gonzalohonorato/servercorego/core/reservation/application"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+	"github.com/gorilla/mux"
+)
+
+type CarpoolController struct {
+	CarpoolUsecase *application.CarpoolUsecase
+}
+
+func NewCarpoolController(
+	carpoolRepository repositories.CarpoolRepository,
+	carpoolReservationRepository repositories.CarpoolReservationRepository,
+	parkingUsageRepository parkingUsageRepositories.ParkingUsageRepository,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+	userRepository userRepositories.UserRepository,
+	reservationUsecase *reservationApplication.ReservationUsecase,
+	wsService *infrastructure.WebSocketService,
+) *CarpoolController {
+	carpoolUseCase := application.NewCarpoolUsecase(
+		carpoolRepository,
+		carpoolReservationRepository,
+		parkingUsageRepository,
+		vehicleRepository,
+		userRepository,
+		reservationUsecase,
+		wsService,
+	)
+
+	return &CarpoolController{
+		CarpoolUsecase: carpoolUseCase,
+	}
+}
+
+func (uc *CarpoolController) GetCarpoolByID(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	carpool, err := uc.CarpoolUsecase.SearchCarpoolByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Carpool not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(carpool)
+}
+
+func (uc *CarpoolController) GetCarpools(w http.ResponseWriter, r *http.Request) {
+	customerID := r.URL.Query().Get("customerId")
+	if customerID == "" {
+		http.Error(w, "customerId query parameter is required", http.StatusBadRequest)
+		return
+	}
+	carpools, err := uc.CarpoolUsecase.SearchCarpoolsByMemberID(customerID)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Carpools not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(carpools)
+}
+
+func (uc *CarpoolController) PostCarpool(w http.ResponseWriter, r *http.Request) {
+	var carpool entities.Carpool
+	if err := json.NewDecoder(r.Body).Decode(&carpool); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.CarpoolUsecase.CreateCarpool(&carpool); err != nil {
+		http.Error(w, "Error creating carpool: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(carpool)
+}
+
+func (uc *CarpoolController) PutCarpool(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var carpool entities.Carpool
+	if err := json.NewDecoder(r.Body).Decode(&carpool); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	carpool.ID = idInt
+
+	if err := uc.CarpoolUsecase.UpdateCarpool(&carpool); err != nil {
+		writeCarpoolError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(carpool)
+}
+
+func (uc *CarpoolController) DeleteCarpoolByID(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	if err := uc.CarpoolUsecase.DeleteCarpoolByID(idInt); err != nil {
+		fmt.Println(err)
+		http.Error(w, "Carpool not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (uc *CarpoolController) PostCarpoolMember(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var member entities.CarpoolMember
+	if err := json.NewDecoder(r.Body).Decode(&member); err != nil || member.CustomerID == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	member.CarpoolID = idInt
+
+	carpool, err := uc.CarpoolUsecase.AddMember(&member)
+	if err != nil {
+		writeCarpoolError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(carpool)
+}
+
+func (uc *CarpoolController) DeleteCarpoolMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	carpool, err := uc.CarpoolUsecase.RemoveMember(idInt, vars["customerID"])
+	if err != nil {
+		writeCarpoolError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(carpool)
+}
+
+func (uc *CarpoolController) PostCarpoolReservation(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		DriverID     string    `json:"driverId"`
+		ParkingID    int       `json:"parkingId"`
+		StartTime    time.Time `json:"startTime"`
+		EndTime      time.Time `json:"endTime"`
+		PassengerIDs []string  `json:"passengerIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.DriverID == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	reservation := &reservationEntities.Reservation{
+		CustomerID: request.DriverID,
+		ParkingID:  request.ParkingID,
+		StartTime:  request.StartTime,
+		EndTime:    request.EndTime,
+	}
+	carpoolReservation, err := uc.CarpoolUsecase.CreateCarpoolReservation(idInt, reservation, request.PassengerIDs)
+	if err != nil {
+		writeCarpoolError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entities.CarpoolReservationDetail{
+		CarpoolReservation: *carpoolReservation,
+		Reservation:        reservation,
+	})
+}
+
+func (uc *CarpoolController) GetCarpoolHistory(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	history, err := uc.CarpoolUsecase.SearchCarpoolHistory(idInt)
+	if err != nil {
+		writeCarpoolError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+func writeCarpoolError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	errorCode := "CARPOOL_INVALID"
+
+	var ruleErr *bookingRuleApplication.BookingRuleError
+	switch {
+	case errors.Is(err, application.ErrCarpoolNotFound):
+		status, errorCode = http.StatusNotFound, "CARPOOL_NOT_FOUND"
+	case errors.As(err, &ruleErr):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errorCode":  "BOOKING_RULE_VIOLATION",
+			"message":    err.Error(),
+			"violations": ruleErr.Violations,
+		})
+		return
+	case errors.Is(err, noShowApplication.ErrBookingSuspended):
+		status, errorCode = http.StatusForbidden, "BOOKING_SUSPENDED"
+	case errors.Is(err, quotaApplication.ErrQuotaExhausted):
+		status, errorCode = http.StatusConflict, "QUOTA_EXHAUSTED"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"errorCode": errorCode,
+		"message":   err.Error(),
+	})
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/carpool/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func CarpoolRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewCarpoolController(
+		container.ProvideCarpoolRepository(),
+		container.ProvideCarpoolReservationRepository(),
+		container.ProvideParkingUsageRepository(),
+		container.ProvideVehicleRepository(),
+		container.ProvideUserRepository(),
+		container.ProvideReservationUsecase(),
+		container.ProvideWebSocketService(),
+	)
+
+	router.HandleFunc("/carpools", controller.GetCarpools).Methods("GET")
+	router.HandleFunc("/carpools", controller.PostCarpool).Methods("POST")
+	router.HandleFunc("/carpools/{id}", controller.GetCarpoolByID).Methods("GET")
+	router.HandleFunc("/carpools/{id}", controller.PutCarpool).Methods("PUT")
+	router.HandleFunc("/carpools/{id}", controller.DeleteCarpoolByID).Methods("DELETE")
+	router.HandleFunc("/carpools/{id}/members", controller.PostCarpoolMember).Methods("POST")
+	router.HandleFunc("/carpools/{id}/members/{customerID}", controller.DeleteCarpoolMember).Methods("DELETE")
+	router.HandleFunc("/carpools/{id}/reservations", controller.PostCarpoolReservation).Methods("POST")
+	router.HandleFunc("/carpools/{id}/history", controller.GetCarpoolHistory).Methods("GET")
+}
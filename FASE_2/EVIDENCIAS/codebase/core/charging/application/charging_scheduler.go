@@ -0,0 +1,81 @@
+package application
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+type ChargingScheduler struct {
+	usecase *ChargingUsecase
+	stop    chan bool
+	running bool
+}
+
+func NewChargingScheduler(usecase *ChargingUsecase) *ChargingScheduler {
+	return &ChargingScheduler{
+		usecase: usecase,
+		stop:    make(chan bool),
+		running: false,
+	}
+}
+
+func (s *ChargingScheduler) Start() {
+	if s.running {
+		log.Println("El scheduler de sesiones de carga ya está en ejecución")
+		return
+	}
+
+	s.running = true
+	log.Println("Iniciando scheduler de sesiones de carga...")
+
+	s.runChargingTask()
+
+	go s.startChargingScheduler()
+}
+
+func (s *ChargingScheduler) startChargingScheduler() {
+	intervalStr := os.Getenv("CHARGING_CHECK_INTERVAL")
+	interval := 5 * time.Minute
+
+	if intervalStr != "" {
+		if minutes, err := strconv.Atoi(intervalStr); err == nil && minutes > 0 {
+			interval = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	log.Printf("Iniciando scheduler de sesiones de carga con intervalo de %v", interval)
+	ticker := time.NewTicker(interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runChargingTask()
+		case <-s.stop:
+			ticker.Stop()
+			log.Println("Scheduler de sesiones de carga detenido")
+			return
+		}
+	}
+}
+
+func (s *ChargingScheduler) Stop() {
+	if s.running {
+		close(s.stop)
+		s.running = false
+		log.Println("Deteniendo scheduler de sesiones de carga...")
+	}
+}
+
+func (s *ChargingScheduler) runChargingTask() {
+	log.Println("Ejecutando tarea de revisión de sesiones de carga...")
+	count, err := s.usecase.CheckChargingSessions(time.Now())
+	if err != nil {
+		log.Printf("Error al revisar sesiones de carga: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("Proceso completado: %d sesiones de carga actualizadas", count)
+	}
+}
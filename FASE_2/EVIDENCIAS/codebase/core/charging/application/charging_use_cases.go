@@ -0,0 +1,460 @@
+package application
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/charging/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/charging/domain/gateways"
+	"github.com/gonzalohonorato/servercorego/core/charging/domain/repositories"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	parkingUsageRepositories "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	paymentApplication "github.com/gonzalohonorato/servercorego/core/payment/application"
+	paymentEntities "github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+	paymentGateways "github.com/gonzalohonorato/servercorego/core/payment/domain/gateways"
+	paymentRepositories "github.com/gonzalohonorato/servercorego/core/payment/domain/repositories"
+	tariffApplication "github.com/gonzalohonorato/servercorego/core/tariff/application"
+	tariffRepositories "github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+)
+
+const defaultCurrency = "CLP"
+
+var (
+	ErrParkingUsageClosed = fmt.Errorf("el uso de estacionamiento ya registró su salida")
+	ErrNoCharger          = fmt.Errorf("el estacionamiento no cuenta con cargador eléctrico")
+	ErrChargerBusy        = fmt.Errorf("el cargador del estacionamiento ya tiene una sesión abierta")
+	ErrSessionNotOpen     = fmt.Errorf("la sesión de carga ya fue cerrada")
+)
+
+type ChargingUsecase struct {
+	ChargingSessionRepository repositories.ChargingSessionRepository
+	ParkingUsageRepository    parkingUsageRepositories.ParkingUsageRepository
+	ParkingRepository         parkingRepositories.ParkingRepository
+	VehicleRepository         vehicleRepositories.VehicleRepository
+	TariffUsecase             *tariffApplication.TariffUsecase
+	PaymentUsecase            *paymentApplication.PaymentUsecase
+	ChargerClient             gateways.ChargerClient
+	WebSocketService          *infrastructure.WebSocketService
+}
+
+func NewChargingUsecase(
+	chargingSessionRepo repositories.ChargingSessionRepository,
+	parkingUsageRepo parkingUsageRepositories.ParkingUsageRepository,
+	parkingRepo parkingRepositories.ParkingRepository,
+	vehicleRepo vehicleRepositories.VehicleRepository,
+	tariffRepo tariffRepositories.TariffRepository,
+	parkingChargeRepo tariffRepositories.ParkingChargeRepository,
+	ledgerRepo paymentRepositories.LedgerRepository,
+	paymentGateway paymentGateways.PaymentGateway,
+	chargerClient gateways.ChargerClient,
+	wsService *infrastructure.WebSocketService,
+	calendarRepo calendarRepositories.CalendarEventRepository,
+) *ChargingUsecase {
+	return &ChargingUsecase{
+		ChargingSessionRepository: chargingSessionRepo,
+		ParkingUsageRepository:    parkingUsageRepo,
+		ParkingRepository:         parkingRepo,
+		VehicleRepository:         vehicleRepo,
+		TariffUsecase:             tariffApplication.NewTariffUsecase(tariffRepo, parkingChargeRepo, calendarRepo),
+		PaymentUsecase:            paymentApplication.NewPaymentUsecase(ledgerRepo, parkingChargeRepo, paymentGateway, parkingUsageRepo, vehicleRepo),
+		ChargerClient:             chargerClient,
+		WebSocketService:          wsService,
+	}
+}
+
+func ChargingMaxMinutes() int {
+	return envPositiveInt("CHARGING_MAX_MINUTES", 240)
+}
+
+func ChargingMoveGrace() time.Duration {
+	return time.Duration(envPositiveInt("CHARGING_MOVE_GRACE_MINUTES", 15)) * time.Minute
+}
+
+func envPositiveInt(name string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func (uc *ChargingUsecase) SearchChargingSessionByID(id int) (*entities.ChargingSession, error) {
+	return uc.ChargingSessionRepository.SearchChargingSessionByID(id)
+}
+
+func (uc *ChargingUsecase) SearchChargingSessionsByParkingUsageID(parkingUsageID int) (*entities.ChargingSessions, error) {
+	return uc.ChargingSessionRepository.SearchChargingSessionsByParkingUsageID(parkingUsageID)
+}
+
+func (uc *ChargingUsecase) SearchChargingSessionsByCustomerID(customerID string) (*entities.ChargingSessions, error) {
+	return uc.ChargingSessionRepository.SearchChargingSessionsByCustomerID(customerID)
+}
+
+func (uc *ChargingUsecase) SearchOpenChargingSessions() (*entities.ChargingSessions, error) {
+	return uc.ChargingSessionRepository.SearchOpenChargingSessions()
+}
+
+func (uc *ChargingUsecase) ReadCharger(parkingID int) (*entities.ChargerReading, error) {
+	return uc.ChargerClient.ReadCharger(parkingID)
+}
+
+func (uc *ChargingUsecase) StartChargingSession(request *entities.StartChargingRequest) (*entities.ChargingSession, error) {
+	usage, err := uc.ParkingUsageRepository.SearchParkingUsageByID(request.ParkingUsageID)
+	if err != nil {
+		return nil, fmt.Errorf("uso de estacionamiento %d no encontrado: %w", request.ParkingUsageID, err)
+	}
+	if usage.ExitTime != nil {
+		return nil, ErrParkingUsageClosed
+	}
+
+	parking, err := uc.ParkingRepository.SearchParkingByID(usage.ParkingID)
+	if err != nil {
+		return nil, fmt.Errorf("estacionamiento %d no encontrado: %w", usage.ParkingID, err)
+	}
+	if !parking.HasEVCharger {
+		return nil, ErrNoCharger
+	}
+
+	open, err := uc.ChargingSessionRepository.SearchOpenChargingSessionByParkingID(parking.ID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, ErrChargerBusy
+	}
+
+	source := request.EnergySource
+	if source == "" {
+		source = entities.EnergySourceManual
+	}
+	if source != entities.EnergySourceManual && source != entities.EnergySourceCharger {
+		return nil, fmt.Errorf("origen de energía inválido: %s", source)
+	}
+
+	maxMinutes := request.MaxMinutes
+	if maxMinutes < 0 {
+		return nil, fmt.Errorf("el tiempo máximo de carga no puede ser negativo")
+	}
+	if maxMinutes == 0 {
+		maxMinutes = ChargingMaxMinutes()
+	}
+
+	customerID, err := uc.PaymentUsecase.UsageCustomerID(usage)
+	if err != nil {
+		return nil, err
+	}
+
+	if source == entities.EnergySourceCharger {
+		if err := uc.ChargerClient.StartCharging(parking.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	session := &entities.ChargingSession{
+		ParkingUsageID: usage.ID,
+		ParkingID:      parking.ID,
+		CustomerID:     customerID,
+		VisitorRut:     usage.VisitorRut,
+		EnergySource:   source,
+		Status:         entities.ChargingStatusActive,
+		StartedAt:      time.Now(),
+		MaxMinutes:     maxMinutes,
+		Currency:       defaultCurrency,
+	}
+	if err := uc.ChargingSessionRepository.CreateChargingSession(session); err != nil {
+		if source == entities.EnergySourceCharger {
+			uc.ChargerClient.StopCharging(parking.ID)
+		}
+		return nil, err
+	}
+
+	if session.CustomerID != nil && uc.WebSocketService != nil {
+		uc.WebSocketService.NotifyUser(*session.CustomerID, "charging_started", map[string]interface{}{
+			"chargingSession": session,
+			"deadline":        session.Deadline(),
+		})
+	}
+
+	return session, nil
+}
+
+func (uc *ChargingUsecase) RecordEnergy(id int, energyKWh float64) (*entities.ChargingSession, error) {
+	session, err := uc.ChargingSessionRepository.SearchChargingSessionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, ErrSessionNotOpen
+	}
+	if session.EnergySource != entities.EnergySourceManual {
+		return nil, fmt.Errorf("la energía de esta sesión la informa el cargador")
+	}
+	if energyKWh < 0 {
+		return nil, fmt.Errorf("la energía entregada no puede ser negativa")
+	}
+
+	session.EnergyKWh = energyKWh
+	if err := uc.ChargingSessionRepository.UpdateChargingSessionByID(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (uc *ChargingUsecase) CompleteChargingSession(id int) (*entities.ChargingSession, error) {
+	session, err := uc.ChargingSessionRepository.SearchChargingSessionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != entities.ChargingStatusActive {
+		return nil, fmt.Errorf("la sesión de carga no está activa")
+	}
+
+	if err := uc.completeChargingSession(session, time.Now(), "completed"); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (uc *ChargingUsecase) StopChargingSession(id int, energyKWh *float64) (*entities.ChargingSession, error) {
+	session, err := uc.ChargingSessionRepository.SearchChargingSessionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, ErrSessionNotOpen
+	}
+	if energyKWh != nil {
+		if *energyKWh < 0 {
+			return nil, fmt.Errorf("la energía entregada no puede ser negativa")
+		}
+		if session.EnergySource != entities.EnergySourceManual {
+			return nil, fmt.Errorf("la energía de esta sesión la informa el cargador")
+		}
+		session.EnergyKWh = *energyKWh
+	}
+
+	if err := uc.closeChargingSession(session, time.Now()); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (uc *ChargingUsecase) CloseChargingSessionsByParkingUsageID(parkingUsageID int, at time.Time) error {
+	sessions, err := uc.ChargingSessionRepository.SearchChargingSessionsByParkingUsageID(parkingUsageID)
+	if err != nil {
+		return err
+	}
+
+	for i := range *sessions {
+		session := &(*sessions)[i]
+		if !session.IsOpen() {
+			continue
+		}
+		if err := uc.closeChargingSession(session, at); err != nil {
+			return fmt.Errorf("error al cerrar la sesión de carga %d: %w", session.ID, err)
+		}
+	}
+	return nil
+}
+
+func (uc *ChargingUsecase) CheckChargingSessions(now time.Time) (int, error) {
+	sessions, err := uc.ChargingSessionRepository.SearchOpenChargingSessions()
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for i := range *sessions {
+		session := &(*sessions)[i]
+
+		usage, err := uc.ParkingUsageRepository.SearchParkingUsageByID(session.ParkingUsageID)
+		if err == nil && usage.ExitTime != nil {
+			if err := uc.closeChargingSession(session, *usage.ExitTime); err != nil {
+				log.Printf("Error al cerrar la sesión de carga %d: %v", session.ID, err)
+				continue
+			}
+			updated++
+			continue
+		}
+
+		switch session.Status {
+		case entities.ChargingStatusActive:
+			reason := ""
+			if session.EnergySource == entities.EnergySourceCharger {
+				reading, err := uc.ChargerClient.ReadCharger(session.ParkingID)
+				if err != nil {
+					log.Printf("Error al leer el cargador del estacionamiento %d: %v", session.ParkingID, err)
+				} else {
+					session.EnergyKWh = reading.EnergyKWh
+					if !reading.Charging {
+						reason = "completed"
+					}
+				}
+			}
+			if reason == "" && !now.Before(session.Deadline()) {
+				reason = "time_limit"
+			}
+
+			if reason == "" {
+				if session.EnergySource == entities.EnergySourceCharger {
+					if err := uc.ChargingSessionRepository.UpdateChargingSessionByID(session); err != nil {
+						log.Printf("Error al actualizar la sesión de carga %d: %v", session.ID, err)
+					}
+				}
+				continue
+			}
+
+			if err := uc.completeChargingSession(session, now, reason); err != nil {
+				log.Printf("Error al completar la sesión de carga %d: %v", session.ID, err)
+				continue
+			}
+			updated++
+
+		case entities.ChargingStatusCompleted:
+			if session.EscalatedAt != nil || session.CompletedAt == nil || now.Sub(*session.CompletedAt) < ChargingMoveGrace() {
+				continue
+			}
+			if err := uc.escalateChargingSession(session, now); err != nil {
+				log.Printf("Error al escalar la sesión de carga %d: %v", session.ID, err)
+				continue
+			}
+			updated++
+		}
+	}
+
+	return updated, nil
+}
+
+func (uc *ChargingUsecase) completeChargingSession(session *entities.ChargingSession, at time.Time, reason string) error {
+	if session.EnergySource == entities.EnergySourceCharger {
+		if reading, err := uc.ChargerClient.StopCharging(session.ParkingID); err == nil {
+			session.EnergyKWh = reading.EnergyKWh
+		} else {
+			log.Printf("Error al detener el cargador del estacionamiento %d: %v", session.ParkingID, err)
+		}
+	}
+
+	session.Status = entities.ChargingStatusCompleted
+	session.CompletedAt = &at
+	if session.CustomerID != nil {
+		session.NotifiedAt = &at
+	}
+	if err := uc.ChargingSessionRepository.UpdateChargingSessionByID(session); err != nil {
+		return err
+	}
+
+	if session.CustomerID != nil && uc.WebSocketService != nil {
+		uc.WebSocketService.NotifyUser(*session.CustomerID, "move_your_car", map[string]interface{}{
+			"chargingSession": session,
+			"reason":          reason,
+			"message":         "La carga de tu vehículo finalizó, por favor libera el estacionamiento con cargador",
+			"moveBefore":      at.Add(ChargingMoveGrace()),
+		})
+	}
+
+	return nil
+}
+
+func (uc *ChargingUsecase) escalateChargingSession(session *entities.ChargingSession, at time.Time) error {
+	session.EscalatedAt = &at
+	if err := uc.ChargingSessionRepository.UpdateChargingSessionByID(session); err != nil {
+		return err
+	}
+
+	if uc.WebSocketService != nil {
+		if session.CustomerID != nil {
+			uc.WebSocketService.NotifyUser(*session.CustomerID, "move_your_car", map[string]interface{}{
+				"chargingSession": session,
+				"reason":          "reminder",
+				"message":         "Tu vehículo sigue ocupando un estacionamiento con cargador, por favor muévelo",
+			})
+		}
+		uc.WebSocketService.BroadcastAdminAlert("ev_spot_occupied", "medium", map[string]interface{}{
+			"chargingSession": session,
+			"completedAt":     session.CompletedAt,
+		})
+	}
+
+	return nil
+}
+
+func (uc *ChargingUsecase) closeChargingSession(session *entities.ChargingSession, at time.Time) error {
+	if session.Status == entities.ChargingStatusActive && session.EnergySource == entities.EnergySourceCharger {
+		if reading, err := uc.ChargerClient.StopCharging(session.ParkingID); err == nil {
+			session.EnergyKWh = reading.EnergyKWh
+		} else {
+			log.Printf("Error al detener el cargador del estacionamiento %d: %v", session.ParkingID, err)
+		}
+	}
+
+	if at.Before(session.StartedAt) {
+		at = session.StartedAt
+	}
+	session.Status = entities.ChargingStatusClosed
+	session.EndedAt = &at
+
+	if err := uc.priceChargingSession(session); err != nil {
+		return err
+	}
+
+	if err := uc.ChargingSessionRepository.UpdateChargingSessionByID(session); err != nil {
+		return err
+	}
+
+	if session.Cost > 0 {
+		usageID := session.ParkingUsageID
+		entry := &paymentEntities.LedgerEntry{
+			CustomerID:     session.CustomerID,
+			VisitorRut:     session.VisitorRut,
+			ParkingUsageID: &usageID,
+			Amount:         session.Cost,
+			Currency:       session.Currency,
+			Reference:      fmt.Sprintf("charging-%d", session.ID),
+			Description:    fmt.Sprintf("Sesión de carga: %.2f kWh", session.EnergyKWh),
+			CreatedAt:      at,
+		}
+		if err := uc.PaymentUsecase.RecordAdditionalCharge(entry); err != nil {
+			return err
+		}
+	}
+
+	if session.CustomerID != nil && uc.WebSocketService != nil {
+		uc.WebSocketService.NotifyUser(*session.CustomerID, "charging_closed", map[string]interface{}{
+			"chargingSession": session,
+		})
+	}
+
+	return nil
+}
+
+func (uc *ChargingUsecase) priceChargingSession(session *entities.ChargingSession) error {
+	zone := ""
+	if usage, err := uc.ParkingUsageRepository.SearchParkingUsageByID(session.ParkingUsageID); err == nil {
+		zone = usage.Zone
+	}
+	if zone == "" {
+		if parking, err := uc.ParkingRepository.SearchParkingByID(session.ParkingID); err == nil {
+			zone = parking.Zone
+		}
+	}
+
+	tariff, fee, err := uc.TariffUsecase.QuoteChargingFee(zone, session.StartedAt, *session.EndedAt, session.EnergyKWh)
+	if err != nil {
+		return err
+	}
+	if tariff == nil {
+		session.Cost = 0
+		return nil
+	}
+
+	session.TariffID = &tariff.ID
+	session.Cost = fee.Amount
+	session.Currency = tariff.Currency
+	return nil
+}
+
@@ -0,0 +1,58 @@
+package entities
+
+import "time"
+
+const (
+	ChargingStatusActive    = "active"
+	ChargingStatusCompleted = "completed"
+	ChargingStatusClosed    = "closed"
+)
+
+const (
+	EnergySourceManual  = "manual"
+	EnergySourceCharger = "charger"
+)
+
+type ChargingSession struct {
+	ID             int        `json:"id"`
+	ParkingUsageID int        `json:"parkingUsageId"`
+	ParkingID      int        `json:"parkingId"`
+	CustomerID     *string    `json:"customerId"`
+	VisitorRut     string     `json:"visitorRut"`
+	EnergySource   string     `json:"energySource"`
+	Status         string     `json:"status"`
+	StartedAt      time.Time  `json:"startedAt"`
+	CompletedAt    *time.Time `json:"completedAt"`
+	EndedAt        *time.Time `json:"endedAt"`
+	MaxMinutes     int        `json:"maxMinutes"`
+	EnergyKWh      float64    `json:"energyKwh"`
+	TariffID       *int       `json:"tariffId"`
+	Cost           float64    `json:"cost"`
+	Currency       string     `json:"currency"`
+	NotifiedAt     *time.Time `json:"notifiedAt"`
+	EscalatedAt    *time.Time `json:"escalatedAt"`
+}
+
+type ChargingSessions []ChargingSession
+
+func (s *ChargingSession) Deadline() time.Time {
+	return s.StartedAt.Add(time.Duration(s.MaxMinutes) * time.Minute)
+}
+
+func (s *ChargingSession) IsOpen() bool {
+	return s.Status == ChargingStatusActive || s.Status == ChargingStatusCompleted
+}
+
+type ChargerReading struct {
+	ParkingID int       `json:"parkingId"`
+	EnergyKWh float64   `json:"energyKwh"`
+	PowerKW   float64   `json:"powerKw"`
+	Charging  bool      `json:"charging"`
+	ReadAt    time.Time `json:"readAt"`
+}
+
+type StartChargingRequest struct {
+	ParkingUsageID int    `json:"parkingUsageId"`
+	EnergySource   string `json:"energySource"`
+	MaxMinutes     int    `json:"maxMinutes"`
+}
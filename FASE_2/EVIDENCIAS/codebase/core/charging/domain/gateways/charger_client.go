@@ -0,0 +1,9 @@
+package gateways
+
+import "github.com/gonzalohonorato/servercorego/core/charging/domain/entities"
+
+type ChargerClient interface {
+	StartCharging(parkingID int) error
+	ReadCharger(parkingID int) (*entities.ChargerReading, error)
+	StopCharging(parkingID int) (*entities.ChargerReading, error)
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/charging/domain/entities"
+
+type ChargingSessionRepository interface {
+	SearchChargingSessionByID(id int) (*entities.ChargingSession, error)
+	SearchChargingSessionsByParkingUsageID(parkingUsageID int) (*entities.ChargingSessions, error)
+	SearchChargingSessionsByCustomerID(customerID string) (*entities.ChargingSessions, error)
+	SearchOpenChargingSessions() (*entities.ChargingSessions, error)
+	SearchOpenChargingSessionByParkingID(parkingID int) (*entities.ChargingSession, error)
+	CreateChargingSession(session *entities.ChargingSession) error
+	UpdateChargingSessionByID(session *entities.ChargingSession) error
+}
@@ -0,0 +1,97 @@
+package gateways
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/charging/domain/entities"
+)
+
+type SimulatedChargerClient struct {
+	powerKW     float64
+	capacityKWh float64
+	sessions    map[int]time.Time
+	mu          sync.Mutex
+}
+
+func NewSimulatedChargerClient() *SimulatedChargerClient {
+	return &SimulatedChargerClient{
+		powerKW:     envFloat("CHARGER_SIM_POWER_KW", 7.4),
+		capacityKWh: envFloat("CHARGER_SIM_CAPACITY_KWH", 40),
+		sessions:    make(map[int]time.Time),
+	}
+}
+
+func (c *SimulatedChargerClient) StartCharging(parkingID int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.sessions[parkingID]; exists {
+		return fmt.Errorf("el cargador del estacionamiento %d ya está en uso", parkingID)
+	}
+	c.sessions[parkingID] = time.Now()
+	log.Printf("Cargador simulado del estacionamiento %d iniciado a %.1f kW", parkingID, c.powerKW)
+	return nil
+}
+
+func (c *SimulatedChargerClient) ReadCharger(parkingID int) (*entities.ChargerReading, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	startedAt, exists := c.sessions[parkingID]
+	if !exists {
+		return nil, fmt.Errorf("el cargador del estacionamiento %d no tiene una carga activa", parkingID)
+	}
+	return c.reading(parkingID, startedAt, time.Now()), nil
+}
+
+func (c *SimulatedChargerClient) StopCharging(parkingID int) (*entities.ChargerReading, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	startedAt, exists := c.sessions[parkingID]
+	if !exists {
+		return nil, fmt.Errorf("el cargador del estacionamiento %d no tiene una carga activa", parkingID)
+	}
+	delete(c.sessions, parkingID)
+
+	reading := c.reading(parkingID, startedAt, time.Now())
+	reading.Charging = false
+	reading.PowerKW = 0
+	log.Printf("Cargador simulado del estacionamiento %d detenido con %.2f kWh entregados", parkingID, reading.EnergyKWh)
+	return reading, nil
+}
+
+func (c *SimulatedChargerClient) reading(parkingID int, startedAt, now time.Time) *entities.ChargerReading {
+	energy := c.powerKW * now.Sub(startedAt).Hours()
+	charging := true
+	if energy >= c.capacityKWh {
+		energy = c.capacityKWh
+		charging = false
+	}
+
+	power := 0.0
+	if charging {
+		power = c.powerKW
+	}
+
+	return &entities.ChargerReading{
+		ParkingID: parkingID,
+		EnergyKWh: math.Round(energy*100) / 100,
+		PowerKW:   power,
+		Charging:  charging,
+		ReadAt:    now,
+	}
+}
+
+func envFloat(name string, fallback float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(name), 64); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
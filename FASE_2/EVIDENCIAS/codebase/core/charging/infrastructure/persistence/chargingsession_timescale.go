@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/charging/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleChargingSessionRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleChargingSessionRepository(pool *pgxpool.Pool) *TimescaleChargingSessionRepository {
+	return &TimescaleChargingSessionRepository{
+		dbPool: pool,
+	}
+}
+
+const chargingSessionColumns = `id, parking_usage_id, parking_id, customer_id, visitor_rut, energy_source, status,
+	started_at, completed_at, ended_at, max_minutes, energy_kwh, tariff_id, cost, currency, notified_at, escalated_at`
+
+func (r *TimescaleChargingSessionRepository) SearchChargingSessionByID(id int) (*entities.ChargingSession, error) {
+	ctx := context.Background()
+	query := `SELECT ` + chargingSessionColumns + ` FROM charging_session WHERE id = $1`
+	var s entities.ChargingSession
+	if err := scanChargingSession(r.dbPool.QueryRow(ctx, query, id), &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *TimescaleChargingSessionRepository) SearchChargingSessionsByParkingUsageID(parkingUsageID int) (*entities.ChargingSessions, error) {
+	query := `SELECT ` + chargingSessionColumns + ` FROM charging_session WHERE parking_usage_id = $1 ORDER BY started_at`
+	return r.searchChargingSessions(query, parkingUsageID)
+}
+
+func (r *TimescaleChargingSessionRepository) SearchChargingSessionsByCustomerID(customerID string) (*entities.ChargingSessions, error) {
+	query := `SELECT ` + chargingSessionColumns + ` FROM charging_session WHERE customer_id = $1 ORDER BY started_at DESC`
+	return r.searchChargingSessions(query, customerID)
+}
+
+func (r *TimescaleChargingSessionRepository) SearchOpenChargingSessions() (*entities.ChargingSessions, error) {
+	query := `SELECT ` + chargingSessionColumns + ` FROM charging_session WHERE status IN ($1, $2) ORDER BY started_at`
+	return r.searchChargingSessions(query, entities.ChargingStatusActive, entities.ChargingStatusCompleted)
+}
+
+func (r *TimescaleChargingSessionRepository) SearchOpenChargingSessionByParkingID(parkingID int) (*entities.ChargingSession, error) {
+	ctx := context.Background()
+	query := `SELECT ` + chargingSessionColumns + ` FROM charging_session
+	WHERE parking_id = $1 AND status IN ($2, $3) ORDER BY started_at DESC LIMIT 1`
+	var s entities.ChargingSession
+	err := scanChargingSession(r.dbPool.QueryRow(ctx, query, parkingID, entities.ChargingStatusActive, entities.ChargingStatusCompleted), &s)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *TimescaleChargingSessionRepository) searchChargingSessions(query string, args ...interface{}) (*entities.ChargingSessions, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := entities.ChargingSessions{}
+	for rows.Next() {
+		var s entities.ChargingSession
+		if err := scanChargingSession(rows, &s); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &sessions, nil
+}
+
+func (r *TimescaleChargingSessionRepository) CreateChargingSession(session *entities.ChargingSession) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO charging_session (
+		parking_usage_id, parking_id, customer_id, visitor_rut, energy_source, status,
+		started_at, completed_at, ended_at, max_minutes, energy_kwh, tariff_id, cost, currency, notified_at, escalated_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		session.ParkingUsageID, session.ParkingID, session.CustomerID, session.VisitorRut, session.EnergySource,
+		session.Status, session.StartedAt, session.CompletedAt, session.EndedAt, session.MaxMinutes,
+		session.EnergyKWh, session.TariffID, session.Cost, session.Currency, session.NotifiedAt, session.EscalatedAt,
+	).Scan(&session.ID)
+}
+
+func (r *TimescaleChargingSessionRepository) UpdateChargingSessionByID(session *entities.ChargingSession) error {
+	ctx := context.Background()
+	query := `UPDATE charging_session SET
+		status = $2, completed_at = $3, ended_at = $4, max_minutes = $5, energy_kwh = $6,
+		tariff_id = $7, cost = $8, currency = $9, notified_at = $10, escalated_at = $11
+	WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query,
+		session.ID, session.Status, session.CompletedAt, session.EndedAt, session.MaxMinutes, session.EnergyKWh,
+		session.TariffID, session.Cost, session.Currency, session.NotifiedAt, session.EscalatedAt,
+	)
+	return err
+}
+
+func scanChargingSession(row pgx.Row, s *entities.ChargingSession) error {
+	return row.Scan(&s.ID, &s.ParkingUsageID, &s.ParkingID, &s.CustomerID, &s.VisitorRut, &s.EnergySource, &s.Status,
+		&s.StartedAt, &s.CompletedAt, &s.EndedAt, &s.MaxMinutes, &s.EnergyKWh, &s.TariffID, &s.Cost, &s.Currency,
+		&s.NotifiedAt, &s.EscalatedAt)
+}
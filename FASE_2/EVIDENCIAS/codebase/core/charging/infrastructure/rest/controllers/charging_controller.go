@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/charging/application"
+	"github.com/gonzalohonorato/servercorego/core/charging/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/charging/domain/gateways"
+	"github.com/gonzalohonorato/servercorego/core/charging/domain/repositories"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	parkingUsageRepositories "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	paymentGateways "github.com/gonzalohonorato/servercorego/core/payment/domain/gateways"
+	paymentRepositories "github.com/gonzalohonorato/servercorego/core/payment/domain/repositories"
+	tariffRepositories "github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+	"github.com/gorilla/mux"
+)
+
+type ChargingController struct {
+	ChargingUsecase *application.ChargingUsecase
+}
+
+func NewChargingController(
+	chargingSessionRepository repositories.ChargingSessionRepository,
+	parkingUsageRepository parkingUsageRepositories.ParkingUsageRepository,
+	parkingRepository parkingRepositories.ParkingRepository,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+	tariffRepository tariffRepositories.TariffRepository,
+	parkingChargeRepository tariffRepositories.ParkingChargeRepository,
+	ledgerRepository paymentRepositories.LedgerRepository,
+	paymentGateway paymentGateways.PaymentGateway,
+	chargerClient gateways.ChargerClient,
+	wsService *infrastructure.WebSocketService,
+	calendarEventRepository calendarRepositories.CalendarEventRepository,
+) *ChargingController {
+	chargingUseCase := application.NewChargingUsecase(
+		chargingSessionRepository,
+		parkingUsageRepository,
+		parkingRepository,
+		vehicleRepository,
+		tariffRepository,
+		parkingChargeRepository,
+		ledgerRepository,
+		paymentGateway,
+		chargerClient,
+		wsService,
+		calendarEventRepository,
+	)
+
+	return &ChargingController{
+		ChargingUsecase: chargingUseCase,
+	}
+}
+
+func (uc *ChargingController) GetChargingSessionByID(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	session, err := uc.ChargingUsecase.SearchChargingSessionByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Charging session not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+func (uc *ChargingController) GetOpenChargingSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := uc.ChargingUsecase.SearchOpenChargingSessions()
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Charging sessions not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+func (uc *ChargingController) GetChargingSessionsByParkingUsageID(w http.ResponseWriter, r *http.Request) {
+	usageID, err := strconv.Atoi(mux.Vars(r)["parkingUsageID"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	sessions, err := uc.ChargingUsecase.SearchChargingSessionsByParkingUsageID(usageID)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Charging sessions not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+func (uc *ChargingController) GetChargingSessionsByCustomerID(w http.ResponseWriter, r *http.Request) {
+	sessions, err := uc.ChargingUsecase.SearchChargingSessionsByCustomerID(mux.Vars(r)["customerID"])
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Charging sessions not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+func (uc *ChargingController) PostChargingSession(w http.ResponseWriter, r *http.Request) {
+	var request entities.StartChargingRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.ParkingUsageID == 0 {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	session, err := uc.ChargingUsecase.StartChargingSession(&request)
+	if err != nil {
+		if errors.Is(err, application.ErrChargerBusy) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, "Error starting charging session: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+func (uc *ChargingController) PutChargingSessionEnergy(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		EnergyKWh *float64 `json:"energyKwh"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.EnergyKWh == nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	session, err := uc.ChargingUsecase.RecordEnergy(idInt, *request.EnergyKWh)
+	if err != nil {
+		http.Error(w, "Error recording energy: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+func (uc *ChargingController) PostChargingSessionComplete(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	session, err := uc.ChargingUsecase.CompleteChargingSession(idInt)
+	if err != nil {
+		http.Error(w, "Error completing charging session: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+func (uc *ChargingController) PostChargingSessionStop(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		EnergyKWh *float64 `json:"energyKwh"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+	}
+
+	session, err := uc.ChargingUsecase.StopChargingSession(idInt, request.EnergyKWh)
+	if err != nil {
+		http.Error(w, "Error stopping charging session: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+func (uc *ChargingController) GetChargerReading(w http.ResponseWriter, r *http.Request) {
+	parkingID, err := strconv.Atoi(mux.Vars(r)["parkingID"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	reading, err := uc.ChargingUsecase.ReadCharger(parkingID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reading)
+}
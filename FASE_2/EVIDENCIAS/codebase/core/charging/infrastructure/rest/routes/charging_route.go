@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/charging/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func ChargingRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewChargingController(
+		container.ProvideChargingSessionRepository(),
+		container.ProvideParkingUsageRepository(),
+		container.ProvideParkingRepository(),
+		container.ProvideVehicleRepository(),
+		container.ProvideTariffRepository(),
+		container.ProvideParkingChargeRepository(),
+		container.ProvideLedgerRepository(),
+		container.ProvidePaymentGateway(),
+		container.ProvideChargerClient(),
+		container.ProvideWebSocketService(),
+		container.ProvideCalendarEventRepository(),
+	)
+
+	router.HandleFunc("/charging-sessions", controller.PostChargingSession).Methods("POST")
+	router.HandleFunc("/charging-sessions/open", controller.GetOpenChargingSessions).Methods("GET")
+	router.HandleFunc("/charging-sessions/usage/{parkingUsageID}", controller.GetChargingSessionsByParkingUsageID).Methods("GET")
+	router.HandleFunc("/charging-sessions/customer/{customerID}", controller.GetChargingSessionsByCustomerID).Methods("GET")
+	router.HandleFunc("/charging-sessions/{id}", controller.GetChargingSessionByID).Methods("GET")
+	router.HandleFunc("/charging-sessions/{id}/energy", controller.PutChargingSessionEnergy).Methods("PUT")
+	router.HandleFunc("/charging-sessions/{id}/complete", controller.PostChargingSessionComplete).Methods("POST")
+	router.HandleFunc("/charging-sessions/{id}/stop", controller.PostChargingSessionStop).Methods("POST")
+
+	router.HandleFunc("/chargers/{parkingID}/reading", controller.GetChargerReading).Methods("GET")
+}
@@ -0,0 +1,258 @@
+package application
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	bookingRuleRepositories "github.com/gonzalohonorato/servercorego/core/bookingrule/domain/repositories"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/closure/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/closure/domain/repositories"
+	noShowRepositories "github.com/gonzalohonorato/servercorego/core/noshow/domain/repositories"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	quotaRepositories "github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
+	reservationApplication "github.com/gonzalohonorato/servercorego/core/reservation/application"
+	reservationRepositories "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+)
+
+type ClosureUsecase struct {
+	ClosureRepository  repositories.ClosureRepository
+	ParkingRepository  parkingRepositories.ParkingRepository
+	ReservationUsecase *reservationApplication.ReservationUsecase
+	WebSocketService   *infrastructure.WebSocketService
+}
+
+func NewClosureUsecase(
+	closureRepo repositories.ClosureRepository,
+	reservationRepo reservationRepositories.ReservationRepository,
+	parkingRepo parkingRepositories.ParkingRepository,
+	userRepo userRepositories.UserRepository,
+	quotaPolicyRepo quotaRepositories.QuotaPolicyRepository,
+	quotaMovementRepo quotaRepositories.QuotaMovementRepository,
+	bookingRuleRepo bookingRuleRepositories.BookingRuleRepository,
+	noShowRepo noShowRepositories.NoShowRepository,
+	suspensionRepo noShowRepositories.BookingSuspensionRepository,
+	wsService *infrastructure.WebSocketService,
+	vehicleRepo vehicleRepositories.VehicleRepository,
+	permitRepo parkingRepositories.AccessibilityPermitRepository,
+	calendarRepo calendarRepositories.CalendarEventRepository,
+) *ClosureUsecase {
+	return &ClosureUsecase{
+		ClosureRepository: closureRepo,
+		ParkingRepository: parkingRepo,
+		ReservationUsecase: reservationApplication.NewReservationUsecase(
+			reservationRepo,
+			parkingRepo,
+			userRepo,
+			quotaPolicyRepo,
+			quotaMovementRepo,
+			bookingRuleRepo,
+			noShowRepo,
+			suspensionRepo,
+			wsService,
+			vehicleRepo,
+			permitRepo,
+			closureRepo,
+			calendarRepo,
+		),
+		WebSocketService: wsService,
+	}
+}
+
+func (uc *ClosureUsecase) SearchClosureByID(id int) (*entities.ParkingClosure, error) {
+	return uc.ClosureRepository.SearchClosureByID(id)
+}
+
+func (uc *ClosureUsecase) SearchClosures() (*entities.ParkingClosures, error) {
+	return uc.ClosureRepository.SearchClosures()
+}
+
+func (uc *ClosureUsecase) SearchClosuresBetween(start, end time.Time) (*entities.ParkingClosures, error) {
+	return uc.ClosureRepository.SearchClosuresBetween(start, end)
+}
+
+func (uc *ClosureUsecase) CreateClosure(closure *entities.ParkingClosure) (*entities.ClosureImpact, error) {
+	if err := uc.validateClosure(closure); err != nil {
+		return nil, err
+	}
+
+	closure.CreatedAt = time.Now()
+	closure.CancelledAt = nil
+	closure.CancelledBy = nil
+	if err := uc.ClosureRepository.CreateClosure(closure); err != nil {
+		return nil, err
+	}
+
+	return uc.applyClosure(closure, "parking_closure_created")
+}
+
+func (uc *ClosureUsecase) UpdateClosure(closure *entities.ParkingClosure) (*entities.ClosureImpact, error) {
+	current, err := uc.ClosureRepository.SearchClosureByID(closure.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.CancelledAt != nil {
+		return nil, fmt.Errorf("el cierre %d fue cancelado y no puede modificarse", closure.ID)
+	}
+	if err := uc.validateClosure(closure); err != nil {
+		return nil, err
+	}
+
+	closure.CreatedBy = current.CreatedBy
+	closure.CreatedAt = current.CreatedAt
+	if err := uc.ClosureRepository.UpdateClosureByID(closure); err != nil {
+		return nil, err
+	}
+
+	return uc.applyClosure(closure, "parking_closure_updated")
+}
+
+func (uc *ClosureUsecase) CancelClosure(id int, cancelledBy string) (*entities.ParkingClosure, error) {
+	closure, err := uc.ClosureRepository.SearchClosureByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if closure.CancelledAt != nil {
+		return nil, fmt.Errorf("el cierre %d ya fue cancelado", id)
+	}
+
+	now := time.Now()
+	closure.CancelledAt = &now
+	closure.CancelledBy = &cancelledBy
+	if err := uc.ClosureRepository.UpdateClosureByID(closure); err != nil {
+		return nil, err
+	}
+
+	if uc.WebSocketService != nil {
+		uc.WebSocketService.BroadcastAdminAlert("parking_closure_cancelled", "low", map[string]interface{}{
+			"closure": closure,
+		})
+	}
+
+	return closure, nil
+}
+
+func (uc *ClosureUsecase) applyClosure(closure *entities.ParkingClosure, alertType string) (*entities.ClosureImpact, error) {
+	impact, err := uc.ReservationUsecase.ResolveClosureConflicts(closure)
+	if err != nil {
+		return nil, fmt.Errorf("cierre %d registrado, pero falló la reasignación de reservas: %w", closure.ID, err)
+	}
+
+	log.Printf("Cierre %d aplicado: %d reservas afectadas", closure.ID, len(impact.Conflicts))
+
+	if uc.WebSocketService != nil {
+		priority := "low"
+		for _, conflict := range impact.Conflicts {
+			if conflict.Outcome != entities.ClosureOutcomeRelocated {
+				priority = "medium"
+				break
+			}
+		}
+		uc.WebSocketService.BroadcastAdminAlert(alertType, priority, impact)
+	}
+
+	return impact, nil
+}
+
+func (uc *ClosureUsecase) validateClosure(closure *entities.ParkingClosure) error {
+	closure.Zone = strings.TrimSpace(closure.Zone)
+	closure.Reason = strings.TrimSpace(closure.Reason)
+	closure.CodeFrom = strings.ToUpper(strings.TrimSpace(closure.CodeFrom))
+	closure.CodeTo = strings.ToUpper(strings.TrimSpace(closure.CodeTo))
+
+	bySpots := len(closure.ParkingIDs) > 0 || closure.HasCodeRange()
+	if bySpots == (closure.Zone != "") {
+		return fmt.Errorf("el cierre debe indicar estacionamientos o una zona, pero no ambos")
+	}
+	if closure.Reason == "" {
+		return fmt.Errorf("el motivo del cierre es requerido")
+	}
+	if closure.StartsAt.IsZero() || closure.EndsAt.IsZero() {
+		return fmt.Errorf("el inicio y término del cierre son requeridos")
+	}
+	if !closure.EndsAt.After(closure.StartsAt) {
+		return fmt.Errorf("el término del cierre debe ser posterior al inicio")
+	}
+	if !closure.EndsAt.After(time.Now()) {
+		return fmt.Errorf("el cierre no puede terminar en el pasado")
+	}
+
+	if closure.HasCodeRange() {
+		parkingIDs, err := uc.parkingIDsInCodeRange(closure.CodeFrom, closure.CodeTo)
+		if err != nil {
+			return err
+		}
+		closure.ParkingIDs = parkingIDs
+		return nil
+	}
+
+	seen := make(map[int]bool, len(closure.ParkingIDs))
+	parkingIDs := make([]int, 0, len(closure.ParkingIDs))
+	for _, parkingID := range closure.ParkingIDs {
+		if seen[parkingID] {
+			continue
+		}
+		if _, err := uc.ParkingRepository.SearchParkingByID(parkingID); err != nil {
+			return fmt.Errorf("estacionamiento %d no encontrado", parkingID)
+		}
+		seen[parkingID] = true
+		parkingIDs = append(parkingIDs, parkingID)
+	}
+	closure.ParkingIDs = parkingIDs
+
+	return nil
+}
+
+// El rango se define por código (P0010 a P0020): ambos extremos deben compartir prefijo y se incluyen
+// todos los estacionamientos con ese prefijo cuyo número quede entre ellos
+func (uc *ClosureUsecase) parkingIDsInCodeRange(codeFrom, codeTo string) ([]int, error) {
+	if codeFrom == "" || codeTo == "" {
+		return nil, fmt.Errorf("el rango de estacionamientos requiere código inicial y final")
+	}
+	prefix, from, ok := splitSpotCode(codeFrom)
+	toPrefix, to, toOk := splitSpotCode(codeTo)
+	if !ok || !toOk || prefix != toPrefix {
+		return nil, fmt.Errorf("el rango %s-%s no es válido, ambos códigos deben tener el mismo prefijo y un número", codeFrom, codeTo)
+	}
+	if from > to {
+		return nil, fmt.Errorf("el código inicial %s es posterior al final %s", codeFrom, codeTo)
+	}
+
+	parkings, err := uc.ParkingRepository.SearchParkings()
+	if err != nil {
+		return nil, err
+	}
+
+	parkingIDs := []int{}
+	for _, parking := range *parkings {
+		codePrefix, number, ok := splitSpotCode(strings.ToUpper(strings.TrimSpace(parking.Code)))
+		if ok && codePrefix == prefix && number >= from && number <= to {
+			parkingIDs = append(parkingIDs, parking.ID)
+		}
+	}
+	if len(parkingIDs) == 0 {
+		return nil, fmt.Errorf("no hay estacionamientos en el rango %s-%s", codeFrom, codeTo)
+	}
+	return parkingIDs, nil
+}
+
+func splitSpotCode(code string) (string, int, bool) {
+	digits := len(code)
+	for digits > 0 && code[digits-1] >= '0' && code[digits-1] <= '9' {
+		digits--
+	}
+	if digits == len(code) {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(code[digits:])
+	if err != nil {
+		return "", 0, false
+	}
+	return code[:digits], number, true
+}
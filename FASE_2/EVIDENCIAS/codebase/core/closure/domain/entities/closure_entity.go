@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+const (
+	ClosureOutcomeRelocated = "relocated"
+	ClosureOutcomeCancelled = "cancelled"
+	ClosureOutcomeOccupied  = "occupied"
+)
+
+type ParkingClosure struct {
+	ID          int        `json:"id"`
+	ParkingIDs  []int      `json:"parkingIds"`
+	CodeFrom    string     `json:"codeFrom"`
+	CodeTo      string     `json:"codeTo"`
+	Zone        string     `json:"zone"`
+	StartsAt    time.Time  `json:"startsAt"`
+	EndsAt      time.Time  `json:"endsAt"`
+	Reason      string     `json:"reason"`
+	CreatedBy   string     `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CancelledAt *time.Time `json:"cancelledAt"`
+	CancelledBy *string    `json:"cancelledBy"`
+}
+
+type ParkingClosures []ParkingClosure
+
+func (c *ParkingClosure) HasCodeRange() bool {
+	return c.CodeFrom != "" || c.CodeTo != ""
+}
+
+func (c *ParkingClosure) AppliesTo(parkingID int, zone string) bool {
+	if len(c.ParkingIDs) > 0 {
+		for _, id := range c.ParkingIDs {
+			if id == parkingID {
+				return true
+			}
+		}
+		return false
+	}
+	return c.Zone != "" && c.Zone == zone
+}
+
+func (c *ParkingClosure) Overlaps(start, end time.Time) bool {
+	return c.CancelledAt == nil && c.StartsAt.Before(end) && c.EndsAt.After(start)
+}
+
+func (closures ParkingClosures) Find(parkingID int, zone string, start, end time.Time) *ParkingClosure {
+	for i := range closures {
+		if closures[i].AppliesTo(parkingID, zone) && closures[i].Overlaps(start, end) {
+			return &closures[i]
+		}
+	}
+	return nil
+}
+
+type ClosureConflict struct {
+	ReservationID int       `json:"reservationId"`
+	CustomerID    string    `json:"customerId"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+	FromParkingID int       `json:"fromParkingId"`
+	ToParkingID   *int      `json:"toParkingId"`
+	Outcome       string    `json:"outcome"`
+}
+
+type ClosureImpact struct {
+	Closure   ParkingClosure    `json:"closure"`
+	Conflicts []ClosureConflict `json:"conflicts"`
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/closure/domain/entities"
+)
+
+type ClosureRepository interface {
+	SearchClosureByID(id int) (*entities.ParkingClosure, error)
+	SearchClosures() (*entities.ParkingClosures, error)
+	SearchClosuresBetween(start, end time.Time) (*entities.ParkingClosures, error)
+	CreateClosure(closure *entities.ParkingClosure) error
+	UpdateClosureByID(closure *entities.ParkingClosure) error
+}
@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/closure/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleClosureRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleClosureRepository(pool *pgxpool.Pool) *TimescaleClosureRepository {
+	return &TimescaleClosureRepository{
+		dbPool: pool,
+	}
+}
+
+const closureColumns = `id, parking_ids, code_from, code_to, zone, starts_at, ends_at, reason, created_by, created_at, cancelled_at, cancelled_by`
+
+func (r *TimescaleClosureRepository) SearchClosureByID(id int) (*entities.ParkingClosure, error) {
+	ctx := context.Background()
+	query := `SELECT ` + closureColumns + ` FROM parking_closure WHERE id = $1`
+	var c entities.ParkingClosure
+	err := r.dbPool.QueryRow(ctx, query, id).Scan(&c.ID, &c.ParkingIDs, &c.CodeFrom, &c.CodeTo, &c.Zone, &c.StartsAt, &c.EndsAt, &c.Reason,
+		&c.CreatedBy, &c.CreatedAt, &c.CancelledAt, &c.CancelledBy)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *TimescaleClosureRepository) SearchClosures() (*entities.ParkingClosures, error) {
+	return r.searchClosures(`SELECT ` + closureColumns + ` FROM parking_closure ORDER BY starts_at DESC`)
+}
+
+func (r *TimescaleClosureRepository) SearchClosuresBetween(start, end time.Time) (*entities.ParkingClosures, error) {
+	query := `SELECT ` + closureColumns + ` FROM parking_closure
+	WHERE cancelled_at IS NULL AND starts_at < $2 AND ends_at > $1
+	ORDER BY starts_at`
+	return r.searchClosures(query, start, end)
+}
+
+func (r *TimescaleClosureRepository) searchClosures(query string, args ...interface{}) (*entities.ParkingClosures, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	closures := entities.ParkingClosures{}
+	for rows.Next() {
+		var c entities.ParkingClosure
+		if err := rows.Scan(&c.ID, &c.ParkingIDs, &c.CodeFrom, &c.CodeTo, &c.Zone, &c.StartsAt, &c.EndsAt, &c.Reason,
+			&c.CreatedBy, &c.CreatedAt, &c.CancelledAt, &c.CancelledBy); err != nil {
+			return nil, err
+		}
+		closures = append(closures, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &closures, nil
+}
+
+func (r *TimescaleClosureRepository) CreateClosure(closure *entities.ParkingClosure) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO parking_closure (
+		parking_ids, code_from, code_to, zone, starts_at, ends_at, reason, created_by, created_at, cancelled_at, cancelled_by
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		closure.ParkingIDs, closure.CodeFrom, closure.CodeTo, closure.Zone, closure.StartsAt, closure.EndsAt, closure.Reason,
+		closure.CreatedBy, closure.CreatedAt, closure.CancelledAt, closure.CancelledBy,
+	).Scan(&closure.ID)
+}
+
+func (r *TimescaleClosureRepository) UpdateClosureByID(closure *entities.ParkingClosure) error {
+	ctx := context.Background()
+	query := `UPDATE parking_closure SET
+		parking_ids = $2, code_from = $3, code_to = $4, zone = $5, starts_at = $6, ends_at = $7, reason = $8,
+		cancelled_at = $9, cancelled_by = $10
+	WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query,
+		closure.ID, closure.ParkingIDs, closure.CodeFrom, closure.CodeTo, closure.Zone, closure.StartsAt, closure.EndsAt, closure.Reason,
+		closure.CancelledAt, closure.CancelledBy,
+	)
+	return err
+}
@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	bookingRuleRepositories "github.com/gonzalohonorato/servercorego/core/bookingrule/domain/repositories"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/closure/application"
+	"github.com/gonzalohonorato/servercorego/core/closure/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/closure/domain/repositories"
+	noShowRepositories "github.com/gonzalohonorato/servercorego/core/noshow/domain/repositories"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	quotaRepositories "github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
+	reservationRepositories "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+	"github.com/gorilla/mux"
+)
+
+type ClosureController struct {
+	ClosureUsecase *application.ClosureUsecase
+}
+
+func NewClosureController(
+	closureRepository repositories.ClosureRepository,
+	reservationRepository reservationRepositories.ReservationRepository,
+	parkingRepository parkingRepositories.ParkingRepository,
+	userRepository userRepositories.UserRepository,
+	quotaPolicyRepository quotaRepositories.QuotaPolicyRepository,
+	quotaMovementRepository quotaRepositories.QuotaMovementRepository,
+	bookingRuleRepository bookingRuleRepositories.BookingRuleRepository,
+	noShowRepository noShowRepositories.NoShowRepository,
+	bookingSuspensionRepository noShowRepositories.BookingSuspensionRepository,
+	wsService *infrastructure.WebSocketService,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+	accessibilityPermitRepository parkingRepositories.AccessibilityPermitRepository,
+	calendarEventRepository calendarRepositories.CalendarEventRepository,
+) *ClosureController {
+	closureUseCase := application.NewClosureUsecase(
+		closureRepository,
+		reservationRepository,
+		parkingRepository,
+		userRepository,
+		quotaPolicyRepository,
+		quotaMovementRepository,
+		bookingRuleRepository,
+		noShowRepository,
+		bookingSuspensionRepository,
+		wsService,
+		vehicleRepository,
+		accessibilityPermitRepository,
+		calendarEventRepository,
+	)
+
+	return &ClosureController{
+		ClosureUsecase: closureUseCase,
+	}
+}
+
+func (uc *ClosureController) GetClosureByID(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	closure, err := uc.ClosureUsecase.SearchClosureByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Closure not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(closure)
+}
+
+func (uc *ClosureController) GetClosures(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var closures *entities.ParkingClosures
+	var err error
+	if query.Get("from") != "" || query.Get("to") != "" {
+		start, errFrom := utils.ParseDateTime(query.Get("from"))
+		end, errTo := utils.ParseDateTime(query.Get("to"))
+		if errFrom != nil || errTo != nil || !end.After(start) {
+			http.Error(w, "Parámetros from y to inválidos", http.StatusBadRequest)
+			return
+		}
+		closures, err = uc.ClosureUsecase.SearchClosuresBetween(start, end)
+	} else {
+		closures, err = uc.ClosureUsecase.SearchClosures()
+	}
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Closures not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(closures)
+}
+
+func (uc *ClosureController) PostClosure(w http.ResponseWriter, r *http.Request) {
+	var closure entities.ParkingClosure
+	if err := json.NewDecoder(r.Body).Decode(&closure); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	impact, err := uc.ClosureUsecase.CreateClosure(&closure)
+	if err != nil {
+		http.Error(w, "Error creating closure: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(impact)
+}
+
+func (uc *ClosureController) PutClosure(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var closure entities.ParkingClosure
+	if err := json.NewDecoder(r.Body).Decode(&closure); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	closure.ID = idInt
+
+	impact, err := uc.ClosureUsecase.UpdateClosure(&closure)
+	if err != nil {
+		http.Error(w, "Error updating closure: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(impact)
+}
+
+func (uc *ClosureController) PostClosureCancel(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		CancelledBy string `json:"cancelledBy"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.CancelledBy == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	closure, err := uc.ClosureUsecase.CancelClosure(idInt, request.CancelledBy)
+	if err != nil {
+		http.Error(w, "Error cancelling closure: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(closure)
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/closure/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func ClosureRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewClosureController(
+		container.ProvideClosureRepository(),
+		container.ProvideReservationRepository(),
+		container.ProvideParkingRepository(),
+		container.ProvideUserRepository(),
+		container.ProvideQuotaPolicyRepository(),
+		container.ProvideQuotaMovementRepository(),
+		container.ProvideBookingRuleRepository(),
+		container.ProvideNoShowRepository(),
+		container.ProvideBookingSuspensionRepository(),
+		container.ProvideWebSocketService(),
+		container.ProvideVehicleRepository(),
+		container.ProvideAccessibilityPermitRepository(),
+		container.ProvideCalendarEventRepository(),
+	)
+
+	router.HandleFunc("/closures", controller.GetClosures).Methods("GET")
+	router.HandleFunc("/closures", controller.PostClosure).Methods("POST")
+	router.HandleFunc("/closures/{id}", controller.GetClosureByID).Methods("GET")
+	router.HandleFunc("/closures/{id}", controller.PutClosure).Methods("PUT")
+	router.HandleFunc("/closures/{id}/cancel", controller.PostClosureCancel).Methods("POST")
+}
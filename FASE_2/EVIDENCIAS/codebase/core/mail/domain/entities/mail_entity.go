@@ -0,0 +1,7 @@
+package entities
+
+type Message struct {
+	To       string `json:"to"`
+	Subject  string `json:"subject"`
+	TextBody string `json:"-"`
+}
@@ -0,0 +1,7 @@
+package gateways
+
+import "github.com/gonzalohonorato/servercorego/core/mail/domain/entities"
+
+type Mailer interface {
+	Send(message *entities.Message) error
+}
@@ -0,0 +1,19 @@
+package gateways
+
+import (
+	"log"
+
+	"github.com/gonzalohonorato/servercorego/core/mail/domain/entities"
+)
+
+// Solo registra destinatario y asunto: el cuerpo puede contener enlaces de acceso y nunca se escribe en el log
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(message *entities.Message) error {
+	log.Printf("Correo no enviado (MAIL_PROVIDER=log) a %s: %s", message.To, message.Subject)
+	return nil
+}
@@ -0,0 +1,99 @@
+package gateways
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/mail/domain/entities"
+)
+
+// Envía por SMTP; sin usuario configurado no autentica, lo que permite usar un receptor local como MailHog o Mailpit
+type SMTPMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     mail.Address
+}
+
+func NewSMTPMailer() (*SMTPMailer, error) {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil, fmt.Errorf("SMTP_HOST no configurado")
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	from, err := mail.ParseAddress(os.Getenv("SMTP_FROM"))
+	if err != nil {
+		return nil, fmt.Errorf("SMTP_FROM inválido: %w", err)
+	}
+
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: os.Getenv("SMTP_USERNAME"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     *from,
+	}, nil
+}
+
+func (m *SMTPMailer) Send(message *entities.Message) error {
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("destinatario inválido: %w", err)
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	body, err := m.build(to, message)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(net.JoinHostPort(m.host, m.port), auth, m.from.Address, []string{to.Address}, body); err != nil {
+		return fmt.Errorf("error al enviar correo a %s: %w", to.Address, err)
+	}
+	return nil
+}
+
+func (m *SMTPMailer) build(to *mail.Address, message *entities.Message) ([]byte, error) {
+	if strings.ContainsAny(message.Subject, "\r\n") {
+		return nil, fmt.Errorf("asunto de correo inválido")
+	}
+
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", m.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "base64"},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h[0], h[1])
+	}
+	buf.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(message.TextBody))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes(), nil
+}
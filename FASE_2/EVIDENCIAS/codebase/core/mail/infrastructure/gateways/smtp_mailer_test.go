@@ -0,0 +1,182 @@
+package gateways
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/mail/domain/entities"
+)
+
+// Receptor SMTP mínimo sobre loopback que entrega el contenido del DATA de cada sesión
+func startSMTPSink(t *testing.T) (string, string, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("no se pudo abrir el receptor SMTP: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, messages)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	return host, port, messages
+}
+
+func serveSMTP(conn net.Conn, messages chan<- string) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.Fields(line + " ")[0])
+		switch command {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 localhost")
+		case "MAIL", "RCPT", "RSET", "NOOP":
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 fin con <CRLF>.<CRLF>")
+			data, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			messages <- string(data)
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 adiós")
+			return
+		default:
+			text.PrintfLine("502 no implementado")
+		}
+	}
+}
+
+func newTestMailer(host, port string) *SMTPMailer {
+	return &SMTPMailer{
+		host: host,
+		port: port,
+		from: mail.Address{Name: "Estacionamiento", Address: "no-reply@campus.cl"},
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	host, port, messages := startSMTPSink(t)
+	mailer := newTestMailer(host, port)
+
+	body := strings.Repeat("Tu reserva quedó confirmada para mañana a las 08:00. ", 4)
+	err := mailer.Send(&entities.Message{
+		To:       "Ana Pérez <ana@campus.cl>",
+		Subject:  "Invitación al estacionamiento",
+		TextBody: body,
+	})
+	if err != nil {
+		t.Fatalf("Send devolvió error: %v", err)
+	}
+
+	var raw string
+	select {
+	case raw = <-messages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("el receptor SMTP no recibió el mensaje")
+	}
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(raw)))
+	if err != nil {
+		t.Fatalf("mensaje recibido inválido: %v", err)
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Address != "no-reply@campus.cl" || from[0].Name != "Estacionamiento" {
+		t.Errorf("From = %q, se esperaba Estacionamiento <no-reply@campus.cl>", msg.Header.Get("From"))
+	}
+	to, err := msg.Header.AddressList("To")
+	if err != nil || len(to) != 1 || to[0].Address != "ana@campus.cl" || to[0].Name != "Ana Pérez" {
+		t.Errorf("To = %q, se esperaba Ana Pérez <ana@campus.cl>", msg.Header.Get("To"))
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "Invitación al estacionamiento" {
+		t.Errorf("Subject decodificado = %q (%v), se esperaba %q", subject, err, "Invitación al estacionamiento")
+	}
+	if _, err := mail.ParseDate(msg.Header.Get("Date")); err != nil {
+		t.Errorf("Date inválida %q: %v", msg.Header.Get("Date"), err)
+	}
+	expectedHeaders := map[string]string{
+		"MIME-Version":              "1.0",
+		"Content-Type":              "text/plain; charset=utf-8",
+		"Content-Transfer-Encoding": "base64",
+	}
+	for name, want := range expectedHeaders {
+		if got := msg.Header.Get(name); got != want {
+			t.Errorf("%s = %q, se esperaba %q", name, got, want)
+		}
+	}
+
+	encoded, err := io.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatalf("no se pudo leer el cuerpo: %v", err)
+	}
+	// DotReader entrega las líneas terminadas en LF
+	lines := strings.Split(strings.TrimRight(string(encoded), "\r\n"), "\n")
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		lines[i] = line
+		if len(line) > 76 {
+			t.Errorf("la línea %d del cuerpo tiene %d caracteres, el máximo es 76", i+1, len(line))
+		}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+	if err != nil {
+		t.Fatalf("el cuerpo no es base64 válido: %v", err)
+	}
+	if string(decoded) != body {
+		t.Errorf("cuerpo decodificado = %q, se esperaba %q", decoded, body)
+	}
+}
+
+func TestSMTPMailerSendRejectsHeaderInjection(t *testing.T) {
+	host, port, messages := startSMTPSink(t)
+	mailer := newTestMailer(host, port)
+
+	subjects := []string{
+		"Hola\r\nBcc: intruso@example.com",
+		"Hola\nBcc: intruso@example.com",
+		"Hola\rBcc: intruso@example.com",
+	}
+	for _, subject := range subjects {
+		err := mailer.Send(&entities.Message{
+			To:       "ana@campus.cl",
+			Subject:  subject,
+			TextBody: "cuerpo",
+		})
+		if err == nil {
+			t.Errorf("Send(%q) no devolvió error", subject)
+		}
+	}
+
+	select {
+	case raw := <-messages:
+		t.Errorf("no debía enviarse ningún mensaje, se recibió:\n%s", raw)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
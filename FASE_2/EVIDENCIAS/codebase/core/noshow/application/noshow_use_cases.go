@@ -0,0 +1,256 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/noshow/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/noshow/domain/repositories"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+)
+
+var ErrBookingSuspended = errors.New("las reservas del cliente están suspendidas")
+
+type NoShowUsecase struct {
+	NoShowRepository            repositories.NoShowRepository
+	BookingSuspensionRepository repositories.BookingSuspensionRepository
+	WebSocketService            *infrastructure.WebSocketService
+}
+
+func NewNoShowUsecase(
+	noShowRepo repositories.NoShowRepository,
+	suspensionRepo repositories.BookingSuspensionRepository,
+	wsService *infrastructure.WebSocketService,
+) *NoShowUsecase {
+	return &NoShowUsecase{
+		NoShowRepository:            noShowRepo,
+		BookingSuspensionRepository: suspensionRepo,
+		WebSocketService:            wsService,
+	}
+}
+
+func NoShowPolicyFromEnv() entities.NoShowPolicy {
+	return entities.NoShowPolicy{
+		StrikeLimit:    envPositiveInt("NO_SHOW_STRIKE_LIMIT", 3),
+		WindowDays:     envPositiveInt("NO_SHOW_WINDOW_DAYS", 30),
+		SuspensionDays: envPositiveInt("NO_SHOW_SUSPENSION_DAYS", 7),
+	}
+}
+
+func envPositiveInt(name string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func (uc *NoShowUsecase) SearchNoShowsByCustomerID(customerID string) (*entities.NoShows, error) {
+	return uc.NoShowRepository.SearchNoShowsByCustomerID(customerID)
+}
+
+func (uc *NoShowUsecase) SearchBookingSuspensionsByCustomerID(customerID string) (*entities.BookingSuspensions, error) {
+	return uc.BookingSuspensionRepository.SearchBookingSuspensionsByCustomerID(customerID)
+}
+
+func (uc *NoShowUsecase) CheckBookingAllowed(customerID string, at time.Time) error {
+	if customerID == "" {
+		return nil
+	}
+
+	suspension, err := uc.BookingSuspensionRepository.SearchActiveBookingSuspension(customerID, at)
+	if err != nil {
+		return fmt.Errorf("error al verificar suspensión: %w", err)
+	}
+	if suspension != nil {
+		return fmt.Errorf("%w hasta %s", ErrBookingSuspended, utils.InCampus(suspension.EndsAt).Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func (uc *NoShowUsecase) Status(customerID string, at time.Time) (*entities.NoShowStatus, error) {
+	policy := NoShowPolicyFromEnv()
+
+	noShows, strikes, err := uc.activeStrikes(customerID, policy, at)
+	if err != nil {
+		return nil, err
+	}
+
+	suspension, err := uc.BookingSuspensionRepository.SearchActiveBookingSuspension(customerID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.NoShowStatus{
+		CustomerID:       customerID,
+		Strikes:          strikes,
+		Policy:           policy,
+		ActiveSuspension: suspension,
+		NoShows:          *noShows,
+	}, nil
+}
+
+func (uc *NoShowUsecase) RecordNoShow(reservation *reservationEntities.Reservation) (*entities.NoShow, *entities.BookingSuspension, error) {
+	now := time.Now()
+	noShow := &entities.NoShow{
+		ReservationID:    reservation.ID,
+		CustomerID:       reservation.CustomerID,
+		ParkingID:        reservation.ParkingID,
+		ReservationStart: reservation.StartTime,
+		RecordedAt:       now,
+	}
+	if err := uc.NoShowRepository.CreateNoShow(noShow); err != nil {
+		return nil, nil, fmt.Errorf("error al registrar no-show: %w", err)
+	}
+
+	policy := NoShowPolicyFromEnv()
+	_, strikes, err := uc.activeStrikes(reservation.CustomerID, policy, now)
+	if err != nil {
+		return noShow, nil, err
+	}
+
+	if strikes < policy.StrikeLimit {
+		uc.notifyStrike(noShow, strikes, policy)
+		return noShow, nil, nil
+	}
+
+	active, err := uc.BookingSuspensionRepository.SearchActiveBookingSuspension(reservation.CustomerID, now)
+	if err != nil || active != nil {
+		return noShow, active, err
+	}
+
+	reason := fmt.Sprintf("%d inasistencias en %d días", strikes, policy.WindowDays)
+	suspension, err := uc.suspend(reservation.CustomerID, now, policy.SuspensionDays, reason, entities.SuspensionCreatedBySystem)
+	if err != nil {
+		return noShow, nil, err
+	}
+	return noShow, suspension, nil
+}
+
+func (uc *NoShowUsecase) WaiveNoShow(id int, waivedBy string, reason string) (*entities.NoShow, error) {
+	noShow, err := uc.NoShowRepository.SearchNoShowByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("no-show %d no encontrado: %w", id, err)
+	}
+	if noShow.Waived {
+		return nil, fmt.Errorf("el no-show %d ya fue condonado", id)
+	}
+
+	now := time.Now()
+	noShow.Waived = true
+	noShow.WaivedBy = &waivedBy
+	noShow.WaivedReason = &reason
+	noShow.WaivedAt = &now
+	if err := uc.NoShowRepository.UpdateNoShowByID(noShow); err != nil {
+		return nil, err
+	}
+	return noShow, nil
+}
+
+func (uc *NoShowUsecase) SuspendCustomer(customerID string, days int, reason string, createdBy string) (*entities.BookingSuspension, error) {
+	if customerID == "" || days <= 0 {
+		return nil, fmt.Errorf("se requiere el cliente y una cantidad de días positiva")
+	}
+	return uc.suspend(customerID, time.Now(), days, reason, createdBy)
+}
+
+func (uc *NoShowUsecase) LiftSuspension(id int, liftedBy string) (*entities.BookingSuspension, error) {
+	suspension, err := uc.BookingSuspensionRepository.SearchBookingSuspensionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if suspension == nil {
+		return nil, fmt.Errorf("suspensión %d no encontrada", id)
+	}
+	if suspension.LiftedAt != nil {
+		return nil, fmt.Errorf("la suspensión %d ya fue levantada", id)
+	}
+
+	now := time.Now()
+	suspension.LiftedAt = &now
+	suspension.LiftedBy = &liftedBy
+	if err := uc.BookingSuspensionRepository.UpdateBookingSuspensionByID(suspension); err != nil {
+		return nil, err
+	}
+
+	if uc.WebSocketService != nil {
+		uc.WebSocketService.NotifyUser(suspension.CustomerID, "booking_suspension_lifted", map[string]interface{}{
+			"suspensionId": suspension.ID,
+			"message":      "Tu suspensión de reservas fue levantada, ya puedes volver a reservar",
+		})
+	}
+	return suspension, nil
+}
+
+func (uc *NoShowUsecase) activeStrikes(customerID string, policy entities.NoShowPolicy, at time.Time) (*entities.NoShows, int, error) {
+	since := at.AddDate(0, 0, -policy.WindowDays)
+
+	// Solo una suspensión por inasistencias reinicia el conteo; el no-show que la gatilló se registra
+	// en el mismo instante en que comienza y queda fuera porque el límite es exclusivo
+	latest, err := uc.BookingSuspensionRepository.SearchLatestSystemBookingSuspension(customerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if latest != nil && latest.StartsAt.After(since) {
+		since = latest.StartsAt
+	}
+
+	noShows, err := uc.NoShowRepository.SearchNoShowsByCustomerIDSince(customerID, since)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	strikes := 0
+	for _, noShow := range *noShows {
+		if !noShow.Waived {
+			strikes++
+		}
+	}
+	return noShows, strikes, nil
+}
+
+func (uc *NoShowUsecase) suspend(customerID string, at time.Time, days int, reason string, createdBy string) (*entities.BookingSuspension, error) {
+	suspension := &entities.BookingSuspension{
+		CustomerID: customerID,
+		StartsAt:   at,
+		EndsAt:     at.AddDate(0, 0, days),
+		Reason:     reason,
+		CreatedBy:  createdBy,
+	}
+	if err := uc.BookingSuspensionRepository.CreateBookingSuspension(suspension); err != nil {
+		return nil, fmt.Errorf("error al crear suspensión: %w", err)
+	}
+
+	if uc.WebSocketService != nil {
+		uc.WebSocketService.NotifyUser(customerID, "booking_suspended", map[string]interface{}{
+			"suspensionId": suspension.ID,
+			"endsAt":       suspension.EndsAt,
+			"reason":       reason,
+			"message":      fmt.Sprintf("Tus reservas están suspendidas hasta el %s", utils.InCampus(suspension.EndsAt).Format("02-01-2006 15:04")),
+		})
+		uc.WebSocketService.BroadcastAdminAlert("booking_suspension", "low", map[string]interface{}{
+			"suspension": suspension,
+		})
+	}
+	return suspension, nil
+}
+
+func (uc *NoShowUsecase) notifyStrike(noShow *entities.NoShow, strikes int, policy entities.NoShowPolicy) {
+	if uc.WebSocketService == nil {
+		return
+	}
+
+	uc.WebSocketService.NotifyUser(noShow.CustomerID, "no_show_recorded", map[string]interface{}{
+		"noShowId":      noShow.ID,
+		"reservationId": noShow.ReservationID,
+		"strikes":       strikes,
+		"strikeLimit":   policy.StrikeLimit,
+		"windowDays":    policy.WindowDays,
+		"message": fmt.Sprintf("No te presentaste a tu reserva. Llevas %d de %d inasistencias permitidas en %d días",
+			strikes, policy.StrikeLimit, policy.WindowDays),
+	})
+}
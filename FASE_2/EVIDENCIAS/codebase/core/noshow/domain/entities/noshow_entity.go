@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+const SuspensionCreatedBySystem = "system"
+
+type NoShow struct {
+	ID               int        `json:"id"`
+	ReservationID    int        `json:"reservationId"`
+	CustomerID       string     `json:"customerId"`
+	ParkingID        int        `json:"parkingId"`
+	ReservationStart time.Time  `json:"reservationStart"`
+	RecordedAt       time.Time  `json:"recordedAt"`
+	Waived           bool       `json:"waived"`
+	WaivedBy         *string    `json:"waivedBy"`
+	WaivedReason     *string    `json:"waivedReason"`
+	WaivedAt         *time.Time `json:"waivedAt"`
+}
+
+type NoShows []NoShow
+
+type BookingSuspension struct {
+	ID         int        `json:"id"`
+	CustomerID string     `json:"customerId"`
+	StartsAt   time.Time  `json:"startsAt"`
+	EndsAt     time.Time  `json:"endsAt"`
+	Reason     string     `json:"reason"`
+	CreatedBy  string     `json:"createdBy"`
+	LiftedAt   *time.Time `json:"liftedAt"`
+	LiftedBy   *string    `json:"liftedBy"`
+}
+
+type BookingSuspensions []BookingSuspension
+
+func (s *BookingSuspension) IsActiveAt(at time.Time) bool {
+	return s.LiftedAt == nil && !at.Before(s.StartsAt) && at.Before(s.EndsAt)
+}
+
+type NoShowPolicy struct {
+	StrikeLimit    int `json:"strikeLimit"`
+	WindowDays     int `json:"windowDays"`
+	SuspensionDays int `json:"suspensionDays"`
+}
+
+type NoShowStatus struct {
+	CustomerID       string             `json:"customerId"`
+	Strikes          int                `json:"strikes"`
+	Policy           NoShowPolicy       `json:"policy"`
+	ActiveSuspension *BookingSuspension `json:"activeSuspension"`
+	NoShows          NoShows            `json:"noShows"`
+}
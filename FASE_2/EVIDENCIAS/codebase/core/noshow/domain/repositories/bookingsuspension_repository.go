@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/noshow/domain/entities"
+)
+
+type BookingSuspensionRepository interface {
+	SearchBookingSuspensionByID(id int) (*entities.BookingSuspension, error)
+	SearchBookingSuspensionsByCustomerID(customerID string) (*entities.BookingSuspensions, error)
+	SearchActiveBookingSuspension(customerID string, at time.Time) (*entities.BookingSuspension, error)
+	SearchLatestSystemBookingSuspension(customerID string) (*entities.BookingSuspension, error)
+	CreateBookingSuspension(suspension *entities.BookingSuspension) error
+	UpdateBookingSuspensionByID(suspension *entities.BookingSuspension) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/noshow/domain/entities"
+)
+
+type NoShowRepository interface {
+	SearchNoShowByID(id int) (*entities.NoShow, error)
+	SearchNoShowsByCustomerID(customerID string) (*entities.NoShows, error)
+	SearchNoShowsByCustomerIDSince(customerID string, since time.Time) (*entities.NoShows, error)
+	CreateNoShow(noShow *entities.NoShow) error
+	UpdateNoShowByID(noShow *entities.NoShow) error
+}
@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/noshow/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleBookingSuspensionRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleBookingSuspensionRepository(pool *pgxpool.Pool) *TimescaleBookingSuspensionRepository {
+	return &TimescaleBookingSuspensionRepository{
+		dbPool: pool,
+	}
+}
+
+const bookingSuspensionColumns = `id, customer_id, starts_at, ends_at, reason, created_by, lifted_at, lifted_by`
+
+func (r *TimescaleBookingSuspensionRepository) SearchBookingSuspensionByID(id int) (*entities.BookingSuspension, error) {
+	query := `SELECT ` + bookingSuspensionColumns + ` FROM booking_suspension WHERE id = $1`
+	return r.searchBookingSuspension(query, id)
+}
+
+func (r *TimescaleBookingSuspensionRepository) SearchBookingSuspensionsByCustomerID(customerID string) (*entities.BookingSuspensions, error) {
+	ctx := context.Background()
+	query := `SELECT ` + bookingSuspensionColumns + ` FROM booking_suspension WHERE customer_id = $1 ORDER BY starts_at DESC`
+	rows, err := r.dbPool.Query(ctx, query, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suspensions := entities.BookingSuspensions{}
+	for rows.Next() {
+		var s entities.BookingSuspension
+		if err := rows.Scan(&s.ID, &s.CustomerID, &s.StartsAt, &s.EndsAt, &s.Reason, &s.CreatedBy, &s.LiftedAt, &s.LiftedBy); err != nil {
+			return nil, err
+		}
+		suspensions = append(suspensions, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &suspensions, nil
+}
+
+func (r *TimescaleBookingSuspensionRepository) SearchActiveBookingSuspension(customerID string, at time.Time) (*entities.BookingSuspension, error) {
+	query := `SELECT ` + bookingSuspensionColumns + ` FROM booking_suspension
+	WHERE customer_id = $1 AND lifted_at IS NULL AND starts_at <= $2 AND ends_at > $2
+	ORDER BY ends_at DESC LIMIT 1`
+	return r.searchBookingSuspension(query, customerID, at)
+}
+
+func (r *TimescaleBookingSuspensionRepository) SearchLatestSystemBookingSuspension(customerID string) (*entities.BookingSuspension, error) {
+	query := `SELECT ` + bookingSuspensionColumns + ` FROM booking_suspension
+	WHERE customer_id = $1 AND created_by = $2 ORDER BY starts_at DESC LIMIT 1`
+	return r.searchBookingSuspension(query, customerID, entities.SuspensionCreatedBySystem)
+}
+
+func (r *TimescaleBookingSuspensionRepository) searchBookingSuspension(query string, args ...interface{}) (*entities.BookingSuspension, error) {
+	ctx := context.Background()
+	var s entities.BookingSuspension
+	err := r.dbPool.QueryRow(ctx, query, args...).Scan(&s.ID, &s.CustomerID, &s.StartsAt, &s.EndsAt, &s.Reason,
+		&s.CreatedBy, &s.LiftedAt, &s.LiftedBy)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *TimescaleBookingSuspensionRepository) CreateBookingSuspension(suspension *entities.BookingSuspension) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO booking_suspension (customer_id, starts_at, ends_at, reason, created_by, lifted_at, lifted_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query, suspension.CustomerID, suspension.StartsAt, suspension.EndsAt,
+		suspension.Reason, suspension.CreatedBy, suspension.LiftedAt, suspension.LiftedBy).Scan(&suspension.ID)
+}
+
+func (r *TimescaleBookingSuspensionRepository) UpdateBookingSuspensionByID(suspension *entities.BookingSuspension) error {
+	ctx := context.Background()
+	query := `
+	UPDATE booking_suspension SET
+		starts_at = $1, ends_at = $2, reason = $3, lifted_at = $4, lifted_by = $5
+	WHERE id = $6;
+`
+	_, err := r.dbPool.Exec(ctx, query, suspension.StartsAt, suspension.EndsAt, suspension.Reason,
+		suspension.LiftedAt, suspension.LiftedBy, suspension.ID)
+	return err
+}
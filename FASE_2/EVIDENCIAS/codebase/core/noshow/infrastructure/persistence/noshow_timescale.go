@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/noshow/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleNoShowRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleNoShowRepository(pool *pgxpool.Pool) *TimescaleNoShowRepository {
+	return &TimescaleNoShowRepository{
+		dbPool: pool,
+	}
+}
+
+const noShowColumns = `id, reservation_id, customer_id, parking_id, reservation_start, recorded_at,
+              waived, waived_by, waived_reason, waived_at`
+
+func (r *TimescaleNoShowRepository) SearchNoShowByID(id int) (*entities.NoShow, error) {
+	ctx := context.Background()
+	query := `SELECT ` + noShowColumns + ` FROM reservation_no_show WHERE id = $1`
+	row := r.dbPool.QueryRow(ctx, query, id)
+	var n entities.NoShow
+	err := row.Scan(&n.ID, &n.ReservationID, &n.CustomerID, &n.ParkingID, &n.ReservationStart, &n.RecordedAt,
+		&n.Waived, &n.WaivedBy, &n.WaivedReason, &n.WaivedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (r *TimescaleNoShowRepository) SearchNoShowsByCustomerID(customerID string) (*entities.NoShows, error) {
+	query := `SELECT ` + noShowColumns + ` FROM reservation_no_show WHERE customer_id = $1 ORDER BY recorded_at DESC`
+	return r.searchNoShows(query, customerID)
+}
+
+func (r *TimescaleNoShowRepository) SearchNoShowsByCustomerIDSince(customerID string, since time.Time) (*entities.NoShows, error) {
+	query := `SELECT ` + noShowColumns + ` FROM reservation_no_show
+	WHERE customer_id = $1 AND recorded_at > $2 ORDER BY recorded_at DESC`
+	return r.searchNoShows(query, customerID, since)
+}
+
+func (r *TimescaleNoShowRepository) searchNoShows(query string, args ...interface{}) (*entities.NoShows, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	noShows := entities.NoShows{}
+	for rows.Next() {
+		var n entities.NoShow
+		if err := rows.Scan(&n.ID, &n.ReservationID, &n.CustomerID, &n.ParkingID, &n.ReservationStart, &n.RecordedAt,
+			&n.Waived, &n.WaivedBy, &n.WaivedReason, &n.WaivedAt); err != nil {
+			return nil, err
+		}
+		noShows = append(noShows, n)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &noShows, nil
+}
+
+func (r *TimescaleNoShowRepository) CreateNoShow(noShow *entities.NoShow) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO reservation_no_show (
+		reservation_id, customer_id, parking_id, reservation_start, recorded_at,
+		waived, waived_by, waived_reason, waived_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		noShow.ReservationID, noShow.CustomerID, noShow.ParkingID, noShow.ReservationStart, noShow.RecordedAt,
+		noShow.Waived, noShow.WaivedBy, noShow.WaivedReason, noShow.WaivedAt).Scan(&noShow.ID)
+}
+
+func (r *TimescaleNoShowRepository) UpdateNoShowByID(noShow *entities.NoShow) error {
+	ctx := context.Background()
+	query := `
+	UPDATE reservation_no_show SET
+		waived = $1, waived_by = $2, waived_reason = $3, waived_at = $4
+	WHERE id = $5;
+`
+	_, err := r.dbPool.Exec(ctx, query, noShow.Waived, noShow.WaivedBy, noShow.WaivedReason, noShow.WaivedAt, noShow.ID)
+	return err
+}
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/noshow/application"
+	"github.com/gonzalohonorato/servercorego/core/noshow/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+	"github.com/gorilla/mux"
+)
+
+type NoShowController struct {
+	NoShowUsecase *application.NoShowUsecase
+}
+
+func NewNoShowController(
+	noShowRepository repositories.NoShowRepository,
+	bookingSuspensionRepository repositories.BookingSuspensionRepository,
+	wsService *infrastructure.WebSocketService,
+) *NoShowController {
+	noShowUseCase := application.NewNoShowUsecase(noShowRepository, bookingSuspensionRepository, wsService)
+
+	return &NoShowController{
+		NoShowUsecase: noShowUseCase,
+	}
+}
+
+func (uc *NoShowController) GetNoShowStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	status, err := uc.NoShowUsecase.Status(vars["customerID"], time.Now())
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "No-show status not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func (uc *NoShowController) PostNoShowWaiver(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		WaivedBy string `json:"waivedBy"`
+		Reason   string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.WaivedBy == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	noShow, err := uc.NoShowUsecase.WaiveNoShow(idInt, request.WaivedBy, request.Reason)
+	if err != nil {
+		http.Error(w, "Error waiving no-show: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(noShow)
+}
+
+func (uc *NoShowController) GetBookingSuspensionsByCustomerID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	suspensions, err := uc.NoShowUsecase.SearchBookingSuspensionsByCustomerID(vars["customerID"])
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Booking suspensions not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suspensions)
+}
+
+func (uc *NoShowController) PostBookingSuspension(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		CustomerID string `json:"customerId"`
+		Days       int    `json:"days"`
+		Reason     string `json:"reason"`
+		CreatedBy  string `json:"createdBy"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.CreatedBy == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	suspension, err := uc.NoShowUsecase.SuspendCustomer(request.CustomerID, request.Days, request.Reason, request.CreatedBy)
+	if err != nil {
+		http.Error(w, "Error creating booking suspension: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(suspension)
+}
+
+func (uc *NoShowController) PostBookingSuspensionLift(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		LiftedBy string `json:"liftedBy"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.LiftedBy == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	suspension, err := uc.NoShowUsecase.LiftSuspension(idInt, request.LiftedBy)
+	if err != nil {
+		http.Error(w, "Error lifting booking suspension: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suspension)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/noshow/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func NoShowRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewNoShowController(
+		container.ProvideNoShowRepository(),
+		container.ProvideBookingSuspensionRepository(),
+		container.ProvideWebSocketService(),
+	)
+
+	router.HandleFunc("/no-shows/customer/{customerID}", controller.GetNoShowStatus).Methods("GET")
+	router.HandleFunc("/no-shows/{id}/waive", controller.PostNoShowWaiver).Methods("POST")
+
+	router.HandleFunc("/booking-suspensions", controller.PostBookingSuspension).Methods("POST")
+	router.HandleFunc("/booking-suspensions/customer/{customerID}", controller.GetBookingSuspensionsByCustomerID).Methods("GET")
+	router.HandleFunc("/booking-suspensions/{id}/lift", controller.PostBookingSuspensionLift).Methods("POST")
+}
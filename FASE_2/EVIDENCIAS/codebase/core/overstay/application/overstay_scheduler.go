@@ -0,0 +1,81 @@
+package application
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+type OverstayScheduler struct {
+	usecase *OverstayUsecase
+	stop    chan bool
+	running bool
+}
+
+func NewOverstayScheduler(usecase *OverstayUsecase) *OverstayScheduler {
+	return &OverstayScheduler{
+		usecase: usecase,
+		stop:    make(chan bool),
+		running: false,
+	}
+}
+
+func (s *OverstayScheduler) Start() {
+	if s.running {
+		log.Println("El scheduler de permanencias ya está en ejecución")
+		return
+	}
+
+	s.running = true
+	log.Println("Iniciando scheduler de detección de permanencias excedidas...")
+
+	s.runOverstayTask()
+
+	go s.startOverstayScheduler()
+}
+
+func (s *OverstayScheduler) startOverstayScheduler() {
+	intervalStr := os.Getenv("OVERSTAY_CHECK_INTERVAL")
+	interval := 5 * time.Minute
+
+	if intervalStr != "" {
+		if minutes, err := strconv.Atoi(intervalStr); err == nil && minutes > 0 {
+			interval = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	log.Printf("Iniciando scheduler de permanencias con intervalo de %v", interval)
+	ticker := time.NewTicker(interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runOverstayTask()
+		case <-s.stop:
+			ticker.Stop()
+			log.Println("Scheduler de permanencias detenido")
+			return
+		}
+	}
+}
+
+func (s *OverstayScheduler) Stop() {
+	if s.running {
+		close(s.stop)
+		s.running = false
+		log.Println("Deteniendo scheduler de permanencias...")
+	}
+}
+
+func (s *OverstayScheduler) runOverstayTask() {
+	log.Println("Ejecutando tarea de detección de permanencias excedidas...")
+	count, err := s.usecase.DetectOverstays()
+	if err != nil {
+		log.Printf("Error al detectar permanencias excedidas: %v", err)
+	} else {
+		if count > 0 {
+			log.Printf("Proceso completado: %d permanencias excedidas detectadas", count)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package application
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/overstay/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/overstay/domain/repositories"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	parkingUsageEntities "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
+	parkingUsageRepositories "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	reservationRepositories "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+)
+
+const defaultCampusClosingTime = "22:00"
+
+type OverstayUsecase struct {
+	OverstayRepository     repositories.OverstayRepository
+	ZoneHoursRepository    repositories.ZoneHoursRepository
+	ParkingUsageRepository parkingUsageRepositories.ParkingUsageRepository
+	ParkingRepository      parkingRepositories.ParkingRepository
+	ReservationRepository  reservationRepositories.ReservationRepository
+	VehicleRepository      vehicleRepositories.VehicleRepository
+	WebSocketService       *infrastructure.WebSocketService
+}
+
+func NewOverstayUsecase(
+	overstayRepo repositories.OverstayRepository,
+	zoneHoursRepo repositories.ZoneHoursRepository,
+	parkingUsageRepo parkingUsageRepositories.ParkingUsageRepository,
+	parkingRepo parkingRepositories.ParkingRepository,
+	reservationRepo reservationRepositories.ReservationRepository,
+	vehicleRepo vehicleRepositories.VehicleRepository,
+	wsService *infrastructure.WebSocketService,
+) *OverstayUsecase {
+	return &OverstayUsecase{
+		OverstayRepository:     overstayRepo,
+		ZoneHoursRepository:    zoneHoursRepo,
+		ParkingUsageRepository: parkingUsageRepo,
+		ParkingRepository:      parkingRepo,
+		ReservationRepository:  reservationRepo,
+		VehicleRepository:      vehicleRepo,
+		WebSocketService:       wsService,
+	}
+}
+
+func (uc *OverstayUsecase) SearchOverstayByID(id int) (*entities.Overstay, error) {
+	return uc.OverstayRepository.SearchOverstayByID(id)
+}
+
+func (uc *OverstayUsecase) SearchOverstays(customerID string, onlyOpen bool) (*entities.Overstays, error) {
+	if customerID != "" {
+		return uc.OverstayRepository.SearchOverstaysByCustomerID(customerID)
+	}
+	if onlyOpen {
+		return uc.OverstayRepository.SearchOpenOverstays()
+	}
+	return uc.OverstayRepository.SearchOverstays()
+}
+
+func (uc *OverstayUsecase) SearchZoneHours() (*entities.ZoneHoursList, error) {
+	return uc.ZoneHoursRepository.SearchZoneHours()
+}
+
+func (uc *OverstayUsecase) SaveZoneHours(zoneHours *entities.ZoneHours) error {
+	if zoneHours.Zone == "" {
+		return fmt.Errorf("la zona es requerida")
+	}
+	if _, err := time.Parse("15:04", zoneHours.OpeningTime); err != nil {
+		return fmt.Errorf("hora de apertura inválida, se espera HH:MM")
+	}
+	if _, err := time.Parse("15:04", zoneHours.ClosingTime); err != nil {
+		return fmt.Errorf("hora de cierre inválida, se espera HH:MM")
+	}
+	return uc.ZoneHoursRepository.SaveZoneHours(zoneHours)
+}
+
+func (uc *OverstayUsecase) DetectOverstays() (int, error) {
+	now := time.Now()
+
+	activeUsages, err := uc.ParkingUsageRepository.SearchActiveParkingUsages()
+	if err != nil {
+		return 0, fmt.Errorf("error al obtener usos activos: %w", err)
+	}
+
+	activeIDs := make(map[int]bool)
+	count := 0
+	for _, usage := range *activeUsages {
+		if usage.ExitTime != nil || usage.EntryTime == nil {
+			continue
+		}
+		activeIDs[usage.ID] = true
+
+		detected, err := uc.checkParkingUsage(usage, now)
+		if err != nil {
+			fmt.Printf("Error al revisar permanencia del uso %d: %v\n", usage.ID, err)
+			continue
+		}
+		count += detected
+	}
+
+	if err := uc.closeFinishedOverstays(activeIDs, now); err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+func (uc *OverstayUsecase) checkParkingUsage(usage parkingUsageEntities.ParkingUsage, now time.Time) (int, error) {
+	existing, err := uc.OverstayRepository.SearchOverstaysByParkingUsageID(usage.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	byReason := make(map[string]*entities.Overstay)
+	for i := range *existing {
+		byReason[(*existing)[i].Reason] = &(*existing)[i]
+	}
+
+	var customerID *string
+	count := 0
+
+	if usage.ReservationID != nil {
+		reservation, err := uc.ReservationRepository.SearchReservationByID(*usage.ReservationID)
+		if err == nil && reservation != nil {
+			customerID = &reservation.CustomerID
+			if now.After(reservation.EndTime) {
+				created, err := uc.registerOverstay(usage, entities.OverstayReservationEnd, reservation.EndTime, customerID, byReason, now)
+				if err != nil {
+					return count, err
+				}
+				if created {
+					count++
+				}
+			}
+		}
+	}
+
+	if customerID == nil && usage.VehicleID != nil {
+		vehicle, err := uc.VehicleRepository.SearchVehicleByID(*usage.VehicleID)
+		if err == nil && vehicle != nil && vehicle.CustomerID != "" {
+			customerID = &vehicle.CustomerID
+		}
+	}
+
+	closingTime := uc.closingTimeFor(uc.usageZone(usage), *usage.EntryTime)
+	if now.After(closingTime) {
+		created, err := uc.registerOverstay(usage, entities.OverstayZoneClosing, closingTime, customerID, byReason, now)
+		if err != nil {
+			return count, err
+		}
+		if created {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (uc *OverstayUsecase) registerOverstay(
+	usage parkingUsageEntities.ParkingUsage,
+	reason string,
+	expectedExit time.Time,
+	customerID *string,
+	byReason map[string]*entities.Overstay,
+	now time.Time,
+) (bool, error) {
+	minutes := int(now.Sub(expectedExit).Minutes())
+
+	if overstay, ok := byReason[reason]; ok {
+		if overstay.ExitTime != nil {
+			return false, nil
+		}
+		overstay.OverstayMinutes = minutes
+		return false, uc.OverstayRepository.UpdateOverstayByID(overstay)
+	}
+
+	overstay := &entities.Overstay{
+		ParkingUsageID:   usage.ID,
+		ReservationID:    usage.ReservationID,
+		VehicleID:        usage.VehicleID,
+		ParkingID:        usage.ParkingID,
+		CustomerID:       customerID,
+		Reason:           reason,
+		ExpectedExitTime: expectedExit,
+		DetectedAt:       now,
+		OverstayMinutes:  minutes,
+	}
+
+	if err := uc.OverstayRepository.CreateOverstay(overstay); err != nil {
+		return false, err
+	}
+
+	uc.notifyOverstay(overstay, usage)
+	return true, nil
+}
+
+func (uc *OverstayUsecase) closeFinishedOverstays(activeIDs map[int]bool, now time.Time) error {
+	openOverstays, err := uc.OverstayRepository.SearchOpenOverstays()
+	if err != nil {
+		return fmt.Errorf("error al obtener permanencias abiertas: %w", err)
+	}
+
+	for _, overstay := range *openOverstays {
+		if activeIDs[overstay.ParkingUsageID] {
+			continue
+		}
+
+		exitTime := now
+		usage, err := uc.ParkingUsageRepository.SearchParkingUsageByID(overstay.ParkingUsageID)
+		if err == nil && usage != nil && usage.ExitTime != nil {
+			exitTime = *usage.ExitTime
+		}
+
+		overstay.ExitTime = &exitTime
+		overstay.OverstayMinutes = int(exitTime.Sub(overstay.ExpectedExitTime).Minutes())
+		if overstay.OverstayMinutes < 0 {
+			overstay.OverstayMinutes = 0
+		}
+
+		if err := uc.OverstayRepository.UpdateOverstayByID(&overstay); err != nil {
+			fmt.Printf("Error al cerrar permanencia %d: %v\n", overstay.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *OverstayUsecase) usageZone(usage parkingUsageEntities.ParkingUsage) string {
+	if usage.Zone != "" {
+		return usage.Zone
+	}
+	parking, err := uc.ParkingRepository.SearchParkingByID(usage.ParkingID)
+	if err != nil || parking == nil {
+		return ""
+	}
+	return parking.Zone
+}
+
+func (uc *OverstayUsecase) closingTimeFor(zone string, entryTime time.Time) time.Time {
+	closing := os.Getenv("CAMPUS_CLOSING_TIME")
+	if closing == "" {
+		closing = defaultCampusClosingTime
+	}
+
+	if zone != "" {
+		if zoneHours, err := uc.ZoneHoursRepository.SearchZoneHoursByZone(zone); err == nil && zoneHours != nil {
+			closing = zoneHours.ClosingTime
+		}
+	}
+
+	clock, err := time.Parse("15:04", closing)
+	if err != nil {
+		clock, _ = time.Parse("15:04", defaultCampusClosingTime)
+	}
+
+	entryTime = utils.InCampus(entryTime)
+	closingTime := time.Date(entryTime.Year(), entryTime.Month(), entryTime.Day(),
+		clock.Hour(), clock.Minute(), 0, 0, entryTime.Location())
+	if !closingTime.After(entryTime) {
+		closingTime = closingTime.AddDate(0, 0, 1)
+	}
+
+	return closingTime
+}
+
+func (uc *OverstayUsecase) notifyOverstay(overstay *entities.Overstay, usage parkingUsageEntities.ParkingUsage) {
+	if uc.WebSocketService == nil {
+		return
+	}
+
+	parkingCode := ""
+	if parking, err := uc.ParkingRepository.SearchParkingByID(usage.ParkingID); err == nil && parking != nil {
+		parkingCode = parking.Code
+	}
+
+	uc.WebSocketService.BroadcastAdminAlert("overstay_detected", "medium", map[string]interface{}{
+		"overstay":    overstay,
+		"parkingCode": parkingCode,
+		"plate":       usage.OcrPlate,
+	})
+
+	if overstay.CustomerID == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Tu vehículo sigue en el estacionamiento %s después del término de tu reserva", parkingCode)
+	if overstay.Reason == entities.OverstayZoneClosing {
+		message = fmt.Sprintf("Tu vehículo sigue en el estacionamiento %s después del horario de cierre", parkingCode)
+	}
+
+	uc.WebSocketService.NotifyUser(*overstay.CustomerID, "overstay_warning", map[string]interface{}{
+		"overstayId":       overstay.ID,
+		"parkingUsageId":   overstay.ParkingUsageID,
+		"parkingCode":      parkingCode,
+		"reason":           overstay.Reason,
+		"expectedExitTime": overstay.ExpectedExitTime,
+		"message":          message,
+	})
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+const (
+	OverstayReservationEnd = "reservation_end"
+	OverstayZoneClosing    = "zone_closing"
+)
+
+type Overstay struct {
+	ID               int        `json:"id"`
+	ParkingUsageID   int        `json:"parkingUsageId"`
+	ReservationID    *int       `json:"reservationId"`
+	VehicleID        *int       `json:"vehicleId"`
+	ParkingID        int        `json:"parkingId"`
+	CustomerID       *string    `json:"customerId"`
+	Reason           string     `json:"reason"`
+	ExpectedExitTime time.Time  `json:"expectedExitTime"`
+	DetectedAt       time.Time  `json:"detectedAt"`
+	ExitTime         *time.Time `json:"exitTime"`
+	OverstayMinutes  int        `json:"overstayMinutes"`
+}
+
+type Overstays []Overstay
+
+type ZoneHours struct {
+	Zone        string `json:"zone"`
+	OpeningTime string `json:"openingTime"`
+	ClosingTime string `json:"closingTime"`
+}
+
+type ZoneHoursList []ZoneHours
@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/overstay/domain/entities"
+
+type OverstayRepository interface {
+	SearchOverstayByID(id int) (*entities.Overstay, error)
+	SearchOverstays() (*entities.Overstays, error)
+	SearchOpenOverstays() (*entities.Overstays, error)
+	SearchOverstaysByCustomerID(customerID string) (*entities.Overstays, error)
+	SearchOverstaysByParkingUsageID(parkingUsageID int) (*entities.Overstays, error)
+	CreateOverstay(overstay *entities.Overstay) error
+	UpdateOverstayByID(overstay *entities.Overstay) error
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/overstay/domain/entities"
+
+type ZoneHoursRepository interface {
+	SearchZoneHours() (*entities.ZoneHoursList, error)
+	SearchZoneHoursByZone(zone string) (*entities.ZoneHours, error)
+	SaveZoneHours(zoneHours *entities.ZoneHours) error
+}
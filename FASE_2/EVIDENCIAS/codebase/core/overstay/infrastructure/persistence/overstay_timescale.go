@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/overstay/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleOverstayRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleOverstayRepository(pool *pgxpool.Pool) *TimescaleOverstayRepository {
+	return &TimescaleOverstayRepository{
+		dbPool: pool,
+	}
+}
+
+const overstayColumns = `id, parking_usage_id, reservation_id, vehicle_id, parking_id, customer_id, reason,
+              expected_exit_time, detected_at, exit_time, overstay_minutes`
+
+func (r *TimescaleOverstayRepository) SearchOverstayByID(id int) (*entities.Overstay, error) {
+	ctx := context.Background()
+	query := `SELECT ` + overstayColumns + ` FROM parking_overstay WHERE id = $1`
+	row := r.dbPool.QueryRow(ctx, query, id)
+	var o entities.Overstay
+	err := row.Scan(&o.ID, &o.ParkingUsageID, &o.ReservationID, &o.VehicleID, &o.ParkingID, &o.CustomerID, &o.Reason,
+		&o.ExpectedExitTime, &o.DetectedAt, &o.ExitTime, &o.OverstayMinutes)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *TimescaleOverstayRepository) SearchOverstays() (*entities.Overstays, error) {
+	query := `SELECT ` + overstayColumns + ` FROM parking_overstay ORDER BY detected_at DESC`
+	return r.searchOverstays(query)
+}
+
+func (r *TimescaleOverstayRepository) SearchOpenOverstays() (*entities.Overstays, error) {
+	query := `SELECT ` + overstayColumns + ` FROM parking_overstay WHERE exit_time IS NULL ORDER BY detected_at DESC`
+	return r.searchOverstays(query)
+}
+
+func (r *TimescaleOverstayRepository) SearchOverstaysByCustomerID(customerID string) (*entities.Overstays, error) {
+	query := `SELECT ` + overstayColumns + ` FROM parking_overstay WHERE customer_id = $1 ORDER BY detected_at DESC`
+	return r.searchOverstays(query, customerID)
+}
+
+func (r *TimescaleOverstayRepository) SearchOverstaysByParkingUsageID(parkingUsageID int) (*entities.Overstays, error) {
+	query := `SELECT ` + overstayColumns + ` FROM parking_overstay WHERE parking_usage_id = $1 ORDER BY detected_at DESC`
+	return r.searchOverstays(query, parkingUsageID)
+}
+
+func (r *TimescaleOverstayRepository) searchOverstays(query string, args ...interface{}) (*entities.Overstays, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overstays := entities.Overstays{}
+	for rows.Next() {
+		var o entities.Overstay
+		if err := rows.Scan(&o.ID, &o.ParkingUsageID, &o.ReservationID, &o.VehicleID, &o.ParkingID, &o.CustomerID, &o.Reason,
+			&o.ExpectedExitTime, &o.DetectedAt, &o.ExitTime, &o.OverstayMinutes); err != nil {
+			return nil, err
+		}
+		overstays = append(overstays, o)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &overstays, nil
+}
+
+func (r *TimescaleOverstayRepository) CreateOverstay(overstay *entities.Overstay) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO parking_overstay (
+		parking_usage_id, reservation_id, vehicle_id, parking_id, customer_id, reason,
+		expected_exit_time, detected_at, exit_time, overstay_minutes
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		overstay.ParkingUsageID,
+		overstay.ReservationID,
+		overstay.VehicleID,
+		overstay.ParkingID,
+		overstay.CustomerID,
+		overstay.Reason,
+		overstay.ExpectedExitTime,
+		overstay.DetectedAt,
+		overstay.ExitTime,
+		overstay.OverstayMinutes,
+	).Scan(&overstay.ID)
+}
+
+func (r *TimescaleOverstayRepository) UpdateOverstayByID(overstay *entities.Overstay) error {
+	ctx := context.Background()
+	query := `
+	UPDATE parking_overstay SET
+		exit_time = $1,
+		overstay_minutes = $2
+	WHERE id = $3;
+`
+	_, err := r.dbPool.Exec(ctx, query, overstay.ExitTime, overstay.OverstayMinutes, overstay.ID)
+	return err
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/overstay/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleZoneHoursRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleZoneHoursRepository(pool *pgxpool.Pool) *TimescaleZoneHoursRepository {
+	return &TimescaleZoneHoursRepository{
+		dbPool: pool,
+	}
+}
+
+func (r *TimescaleZoneHoursRepository) SearchZoneHours() (*entities.ZoneHoursList, error) {
+	ctx := context.Background()
+	query := `SELECT zone, to_char(opening_time, 'HH24:MI'), to_char(closing_time, 'HH24:MI') FROM zone_hours ORDER BY zone`
+	rows, err := r.dbPool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := entities.ZoneHoursList{}
+	for rows.Next() {
+		var z entities.ZoneHours
+		if err := rows.Scan(&z.Zone, &z.OpeningTime, &z.ClosingTime); err != nil {
+			return nil, err
+		}
+		list = append(list, z)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (r *TimescaleZoneHoursRepository) SearchZoneHoursByZone(zone string) (*entities.ZoneHours, error) {
+	ctx := context.Background()
+	query := `SELECT zone, to_char(opening_time, 'HH24:MI'), to_char(closing_time, 'HH24:MI') FROM zone_hours WHERE zone = $1`
+	var z entities.ZoneHours
+	err := r.dbPool.QueryRow(ctx, query, zone).Scan(&z.Zone, &z.OpeningTime, &z.ClosingTime)
+	if err != nil {
+		return nil, err
+	}
+	return &z, nil
+}
+
+func (r *TimescaleZoneHoursRepository) SaveZoneHours(zoneHours *entities.ZoneHours) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO zone_hours (zone, opening_time, closing_time)
+	VALUES ($1, $2::time, $3::time)
+	ON CONFLICT (zone) DO UPDATE SET
+		opening_time = EXCLUDED.opening_time,
+		closing_time = EXCLUDED.closing_time;
+`
+	_, err := r.dbPool.Exec(ctx, query, zoneHours.Zone, zoneHours.OpeningTime, zoneHours.ClosingTime)
+	return err
+}
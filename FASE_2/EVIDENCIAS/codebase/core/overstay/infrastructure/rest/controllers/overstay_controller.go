@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gonzalohonorato/servercorego/core/overstay/application"
+	"github.com/gonzalohonorato/servercorego/core/overstay/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/overstay/domain/repositories"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	parkingUsageRepositories "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	reservationRepositories "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+	"github.com/gorilla/mux"
+)
+
+type OverstayController struct {
+	OverstayUsecase *application.OverstayUsecase
+}
+
+func NewOverstayController(
+	overstayRepository repositories.OverstayRepository,
+	zoneHoursRepository repositories.ZoneHoursRepository,
+	parkingUsageRepository parkingUsageRepositories.ParkingUsageRepository,
+	parkingRepository parkingRepositories.ParkingRepository,
+	reservationRepository reservationRepositories.ReservationRepository,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+	wsService *infrastructure.WebSocketService,
+) *OverstayController {
+	overstayUseCase := application.NewOverstayUsecase(
+		overstayRepository,
+		zoneHoursRepository,
+		parkingUsageRepository,
+		parkingRepository,
+		reservationRepository,
+		vehicleRepository,
+		wsService,
+	)
+
+	return &OverstayController{
+		OverstayUsecase: overstayUseCase,
+	}
+}
+
+func (uc *OverstayController) GetOverstayByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	overstayID := vars["id"]
+	idInt, err := strconv.Atoi(overstayID)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	overstay, err := uc.OverstayUsecase.SearchOverstayByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Overstay not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(overstay)
+}
+
+func (uc *OverstayController) GetOverstays(w http.ResponseWriter, r *http.Request) {
+	customerID := r.URL.Query().Get("customerId")
+	onlyOpen := r.URL.Query().Get("open") == "true"
+
+	overstays, err := uc.OverstayUsecase.SearchOverstays(customerID, onlyOpen)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Overstays not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(overstays)
+}
+
+func (uc *OverstayController) PostOverstayScan(w http.ResponseWriter, r *http.Request) {
+	count, err := uc.OverstayUsecase.DetectOverstays()
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Error detecting overstays: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"detected": count,
+	})
+}
+
+func (uc *OverstayController) GetZoneHours(w http.ResponseWriter, r *http.Request) {
+	zoneHours, err := uc.OverstayUsecase.SearchZoneHours()
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Zone hours not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(zoneHours)
+}
+
+func (uc *OverstayController) PutZoneHours(w http.ResponseWriter, r *http.Request) {
+	var zoneHours entities.ZoneHours
+	if err := json.NewDecoder(r.Body).Decode(&zoneHours); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.OverstayUsecase.SaveZoneHours(&zoneHours); err != nil {
+		http.Error(w, "Error saving zone hours: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/overstay/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func OverstayRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewOverstayController(
+		container.ProvideOverstayRepository(),
+		container.ProvideZoneHoursRepository(),
+		container.ProvideParkingUsageRepository(),
+		container.ProvideParkingRepository(),
+		container.ProvideReservationRepository(),
+		container.ProvideVehicleRepository(),
+		container.ProvideWebSocketService(),
+	)
+
+	router.HandleFunc("/overstays", controller.GetOverstays).Methods("GET")
+	router.HandleFunc("/overstays/scan", controller.PostOverstayScan).Methods("POST")
+	router.HandleFunc("/overstays/{id}", controller.GetOverstayByID).Methods("GET")
+
+	router.HandleFunc("/zone-hours", controller.GetZoneHours).Methods("GET")
+	router.HandleFunc("/zone-hours", controller.PutZoneHours).Methods("PUT")
+}
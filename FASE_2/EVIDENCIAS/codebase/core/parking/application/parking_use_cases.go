@@ -1,16 +1,22 @@
 package application
 
 import (
+	"time"
+
+	closureEntities "github.com/gonzalohonorato/servercorego/core/closure/domain/entities"
+	closureRepositories "github.com/gonzalohonorato/servercorego/core/closure/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
 )
 
 type ParkingUsecase struct {
-	ParkingRepository repositories.ParkingRepository
+	ParkingRepository             repositories.ParkingRepository
+	AccessibilityPermitRepository repositories.AccessibilityPermitRepository
+	ClosureRepository             closureRepositories.ClosureRepository
 }
 
-func NewParkingUsecase(parkingRepo repositories.ParkingRepository) *ParkingUsecase {
-	return &ParkingUsecase{ParkingRepository: parkingRepo}
+func NewParkingUsecase(parkingRepo repositories.ParkingRepository, permitRepo repositories.AccessibilityPermitRepository, closureRepo closureRepositories.ClosureRepository) *ParkingUsecase {
+	return &ParkingUsecase{ParkingRepository: parkingRepo, AccessibilityPermitRepository: permitRepo, ClosureRepository: closureRepo}
 }
 
 func (uc *ParkingUsecase) SearchParkingByID(id int) (*entities.Parking, error) {
@@ -24,15 +30,97 @@ func (uc *ParkingUsecase) SearchAvailableParkings() (*entities.Parkings, error)
 	return uc.ParkingRepository.SearchAvailableParkings()
 }
 
-func (uc *ParkingUsecase) CreateParking(parking *entities.Parking) error {
+func (uc *ParkingUsecase) SearchAvailableParkingsByFilter(filter entities.ParkingFilter) (*entities.Parkings, error) {
+	parkings, err := uc.ParkingRepository.SearchAvailableParkings()
+	if err != nil {
+		return nil, err
+	}
+	filtered := FilterParkings(*parkings, filter)
+
+	now := time.Now()
+	closures, err := uc.searchClosuresBetween(now, now.Add(time.Minute))
+	if err != nil {
+		return nil, err
+	}
+
+	open := entities.Parkings{}
+	for _, parking := range filtered {
+		if closures.Find(parking.ID, parking.Zone, now, now.Add(time.Minute)) == nil {
+			open = append(open, parking)
+		}
+	}
+	return &open, nil
+}
+
+func (uc *ParkingUsecase) SearchParkingAvailability(start, end time.Time, filter entities.ParkingFilter) (*entities.ParkingAvailabilities, error) {
+	parkings, err := uc.ParkingRepository.SearchParkings()
+	if err != nil {
+		return nil, err
+	}
 
+	closures, err := uc.searchClosuresBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	occupiedNow := !start.After(time.Now())
+	availability := entities.ParkingAvailabilities{}
+	for _, parking := range FilterParkings(*parkings, filter) {
+		closure := closures.Find(parking.ID, parking.Zone, start, end)
+		availability = append(availability, entities.ParkingAvailability{
+			Parking:   parking,
+			Available: closure == nil && !(occupiedNow && parking.IsActive),
+			Closure:   closure,
+		})
+	}
+	return &availability, nil
+}
+
+func (uc *ParkingUsecase) searchClosuresBetween(start, end time.Time) (closureEntities.ParkingClosures, error) {
+	if uc.ClosureRepository == nil {
+		return closureEntities.ParkingClosures{}, nil
+	}
+	closures, err := uc.ClosureRepository.SearchClosuresBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return *closures, nil
+}
+
+func (uc *ParkingUsecase) CreateParking(parking *entities.Parking) error {
+	normalizeSpotType(parking)
 	return uc.ParkingRepository.CreateParking(parking)
 }
 
 func (uc *ParkingUsecase) UpdateParkingById(parking *entities.Parking) error {
+	normalizeSpotType(parking)
 	return uc.ParkingRepository.UpdateParkingByID(parking)
 }
 
 func (uc *ParkingUsecase) DeleteParkingByID(id int) error {
 	return uc.ParkingRepository.DeleteParkingByID(id)
 }
+
+func (uc *ParkingUsecase) SearchAccessibilityPermits() (*entities.AccessibilityPermits, error) {
+	return uc.AccessibilityPermitRepository.SearchAccessibilityPermits()
+}
+
+func (uc *ParkingUsecase) SearchAccessibilityPermitByID(id int) (*entities.AccessibilityPermit, error) {
+	return uc.AccessibilityPermitRepository.SearchAccessibilityPermitByID(id)
+}
+
+func (uc *ParkingUsecase) CreateAccessibilityPermit(permit *entities.AccessibilityPermit) error {
+	return uc.AccessibilityPermitRepository.CreateAccessibilityPermit(permit)
+}
+
+func (uc *ParkingUsecase) UpdateAccessibilityPermitByID(permit *entities.AccessibilityPermit) error {
+	return uc.AccessibilityPermitRepository.UpdateAccessibilityPermitByID(permit)
+}
+
+func (uc *ParkingUsecase) DeleteAccessibilityPermitByID(id int) error {
+	return uc.AccessibilityPermitRepository.DeleteAccessibilityPermitByID(id)
+}
+
+func normalizeSpotType(parking *entities.Parking) {
+	parking.SpotType = SpotTypeForVehicle(parking.SpotType)
+}
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+)
+
+type SpotAllocator struct {
+	VehicleRepository             vehicleRepositories.VehicleRepository
+	AccessibilityPermitRepository repositories.AccessibilityPermitRepository
+}
+
+func NewSpotAllocator(
+	vehicleRepo vehicleRepositories.VehicleRepository,
+	permitRepo repositories.AccessibilityPermitRepository,
+) *SpotAllocator {
+	return &SpotAllocator{
+		VehicleRepository:             vehicleRepo,
+		AccessibilityPermitRepository: permitRepo,
+	}
+}
+
+func (a *SpotAllocator) RequirementsFor(vehicleID int, customerID string, at time.Time) (entities.SpotRequirements, error) {
+	requirements := entities.SpotRequirements{VehicleType: entities.SpotTypeCar}
+
+	if vehicleID != 0 {
+		vehicle, err := a.VehicleRepository.SearchVehicleByID(vehicleID)
+		if err != nil {
+			return requirements, fmt.Errorf("error al obtener vehículo: %w", err)
+		}
+		requirements.VehicleType = vehicle.VehicleType
+		requirements.IsElectric = vehicle.IsElectric
+		if customerID == "" {
+			customerID = vehicle.CustomerID
+		}
+	}
+
+	if customerID != "" {
+		permit, err := a.AccessibilityPermitRepository.SearchActiveAccessibilityPermit(customerID, at)
+		if err != nil {
+			return requirements, fmt.Errorf("error al verificar permiso de accesibilidad: %w", err)
+		}
+		requirements.HasAccessibilityPermit = permit != nil
+	}
+
+	return requirements, nil
+}
+
+func SpotTypeForVehicle(vehicleType string) string {
+	if vehicleType == entities.SpotTypeMotorcycle {
+		return entities.SpotTypeMotorcycle
+	}
+	return entities.SpotTypeCar
+}
+
+func SpotAccepts(parking *entities.Parking, requirements entities.SpotRequirements) bool {
+	return spotScore(parking, requirements) >= 0
+}
+
+func RankParkings(parkings entities.Parkings, requirements entities.SpotRequirements) entities.Parkings {
+	ranked := entities.Parkings{}
+	scores := make(map[int]int)
+	for _, parking := range parkings {
+		score := spotScore(&parking, requirements)
+		if score < 0 {
+			continue
+		}
+		scores[parking.ID] = score
+		ranked = append(ranked, parking)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID] < scores[ranked[j].ID]
+	})
+	return ranked
+}
+
+func FilterParkings(parkings entities.Parkings, filter entities.ParkingFilter) entities.Parkings {
+	filtered := entities.Parkings{}
+	for _, parking := range parkings {
+		if filter.SpotType != "" && SpotTypeForVehicle(parking.SpotType) != filter.SpotType {
+			continue
+		}
+		if filter.IsAccessible != nil && parking.IsAccessible != *filter.IsAccessible {
+			continue
+		}
+		if filter.HasEVCharger != nil && parking.HasEVCharger != *filter.HasEVCharger {
+			continue
+		}
+		if filter.IsCovered != nil && parking.IsCovered != *filter.IsCovered {
+			continue
+		}
+		if filter.IsCarpool != nil && parking.IsCarpool != *filter.IsCarpool {
+			continue
+		}
+		filtered = append(filtered, parking)
+	}
+	return filtered
+}
+
+func spotScore(parking *entities.Parking, requirements entities.SpotRequirements) int {
+	if SpotTypeForVehicle(parking.SpotType) != SpotTypeForVehicle(requirements.VehicleType) {
+		return -1
+	}
+	if parking.IsAccessible && !requirements.HasAccessibilityPermit {
+		return -1
+	}
+	if parking.IsCarpool && !requirements.IsCarpool {
+		return -1
+	}
+
+	score := 0
+	if requirements.IsCarpool && !parking.IsCarpool {
+		score++
+	}
+	if requirements.HasAccessibilityPermit && !parking.IsAccessible {
+		score++
+	}
+	if requirements.IsElectric && !parking.HasEVCharger {
+		score++
+	}
+	if !requirements.IsElectric && parking.HasEVCharger {
+		score += 2
+	}
+	return score
+}
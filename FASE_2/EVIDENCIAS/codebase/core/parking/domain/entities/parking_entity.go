@@ -1,11 +1,69 @@
 package entities
 
+import (
+	"time"
+
+	closureEntities "github.com/gonzalohonorato/servercorego/core/closure/domain/entities"
+)
+
+const (
+	SpotTypeCar        = "car"
+	SpotTypeMotorcycle = "motorcycle"
+)
+
 type Parking struct {
-	ID       int    `json:"id"`
-	Code     string `json:"code"`
-	Location string `json:"location"`
-	Zone     string `json:"zone"`
-	IsActive bool   `json:"isActive"`
+	ID           int    `json:"id"`
+	Code         string `json:"code"`
+	Location     string `json:"location"`
+	Zone         string `json:"zone"`
+	IsActive     bool   `json:"isActive"`
+	SpotType     string `json:"spotType"`
+	IsAccessible bool   `json:"isAccessible"`
+	HasEVCharger bool   `json:"hasEvCharger"`
+	IsCovered    bool   `json:"isCovered"`
+	IsCarpool    bool   `json:"isCarpool"`
 }
 
 type Parkings []Parking
+
+type ParkingAvailability struct {
+	Parking
+	Available bool                            `json:"available"`
+	Closure   *closureEntities.ParkingClosure `json:"closure"`
+}
+
+type ParkingAvailabilities []ParkingAvailability
+
+type ParkingFilter struct {
+	SpotType     string
+	IsAccessible *bool
+	HasEVCharger *bool
+	IsCovered    *bool
+	IsCarpool    *bool
+}
+
+type SpotRequirements struct {
+	VehicleType            string `json:"vehicleType"`
+	IsElectric             bool   `json:"isElectric"`
+	HasAccessibilityPermit bool   `json:"hasAccessibilityPermit"`
+	IsCarpool              bool   `json:"isCarpool"`
+}
+
+type AccessibilityPermit struct {
+	ID         int        `json:"id"`
+	CustomerID string     `json:"customerId"`
+	Number     string     `json:"number"`
+	IssuedBy   string     `json:"issuedBy"`
+	ValidFrom  time.Time  `json:"validFrom"`
+	ValidUntil *time.Time `json:"validUntil"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type AccessibilityPermits []AccessibilityPermit
+
+func (p *AccessibilityPermit) IsValidAt(at time.Time) bool {
+	if at.Before(p.ValidFrom) {
+		return false
+	}
+	return p.ValidUntil == nil || at.Before(*p.ValidUntil)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
+)
+
+type AccessibilityPermitRepository interface {
+	SearchAccessibilityPermitByID(id int) (*entities.AccessibilityPermit, error)
+	SearchAccessibilityPermits() (*entities.AccessibilityPermits, error)
+	SearchActiveAccessibilityPermit(customerID string, at time.Time) (*entities.AccessibilityPermit, error)
+	CreateAccessibilityPermit(permit *entities.AccessibilityPermit) error
+	UpdateAccessibilityPermitByID(permit *entities.AccessibilityPermit) error
+	DeleteAccessibilityPermitByID(id int) error
+}
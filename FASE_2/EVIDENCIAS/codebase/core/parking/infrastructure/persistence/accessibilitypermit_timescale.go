@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleAccessibilityPermitRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleAccessibilityPermitRepository(pool *pgxpool.Pool) *TimescaleAccessibilityPermitRepository {
+	return &TimescaleAccessibilityPermitRepository{
+		dbPool: pool,
+	}
+}
+
+const accessibilityPermitColumns = `id, customer_id, number, issued_by, valid_from, valid_until, created_at`
+
+func (r *TimescaleAccessibilityPermitRepository) SearchAccessibilityPermitByID(id int) (*entities.AccessibilityPermit, error) {
+	ctx := context.Background()
+	query := `SELECT ` + accessibilityPermitColumns + ` FROM accessibility_permit WHERE id = $1`
+	var p entities.AccessibilityPermit
+	err := r.dbPool.QueryRow(ctx, query, id).Scan(&p.ID, &p.CustomerID, &p.Number, &p.IssuedBy, &p.ValidFrom, &p.ValidUntil, &p.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *TimescaleAccessibilityPermitRepository) SearchAccessibilityPermits() (*entities.AccessibilityPermits, error) {
+	ctx := context.Background()
+	query := `SELECT ` + accessibilityPermitColumns + ` FROM accessibility_permit ORDER BY created_at DESC`
+	rows, err := r.dbPool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permits := entities.AccessibilityPermits{}
+	for rows.Next() {
+		var p entities.AccessibilityPermit
+		if err := rows.Scan(&p.ID, &p.CustomerID, &p.Number, &p.IssuedBy, &p.ValidFrom, &p.ValidUntil, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		permits = append(permits, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &permits, nil
+}
+
+func (r *TimescaleAccessibilityPermitRepository) SearchActiveAccessibilityPermit(customerID string, at time.Time) (*entities.AccessibilityPermit, error) {
+	ctx := context.Background()
+	query := `SELECT ` + accessibilityPermitColumns + ` FROM accessibility_permit
+	WHERE customer_id = $1 AND valid_from <= $2 AND (valid_until IS NULL OR valid_until > $2)
+	ORDER BY valid_from DESC LIMIT 1`
+	var p entities.AccessibilityPermit
+	err := r.dbPool.QueryRow(ctx, query, customerID, at).Scan(&p.ID, &p.CustomerID, &p.Number, &p.IssuedBy, &p.ValidFrom, &p.ValidUntil, &p.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *TimescaleAccessibilityPermitRepository) CreateAccessibilityPermit(permit *entities.AccessibilityPermit) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO accessibility_permit (customer_id, number, issued_by, valid_from, valid_until, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;
+`
+	if permit.CreatedAt.IsZero() {
+		permit.CreatedAt = time.Now()
+	}
+	return r.dbPool.QueryRow(ctx, query, permit.CustomerID, permit.Number, permit.IssuedBy, permit.ValidFrom,
+		permit.ValidUntil, permit.CreatedAt).Scan(&permit.ID)
+}
+
+func (r *TimescaleAccessibilityPermitRepository) UpdateAccessibilityPermitByID(permit *entities.AccessibilityPermit) error {
+	ctx := context.Background()
+	query := `
+	UPDATE accessibility_permit SET
+		customer_id = $1, number = $2, issued_by = $3, valid_from = $4, valid_until = $5
+	WHERE id = $6;
+`
+	_, err := r.dbPool.Exec(ctx, query, permit.CustomerID, permit.Number, permit.IssuedBy, permit.ValidFrom,
+		permit.ValidUntil, permit.ID)
+	return err
+}
+
+func (r *TimescaleAccessibilityPermitRepository) DeleteAccessibilityPermitByID(id int) error {
+	ctx := context.Background()
+	query := `DELETE FROM accessibility_permit WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, id)
+	return err
+}
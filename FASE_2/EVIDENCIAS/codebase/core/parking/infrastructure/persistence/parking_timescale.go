@@ -17,12 +17,14 @@ func NewTimescaleDBRepository(pool *pgxpool.Pool) *TimescaleParkingRepository {
 	}
 }
 
+const parkingColumns = `id, code, location, zone, is_active, spot_type, is_accessible, has_ev_charger, is_covered, is_carpool`
+
 func (r *TimescaleParkingRepository) SearchParkingByID(id int) (*entities.Parking, error) {
 	ctx := context.Background()
-	query := `SELECT * FROM parking WHERE id = $1`
+	query := `SELECT ` + parkingColumns + ` FROM parking WHERE id = $1`
 	row := r.dbPool.QueryRow(ctx, query, id)
 	var p entities.Parking
-	err := row.Scan(&p.ID, &p.Code, &p.Location, &p.Zone, &p.IsActive)
+	err := row.Scan(&p.ID, &p.Code, &p.Location, &p.Zone, &p.IsActive, &p.SpotType, &p.IsAccessible, &p.HasEVCharger, &p.IsCovered, &p.IsCarpool)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +33,7 @@ func (r *TimescaleParkingRepository) SearchParkingByID(id int) (*entities.Parkin
 
 func (r *TimescaleParkingRepository) SearchParkings() (*entities.Parkings, error) {
 	ctx := context.Background()
-	query := `SELECT * FROM parking`
+	query := `SELECT ` + parkingColumns + ` FROM parking ORDER BY id`
 	rows, err := r.dbPool.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -41,7 +43,7 @@ func (r *TimescaleParkingRepository) SearchParkings() (*entities.Parkings, error
 	var parkings entities.Parkings
 	for rows.Next() {
 		var p entities.Parking
-		if err := rows.Scan(&p.ID, &p.Code, &p.Location, &p.Zone, &p.IsActive); err != nil {
+		if err := rows.Scan(&p.ID, &p.Code, &p.Location, &p.Zone, &p.IsActive, &p.SpotType, &p.IsAccessible, &p.HasEVCharger, &p.IsCovered, &p.IsCarpool); err != nil {
 			return nil, err
 		}
 		parkings = append(parkings, p)
@@ -56,7 +58,7 @@ func (r *TimescaleParkingRepository) SearchAvailableParkings() (*entities.Parkin
 
 	
 	query := `
-        SELECT p.id, p.code, p.location, p.zone, p.is_active 
+        SELECT p.id, p.code, p.location, p.zone, p.is_active, p.spot_type, p.is_accessible, p.has_ev_charger, p.is_covered, p.is_carpool
         FROM parking p
         WHERE p.is_active = false
     `
@@ -70,7 +72,7 @@ func (r *TimescaleParkingRepository) SearchAvailableParkings() (*entities.Parkin
 	var parkings entities.Parkings
 	for rows.Next() {
 		var p entities.Parking
-		if err := rows.Scan(&p.ID, &p.Code, &p.Location, &p.Zone, &p.IsActive); err != nil {
+		if err := rows.Scan(&p.ID, &p.Code, &p.Location, &p.Zone, &p.IsActive, &p.SpotType, &p.IsAccessible, &p.HasEVCharger, &p.IsCovered, &p.IsCarpool); err != nil {
 			return nil, err
 		}
 		parkings = append(parkings, p)
@@ -85,21 +87,24 @@ func (r *TimescaleParkingRepository) SearchAvailableParkings() (*entities.Parkin
 func (r *TimescaleParkingRepository) CreateParking(parking *entities.Parking) error {
 	ctx := context.Background()
 	query := `
-	INSERT INTO parkings (
-		code, location, zone, is_active
+	INSERT INTO parking (
+		code, location, zone, is_active, spot_type, is_accessible, has_ev_charger, is_covered, is_carpool
 	) VALUES (
-		$1, $2, $3, $4
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
 	);
 `
-	_, err := r.dbPool.Exec(ctx, query, parking.Code, parking.Location, parking.Zone, parking.IsActive)
+	_, err := r.dbPool.Exec(ctx, query, parking.Code, parking.Location, parking.Zone, parking.IsActive,
+		parking.SpotType, parking.IsAccessible, parking.HasEVCharger, parking.IsCovered, parking.IsCarpool)
 	return err
 }
 
 func (r *TimescaleParkingRepository) UpdateParkingByID(p *entities.Parking) error {
 	ctx := context.Background()
-	query := `UPDATE parking SET code = $2, location = $3, zone = $4, is_active = $5 WHERE id = $1`
+	query := `UPDATE parking SET code = $2, location = $3, zone = $4, is_active = $5, spot_type = $6,
+		is_accessible = $7, has_ev_charger = $8, is_covered = $9, is_carpool = $10 WHERE id = $1`
 
-	_, err := r.dbPool.Exec(ctx, query, p.ID, p.Code, p.Location, p.Zone, p.IsActive)
+	_, err := r.dbPool.Exec(ctx, query, p.ID, p.Code, p.Location, p.Zone, p.IsActive, p.SpotType,
+		p.IsAccessible, p.HasEVCharger, p.IsCovered, p.IsCarpool)
 	return err
 }
 
@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	closureRepositories "github.com/gonzalohonorato/servercorego/core/closure/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/parking/application"
 	"github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
@@ -16,8 +20,12 @@ type ParkingController struct {
 	ParkingUsecase *application.ParkingUsecase
 }
 
-func NewParkingController(parkingRepository repositories.ParkingRepository) *ParkingController {
-	parkingUseCase := application.NewParkingUsecase(parkingRepository)
+func NewParkingController(
+	parkingRepository repositories.ParkingRepository,
+	accessibilityPermitRepository repositories.AccessibilityPermitRepository,
+	closureRepository closureRepositories.ClosureRepository,
+) *ParkingController {
+	parkingUseCase := application.NewParkingUsecase(parkingRepository, accessibilityPermitRepository, closureRepository)
 
 	return &ParkingController{
 		ParkingUsecase: parkingUseCase,
@@ -42,7 +50,13 @@ func (uc *ParkingController) GetParkingByID(w http.ResponseWriter, r *http.Reque
 }
 
 func (uc *ParkingController) GetAvailableParkings(w http.ResponseWriter, r *http.Request) {
-	parkings, err := uc.ParkingUsecase.SearchAvailableParkings()
+	filter, err := parseParkingFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	parkings, err := uc.ParkingUsecase.SearchAvailableParkingsByFilter(filter)
 	if err != nil {
 		fmt.Println(err)
 		http.Error(w, "No se pudieron encontrar estacionamientos disponibles", http.StatusNotFound)
@@ -52,6 +66,63 @@ func (uc *ParkingController) GetAvailableParkings(w http.ResponseWriter, r *http
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(parkings)
 }
+
+func (uc *ParkingController) GetParkingAvailability(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter, err := parseParkingFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	start := time.Now()
+	if value := query.Get("from"); value != "" {
+		if start, err = utils.ParseDateTime(value); err != nil {
+			http.Error(w, "Parámetro from inválido", http.StatusBadRequest)
+			return
+		}
+	}
+	end := start.Add(time.Hour)
+	if value := query.Get("to"); value != "" {
+		if end, err = utils.ParseDateTime(value); err != nil {
+			http.Error(w, "Parámetro to inválido", http.StatusBadRequest)
+			return
+		}
+	}
+	if !end.After(start) {
+		http.Error(w, "El parámetro to debe ser posterior a from", http.StatusBadRequest)
+		return
+	}
+
+	availability, err := uc.ParkingUsecase.SearchParkingAvailability(start, end, filter)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "No se pudo obtener la disponibilidad de estacionamientos", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(availability)
+}
+
+func parseParkingFilter(query url.Values) (entities.ParkingFilter, error) {
+	filter := entities.ParkingFilter{SpotType: query.Get("spotType")}
+
+	var err error
+	if filter.IsAccessible, err = parseOptionalBool(query.Get("accessible")); err != nil {
+		return filter, fmt.Errorf("Parámetro accessible inválido")
+	}
+	if filter.HasEVCharger, err = parseOptionalBool(query.Get("evCharger")); err != nil {
+		return filter, fmt.Errorf("Parámetro evCharger inválido")
+	}
+	if filter.IsCovered, err = parseOptionalBool(query.Get("covered")); err != nil {
+		return filter, fmt.Errorf("Parámetro covered inválido")
+	}
+	if filter.IsCarpool, err = parseOptionalBool(query.Get("carpool")); err != nil {
+		return filter, fmt.Errorf("Parámetro carpool inválido")
+	}
+	return filter, nil
+}
 func (uc *ParkingController) GetParkings(w http.ResponseWriter, r *http.Request) {
 	parkings, err := uc.ParkingUsecase.SearchParkings()
 	if err != nil {
@@ -106,3 +177,90 @@ func (uc *ParkingController) DeleteParkingByID(w http.ResponseWriter, r *http.Re
 	}
 	w.Header().Set("Content-Type", "application/json")
 }
+
+func parseOptionalBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func (uc *ParkingController) GetAccessibilityPermits(w http.ResponseWriter, r *http.Request) {
+	permits, err := uc.ParkingUsecase.SearchAccessibilityPermits()
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Accessibility permits not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(permits)
+}
+
+func (uc *ParkingController) GetAccessibilityPermitByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	permit, err := uc.ParkingUsecase.SearchAccessibilityPermitByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Accessibility permit not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(permit)
+}
+
+func (uc *ParkingController) PostAccessibilityPermit(w http.ResponseWriter, r *http.Request) {
+	var permit entities.AccessibilityPermit
+	if err := json.NewDecoder(r.Body).Decode(&permit); err != nil || permit.CustomerID == "" || permit.ValidFrom.IsZero() {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.ParkingUsecase.CreateAccessibilityPermit(&permit); err != nil {
+		http.Error(w, "Error creating accessibility permit", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(permit)
+}
+
+func (uc *ParkingController) PutAccessibilityPermit(w http.ResponseWriter, r *http.Request) {
+	var permit entities.AccessibilityPermit
+	if err := json.NewDecoder(r.Body).Decode(&permit); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.ParkingUsecase.UpdateAccessibilityPermitByID(&permit); err != nil {
+		http.Error(w, "Error update accessibility permit", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *ParkingController) DeleteAccessibilityPermitByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.ParkingUsecase.DeleteAccessibilityPermitByID(idInt); err != nil {
+		http.Error(w, "Error deleting accessibility permit", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
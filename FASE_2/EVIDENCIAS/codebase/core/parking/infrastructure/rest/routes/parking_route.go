@@ -7,12 +7,23 @@ import (
 )
 
 func ParkingRoutes(router *mux.Router, container *injector.Container) {
-	controller := controllers.NewParkingController(container.ProvideParkingRepository())
+	controller := controllers.NewParkingController(
+		container.ProvideParkingRepository(),
+		container.ProvideAccessibilityPermitRepository(),
+		container.ProvideClosureRepository(),
+	)
 	router.HandleFunc("/parkings", controller.PutParking).Methods("PUT")
 	router.HandleFunc("/parkings", controller.GetParkings).Methods("GET")
 	router.HandleFunc("/available-parkings", controller.GetAvailableParkings).Methods("GET")
+	router.HandleFunc("/parking-availability", controller.GetParkingAvailability).Methods("GET")
 
 	router.HandleFunc("/parkings/{id}", controller.DeleteParkingByID).Methods("DELETE")
 	router.HandleFunc("/parkings/{id}", controller.GetParkingByID).Methods("GET")
 	router.HandleFunc("/parkings", controller.PostParking).Methods("POST")
+
+	router.HandleFunc("/accessibility-permits", controller.PutAccessibilityPermit).Methods("PUT")
+	router.HandleFunc("/accessibility-permits", controller.GetAccessibilityPermits).Methods("GET")
+	router.HandleFunc("/accessibility-permits/{id}", controller.DeleteAccessibilityPermitByID).Methods("DELETE")
+	router.HandleFunc("/accessibility-permits/{id}", controller.GetAccessibilityPermitByID).Methods("GET")
+	router.HandleFunc("/accessibility-permits", controller.PostAccessibilityPermit).Methods("POST")
 }
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	chargingApplication "github.com/gonzalohonorato/servercorego/core/charging/application"
+	parkingEntity "github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
+	paymentApplication "github.com/gonzalohonorato/servercorego/core/payment/application"
+	paymentEntities "github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+	tariffApplication "github.com/gonzalohonorato/servercorego/core/tariff/application"
+	tariffEntities "github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	vehicleEntity "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+)
+
+type exitFixture struct {
+	usecase *ParkingUsageUsecase
+	usages  *fakeParkingUsageRepository
+	charges *fakeParkingChargeRepository
+	ledger  *fakeLedgerRepository
+}
+
+func newExitFixture(entryTime time.Time, vehicleOwner string) *exitFixture {
+	vehicleID := 7
+	usages := &fakeParkingUsageRepository{usages: map[int]*entities.ParkingUsage{
+		1: {ID: 1, VehicleID: &vehicleID, ParkingID: 3, EntryTime: &entryTime, Zone: "A"},
+	}}
+	parkings := &fakeParkingRepository{parkings: map[int]*parkingEntity.Parking{
+		3: {ID: 3, Code: "A-03", Zone: "A", IsActive: true},
+	}}
+	vehicles := &fakeVehicleRepository{vehicles: map[int]*vehicleEntity.Vehicle{
+		vehicleID: {ID: vehicleID, Plate: "BCDF10", CustomerID: vehicleOwner},
+	}}
+	tariffs := &fakeTariffRepository{tariffs: tariffEntities.Tariffs{{
+		ID:       1,
+		Name:     "General",
+		Currency: "CLP",
+		IsActive: true,
+		Bands:    tariffEntities.TariffBands{{StartTime: "00:00", EndTime: "00:00", RatePerHour: 1200}},
+	}}}
+	charges := &fakeParkingChargeRepository{}
+	ledger := &fakeLedgerRepository{}
+	users := &fakeUserRepository{}
+	sessions := &fakeChargingSessionRepository{}
+
+	tariffUsecase := &tariffApplication.TariffUsecase{TariffRepository: tariffs, ParkingChargeRepository: charges}
+	paymentUsecase := paymentApplication.NewPaymentUsecase(ledger, charges, approvingGateway{}, usages, vehicles)
+
+	return &exitFixture{
+		usecase: &ParkingUsageUsecase{
+			ParkingUsageRepository: usages,
+			ParkingRepository:      parkings,
+			VehicleRepository:      vehicles,
+			UserRepository:         users,
+			TariffUsecase:          tariffUsecase,
+			PaymentUsecase:         paymentUsecase,
+			ChargingUsecase: &chargingApplication.ChargingUsecase{
+				ChargingSessionRepository: sessions,
+				TariffUsecase:             tariffUsecase,
+				PaymentUsecase:            paymentUsecase,
+			},
+		},
+		usages:  usages,
+		charges: charges,
+		ledger:  ledger,
+	}
+}
+
+func TestProcessParkingExitAfterPayingByUsage(t *testing.T) {
+	os.Setenv("EXIT_REQUIRES_PAYMENT", "true")
+	defer os.Unsetenv("EXIT_REQUIRES_PAYMENT")
+
+	fixture := newExitFixture(time.Now().Add(-2*time.Hour), "cliente-1")
+	request := &ExitRequest{ExitType: "id", ParkingUsageID: 1}
+
+	blocked, err := fixture.usecase.ProcessParkingExit(request)
+	if err != nil {
+		t.Fatalf("error inesperado en la primera salida: %v", err)
+	}
+	if blocked.Success || blocked.ErrorCode != "UNPAID_BALANCE" {
+		t.Fatalf("la primera salida debería bloquearse por saldo pendiente, obtuvo %+v", blocked)
+	}
+	if blocked.Balance <= 0 {
+		t.Fatalf("el saldo pendiente debería ser positivo, obtuvo %.0f", blocked.Balance)
+	}
+
+	usageID := 1
+	payment, err := fixture.usecase.PaymentUsecase.Pay(&paymentEntities.PaymentRequest{
+		ParkingUsageID: &usageID,
+		Amount:         blocked.Balance,
+	})
+	if err != nil {
+		t.Fatalf("error inesperado al pagar por uso: %v", err)
+	}
+	if payment.CustomerID == nil || *payment.CustomerID != "cliente-1" {
+		t.Fatalf("el pago por uso debería imputarse al dueño del vehículo, obtuvo %v", payment.CustomerID)
+	}
+
+	allowed, err := fixture.usecase.ProcessParkingExit(request)
+	if err != nil {
+		t.Fatalf("error inesperado en la segunda salida: %v", err)
+	}
+	if !allowed.Success {
+		t.Fatalf("la salida debería permitirse tras pagar el uso, obtuvo %+v", allowed)
+	}
+	if fixture.usages.usages[1].ExitTime == nil {
+		t.Fatal("el uso debería quedar cerrado tras la salida")
+	}
+}
+
+func TestProcessParkingExitRepricesAfterBlockedAttempt(t *testing.T) {
+	os.Setenv("EXIT_REQUIRES_PAYMENT", "true")
+	defer os.Unsetenv("EXIT_REQUIRES_PAYMENT")
+
+	entryTime := time.Now().Add(-2 * time.Hour)
+	fixture := newExitFixture(entryTime, "cliente-1")
+	request := &ExitRequest{ExitType: "id", ParkingUsageID: 1}
+
+	first, err := fixture.usecase.ProcessParkingExit(request)
+	if err != nil {
+		t.Fatalf("error inesperado en la primera salida: %v", err)
+	}
+	if first.ErrorCode != "UNPAID_BALANCE" {
+		t.Fatalf("la primera salida debería bloquearse por saldo pendiente, obtuvo %+v", first)
+	}
+
+	// El vehículo se queda una hora más después del intento bloqueado
+	earlier := entryTime.Add(-time.Hour)
+	fixture.usages.usages[1].EntryTime = &earlier
+
+	second, err := fixture.usecase.ProcessParkingExit(request)
+	if err != nil {
+		t.Fatalf("error inesperado en la segunda salida: %v", err)
+	}
+	if second.ErrorCode != "UNPAID_BALANCE" {
+		t.Fatalf("la segunda salida debería seguir bloqueada, obtuvo %+v", second)
+	}
+	if len(fixture.charges.charges) != 2 {
+		t.Fatalf("se esperaban 2 cobros (inicial y diferencia), hay %d", len(fixture.charges.charges))
+	}
+	if extra := fixture.charges.charges[1].Amount; extra < 1195 || extra > 1205 {
+		t.Fatalf("la diferencia debería cubrir la hora extra ($1200), obtuvo $%.0f", extra)
+	}
+	if second.Balance != first.Balance+fixture.charges.charges[1].Amount {
+		t.Fatalf("el saldo debería sumar solo la diferencia: %.0f + %.0f, obtuvo %.0f", first.Balance, fixture.charges.charges[1].Amount, second.Balance)
+	}
+
+	third, err := fixture.usecase.ProcessParkingExit(request)
+	if err != nil {
+		t.Fatalf("error inesperado en la tercera salida: %v", err)
+	}
+	if third.Balance != second.Balance || len(fixture.charges.charges) != 2 {
+		t.Fatalf("reintentar sin tiempo adicional no debería cobrar de nuevo: saldo %.0f, cobros %d", third.Balance, len(fixture.charges.charges))
+	}
+}
+
+func TestPayByUnknownUsage(t *testing.T) {
+	fixture := newExitFixture(time.Now().Add(-time.Hour), "cliente-1")
+
+	usageID := 99
+	_, err := fixture.usecase.PaymentUsecase.Pay(&paymentEntities.PaymentRequest{ParkingUsageID: &usageID, Amount: 1000})
+	if !errors.Is(err, paymentApplication.ErrUsageNotFound) {
+		t.Fatalf("se esperaba ErrUsageNotFound, obtuvo %v", err)
+	}
+	if len(fixture.ledger.entries) != 0 {
+		t.Fatalf("no debería registrarse ningún movimiento, hay %d", len(fixture.ledger.entries))
+	}
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"fmt"
+
+	chargingEntities "github.com/gonzalohonorato/servercorego/core/charging/domain/entities"
+	chargingRepositories "github.com/gonzalohonorato/servercorego/core/charging/domain/repositories"
+	parkingEntity "github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
+	parkingRepository "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	paymentEntities "github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+	paymentRepositories "github.com/gonzalohonorato/servercorego/core/payment/domain/repositories"
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	tariffEntities "github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	tariffRepositories "github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+	userEntities "github.com/gonzalohonorato/servercorego/core/user/domain/entities"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleEntity "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+	vehicleRepository "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+)
+
+type fakeParkingUsageRepository struct {
+	repositories.ParkingUsageRepository
+	usages map[int]*entities.ParkingUsage
+}
+
+func (r *fakeParkingUsageRepository) SearchParkingUsageByID(id int) (*entities.ParkingUsage, error) {
+	usage, ok := r.usages[id]
+	if !ok {
+		return nil, fmt.Errorf("uso %d no encontrado", id)
+	}
+	copied := *usage
+	return &copied, nil
+}
+
+func (r *fakeParkingUsageRepository) UpdateParkingUsageByID(usage *entities.ParkingUsage) error {
+	copied := *usage
+	r.usages[usage.ID] = &copied
+	return nil
+}
+
+type fakeParkingRepository struct {
+	parkingRepository.ParkingRepository
+	parkings map[int]*parkingEntity.Parking
+}
+
+func (r *fakeParkingRepository) SearchParkingByID(id int) (*parkingEntity.Parking, error) {
+	parking, ok := r.parkings[id]
+	if !ok {
+		return nil, fmt.Errorf("estacionamiento %d no encontrado", id)
+	}
+	copied := *parking
+	return &copied, nil
+}
+
+func (r *fakeParkingRepository) UpdateParkingByID(parking *parkingEntity.Parking) error {
+	copied := *parking
+	r.parkings[parking.ID] = &copied
+	return nil
+}
+
+type fakeVehicleRepository struct {
+	vehicleRepository.VehicleRepository
+	vehicles map[int]*vehicleEntity.Vehicle
+}
+
+func (r *fakeVehicleRepository) SearchVehicleByID(id int) (*vehicleEntity.Vehicle, error) {
+	vehicle, ok := r.vehicles[id]
+	if !ok {
+		return nil, fmt.Errorf("vehículo %d no encontrado", id)
+	}
+	copied := *vehicle
+	return &copied, nil
+}
+
+func (r *fakeVehicleRepository) SearchVehicleByPlate(plate string) (*vehicleEntity.Vehicle, error) {
+	for _, vehicle := range r.vehicles {
+		if plateApplication.CleanPlate(vehicle.Plate) == plate {
+			copied := *vehicle
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("patente %s no encontrada", plate)
+}
+
+func (r *fakeVehicleRepository) SearchVehiclesByPlateLength(minLength, maxLength int) (*vehicleEntity.Vehicles, error) {
+	vehicles := vehicleEntity.Vehicles{}
+	for _, vehicle := range r.vehicles {
+		if length := len(plateApplication.CleanPlate(vehicle.Plate)); length >= minLength && length <= maxLength {
+			vehicles = append(vehicles, *vehicle)
+		}
+	}
+	return &vehicles, nil
+}
+
+type fakeUserRepository struct {
+	userRepositories.UserRepository
+}
+
+func (r *fakeUserRepository) SearchUserByID(id string) (*userEntities.User, error) {
+	return &userEntities.User{ID: id}, nil
+}
+
+type fakeTariffRepository struct {
+	tariffRepositories.TariffRepository
+	tariffs tariffEntities.Tariffs
+}
+
+func (r *fakeTariffRepository) SearchActiveTariffs() (*tariffEntities.Tariffs, error) {
+	return &r.tariffs, nil
+}
+
+type fakeParkingChargeRepository struct {
+	tariffRepositories.ParkingChargeRepository
+	charges tariffEntities.ParkingCharges
+}
+
+func (r *fakeParkingChargeRepository) SearchParkingChargesByParkingUsageID(parkingUsageID int) (*tariffEntities.ParkingCharges, error) {
+	charges := tariffEntities.ParkingCharges{}
+	for _, charge := range r.charges {
+		if charge.ParkingUsageID == parkingUsageID {
+			charges = append(charges, charge)
+		}
+	}
+	return &charges, nil
+}
+
+func (r *fakeParkingChargeRepository) CreateParkingCharge(charge *tariffEntities.ParkingCharge) error {
+	charge.ID = len(r.charges) + 1
+	r.charges = append(r.charges, *charge)
+	return nil
+}
+
+type fakeLedgerRepository struct {
+	paymentRepositories.LedgerRepository
+	entries paymentEntities.LedgerEntries
+}
+
+func (r *fakeLedgerRepository) CreateLedgerEntry(entry *paymentEntities.LedgerEntry) error {
+	entry.ID = len(r.entries) + 1
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *fakeLedgerRepository) SearchLedgerEntriesByCustomerID(customerID string) (*paymentEntities.LedgerEntries, error) {
+	entries := paymentEntities.LedgerEntries{}
+	for _, entry := range r.entries {
+		if entry.CustomerID != nil && *entry.CustomerID == customerID {
+			entries = append(entries, entry)
+		}
+	}
+	return &entries, nil
+}
+
+func (r *fakeLedgerRepository) SearchLedgerEntriesByParkingUsageID(parkingUsageID int) (*paymentEntities.LedgerEntries, error) {
+	entries := paymentEntities.LedgerEntries{}
+	for _, entry := range r.entries {
+		if entry.ParkingUsageID != nil && *entry.ParkingUsageID == parkingUsageID {
+			entries = append(entries, entry)
+		}
+	}
+	return &entries, nil
+}
+
+type approvingGateway struct{}
+
+func (approvingGateway) Charge(request *paymentEntities.PaymentRequest) (*paymentEntities.GatewayResult, error) {
+	return &paymentEntities.GatewayResult{Approved: true, Reference: "ref-test", Message: "aprobado"}, nil
+}
+
+func (approvingGateway) Refund(reference string, amount float64, currency string) (*paymentEntities.GatewayResult, error) {
+	return &paymentEntities.GatewayResult{Approved: true, Reference: "refund-" + reference}, nil
+}
+
+type fakeChargingSessionRepository struct {
+	chargingRepositories.ChargingSessionRepository
+}
+
+func (r *fakeChargingSessionRepository) SearchChargingSessionsByParkingUsageID(parkingUsageID int) (*chargingEntities.ChargingSessions, error) {
+	return &chargingEntities.ChargingSessions{}, nil
+}
@@ -0,0 +1,55 @@
+package application
+
+import (
+	"testing"
+
+	vehicleEntity "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+)
+
+func TestResolveVehicleByPlate(t *testing.T) {
+	uc := &ParkingUsageUsecase{VehicleRepository: &fakeVehicleRepository{vehicles: map[int]*vehicleEntity.Vehicle{
+		1: {ID: 1, Plate: "BCDF10"},
+		2: {ID: 2, Plate: "XKLM27"},
+		3: {ID: 3, Plate: "GH1234"},
+	}}}
+
+	tests := []struct {
+		name           string
+		plate          string
+		wantVehicle    int
+		wantCandidates []int
+	}{
+		{"coincidencia exacta", "BC-DF-10", 1, nil},
+		{"solo confusiones del OCR", "BCDFIO", 1, nil},
+		{"un carácter mal leído queda para el guardia", "BCDF18", 0, []int{1}},
+		{"un carácter de menos queda para el guardia", "BCDF1", 0, []int{1}},
+		{"un carácter de más queda para el guardia", "XKLM271", 0, []int{2}},
+		{"patente sin parecido", "ZZZZ99", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vehicle, candidates, err := uc.resolveVehicleByPlate(tt.plate)
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+
+			gotVehicle := 0
+			if vehicle != nil {
+				gotVehicle = vehicle.ID
+			}
+			if gotVehicle != tt.wantVehicle {
+				t.Fatalf("resolveVehicleByPlate(%q) = vehículo %d, se esperaba %d", tt.plate, gotVehicle, tt.wantVehicle)
+			}
+
+			if len(candidates) != len(tt.wantCandidates) {
+				t.Fatalf("resolveVehicleByPlate(%q) entregó %d candidatos, se esperaban %d", tt.plate, len(candidates), len(tt.wantCandidates))
+			}
+			for i, want := range tt.wantCandidates {
+				if candidates[i].VehicleID != want {
+					t.Errorf("candidato %d = vehículo %d, se esperaba %d", i, candidates[i].VehicleID, want)
+				}
+			}
+		})
+	}
+}
@@ -2,16 +2,41 @@ package application
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	chargingApplication "github.com/gonzalohonorato/servercorego/core/charging/application"
+	chargingGateways "github.com/gonzalohonorato/servercorego/core/charging/domain/gateways"
+	chargingRepositories "github.com/gonzalohonorato/servercorego/core/charging/domain/repositories"
+	closureEntities "github.com/gonzalohonorato/servercorego/core/closure/domain/entities"
+	closureRepositories "github.com/gonzalohonorato/servercorego/core/closure/domain/repositories"
+	parkingApplication "github.com/gonzalohonorato/servercorego/core/parking/application"
 	parkingEntity "github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
 	parkingRepository "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	paymentApplication "github.com/gonzalohonorato/servercorego/core/payment/application"
+	paymentGateways "github.com/gonzalohonorato/servercorego/core/payment/domain/gateways"
+	paymentRepositories "github.com/gonzalohonorato/servercorego/core/payment/domain/repositories"
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	plateEntities "github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	quotaApplication "github.com/gonzalohonorato/servercorego/core/quota/application"
+	quotaRepositories "github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
 	reservationEntity "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
 	reservationRepository "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	tariffApplication "github.com/gonzalohonorato/servercorego/core/tariff/application"
+	tariffEntities "github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	tariffRepositories "github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
 	vehicleEntity "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
 	vehicleRepository "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	watchlistApplication "github.com/gonzalohonorato/servercorego/core/watchlist/application"
+	watchlistEntities "github.com/gonzalohonorato/servercorego/core/watchlist/domain/entities"
+	watchlistRepositories "github.com/gonzalohonorato/servercorego/core/watchlist/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
 )
 
@@ -21,6 +46,15 @@ type ParkingUsageUsecase struct {
 	VehicleRepository      vehicleRepository.VehicleRepository
 	ReservationRepository  reservationRepository.ReservationRepository
 	WebSocketService       *infrastructure.WebSocketService
+	PlateFormatUsecase     *plateApplication.PlateFormatUsecase
+	WatchlistUsecase       *watchlistApplication.WatchlistUsecase
+	UserRepository         userRepositories.UserRepository
+	TariffUsecase          *tariffApplication.TariffUsecase
+	PaymentUsecase         *paymentApplication.PaymentUsecase
+	QuotaUsecase           *quotaApplication.QuotaUsecase
+	SpotAllocator          *parkingApplication.SpotAllocator
+	ClosureRepository      closureRepositories.ClosureRepository
+	ChargingUsecase        *chargingApplication.ChargingUsecase
 }
 
 func NewParkingUsageUsecase(
@@ -29,6 +63,20 @@ func NewParkingUsageUsecase(
 	vehicleRepo vehicleRepository.VehicleRepository,
 	reservationRepo reservationRepository.ReservationRepository,
 	wsService *infrastructure.WebSocketService,
+	plateFormatRepo plateRepositories.PlateFormatRepository,
+	watchlistRepo watchlistRepositories.WatchlistRepository,
+	userRepo userRepositories.UserRepository,
+	tariffRepo tariffRepositories.TariffRepository,
+	parkingChargeRepo tariffRepositories.ParkingChargeRepository,
+	ledgerRepo paymentRepositories.LedgerRepository,
+	paymentGateway paymentGateways.PaymentGateway,
+	quotaPolicyRepo quotaRepositories.QuotaPolicyRepository,
+	quotaMovementRepo quotaRepositories.QuotaMovementRepository,
+	permitRepo parkingRepository.AccessibilityPermitRepository,
+	closureRepo closureRepositories.ClosureRepository,
+	calendarRepo calendarRepositories.CalendarEventRepository,
+	chargingSessionRepo chargingRepositories.ChargingSessionRepository,
+	chargerClient chargingGateways.ChargerClient,
 ) *ParkingUsageUsecase {
 	return &ParkingUsageUsecase{
 		ParkingUsageRepository: parkingUsageRepo,
@@ -36,6 +84,16 @@ func NewParkingUsageUsecase(
 		VehicleRepository:      vehicleRepo,
 		ReservationRepository:  reservationRepo,
 		WebSocketService:       wsService,
+		PlateFormatUsecase:     plateApplication.NewPlateFormatUsecase(plateFormatRepo),
+		WatchlistUsecase:       watchlistApplication.NewWatchlistUsecase(watchlistRepo, plateFormatRepo),
+		UserRepository:         userRepo,
+		TariffUsecase:          tariffApplication.NewTariffUsecase(tariffRepo, parkingChargeRepo, calendarRepo),
+		PaymentUsecase:         paymentApplication.NewPaymentUsecase(ledgerRepo, parkingChargeRepo, paymentGateway, parkingUsageRepo, vehicleRepo),
+		QuotaUsecase:           quotaApplication.NewQuotaUsecase(quotaPolicyRepo, quotaMovementRepo, userRepo),
+		SpotAllocator:          parkingApplication.NewSpotAllocator(vehicleRepo, permitRepo),
+		ClosureRepository:      closureRepo,
+		ChargingUsecase: chargingApplication.NewChargingUsecase(chargingSessionRepo, parkingUsageRepo, parkingRepo, vehicleRepo,
+			tariffRepo, parkingChargeRepo, ledgerRepo, paymentGateway, chargerClient, wsService, calendarRepo),
 	}
 }
 
@@ -56,7 +114,7 @@ func (uc *ParkingUsageUsecase) SearchActiveParkingUsages() (*entities.ParkingUsa
 func (uc *ParkingUsageUsecase) CreateParkingUsage(parkingUsage *entities.ParkingUsage) error {
 	
 	if parkingUsage.ParkingID == 0 {
-		availableParking, err := uc.findAvailableParking()
+		availableParking, err := uc.findAvailableParking(uc.spotRequirementsForUsage(parkingUsage))
 		if err != nil {
 			return fmt.Errorf("no hay estacionamientos disponibles: %w", err)
 		}
@@ -126,10 +184,13 @@ type ExitRequest struct {
 }
 
 type ExitResponse struct {
-	Success      bool                   `json:"success"`
-	Message      string                 `json:"message"`
-	ParkingUsage *entities.ParkingUsage `json:"parkingUsage,omitempty"`
-	ErrorCode    string                 `json:"errorCode,omitempty"`
+	Success      bool                          `json:"success"`
+	Message      string                        `json:"message"`
+	ParkingUsage *entities.ParkingUsage        `json:"parkingUsage,omitempty"`
+	ErrorCode    string                        `json:"errorCode,omitempty"`
+	Candidates   plateEntities.PlateCandidates `json:"candidates,omitempty"`
+	Charge       *tariffEntities.ParkingCharge `json:"charge,omitempty"`
+	Balance      float64                       `json:"balance,omitempty"`
 }
 
 
@@ -157,7 +218,18 @@ func (uc *ParkingUsageUsecase) ProcessParkingExit(request *ExitRequest) (*ExitRe
 				ErrorCode: "PLATE_REQUIRED",
 			}, nil
 		}
-		parkingUsage, err = uc.findActiveParkingUsageByPlate(request.Plate)
+		var candidates plateEntities.PlateCandidates
+		parkingUsage, candidates, err = uc.findActiveParkingUsageByPlate(request.Plate)
+		if err == nil && parkingUsage == nil && len(candidates) > 0 {
+			response := &ExitResponse{
+				Success:    false,
+				Message:    fmt.Sprintf("La patente %s es ambigua, se requiere confirmación del guardia", request.Plate),
+				ErrorCode:  "PLATE_CONFIRMATION_REQUIRED",
+				Candidates: candidates,
+			}
+			uc.notifyPlateConfirmationRequired("exit", request.Plate, candidates)
+			return response, nil
+		}
 	default:
 		return &ExitResponse{
 			Success:   false,
@@ -191,74 +263,301 @@ func (uc *ParkingUsageUsecase) ProcessParkingExit(request *ExitRequest) (*ExitRe
 		return response, nil
 	}
 
+	charge, balance, err := uc.settleParkingUsage(parkingUsage)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar saldo pendiente: %w", err)
+	}
+
+	if balance > 0 && exitRequiresPayment() {
+		response := &ExitResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("Existe un saldo pendiente de $%.0f, debe pagarse antes de salir", balance),
+			ErrorCode:    "UNPAID_BALANCE",
+			ParkingUsage: parkingUsage,
+			Charge:       charge,
+			Balance:      balance,
+		}
+		uc.notifyExitRejection(response, request)
+		return response, nil
+	}
+
+	if err := uc.closeParkingUsage(parkingUsage, entities.ExitTypeGate); err != nil {
+		return nil, err
+	}
+
 	
+	response := &ExitResponse{
+		Success:      true,
+		Message:      "Salida registrada exitosamente",
+		ParkingUsage: parkingUsage,
+		Charge:       charge,
+	}
+
+	uc.notifyExitSuccess(response, request)
+	return response, nil
+}
+
+
+func (uc *ParkingUsageUsecase) closeParkingUsage(parkingUsage *entities.ParkingUsage, exitType string) error {
 	now := time.Now()
 	parkingUsage.ExitTime = &now
+	parkingUsage.ExitType = exitType
 
-	
-	err = uc.ParkingUsageRepository.UpdateParkingUsageByID(parkingUsage)
+	err := uc.ParkingUsageRepository.UpdateParkingUsageByID(parkingUsage)
 	if err != nil {
-		return nil, fmt.Errorf("error al actualizar registro: %w", err)
+		return fmt.Errorf("error al actualizar registro: %w", err)
 	}
 
-	
 	parking, err := uc.ParkingRepository.SearchParkingByID(parkingUsage.ParkingID)
 	if err != nil {
-		return nil, fmt.Errorf("error al obtener el parking: %w", err)
+		return fmt.Errorf("error al obtener el parking: %w", err)
 	}
 
 	parking.IsActive = false
 	err = uc.ParkingRepository.UpdateParkingByID(parking)
 	if err != nil {
-		return nil, fmt.Errorf("error al actualizar estado del parking: %w", err)
+		return fmt.Errorf("error al actualizar estado del parking: %w", err)
 	}
 
-	
+	return nil
+}
+
+func exitRequiresPayment() bool {
+	return os.Getenv("EXIT_REQUIRES_PAYMENT") != "false"
+}
+
+func (uc *ParkingUsageUsecase) settleParkingUsage(parkingUsage *entities.ParkingUsage) (*tariffEntities.ParkingCharge, float64, error) {
+	now := time.Now()
+	// La carga eléctrica se cobra antes de calcular el saldo para que la deuda quede visible al salir
+	if err := uc.ChargingUsecase.CloseChargingSessionsByParkingUsageID(parkingUsage.ID, now); err != nil {
+		return nil, 0, err
+	}
+
+	charges, err := uc.TariffUsecase.SearchParkingChargesByParkingUsageID(parkingUsage.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Una salida bloqueada ya dejó un cobro calculado a esa hora; se vuelve a tarificar hasta ahora
+	// y solo se agrega la diferencia
+	var charge *tariffEntities.ParkingCharge
+	if len(*charges) > 0 {
+		charge = &(*charges)[len(*charges)-1]
+	}
+
+	priced := *parkingUsage
+	priced.ExitTime = &now
+	if extra := uc.priceParkingUsage(&priced, *charges); extra != nil {
+		charge = extra
+		if charge.Amount > 0 {
+			if _, err := uc.PaymentUsecase.RecordCharge(charge); err != nil {
+				return charge, 0, err
+			}
+		}
+	}
+
+	customerID, _ := uc.resolveUsageCustomer(parkingUsage)
+	balance, err := uc.PaymentUsecase.OutstandingBalance(customerID, parkingUsage.ID)
+	if err != nil {
+		return charge, 0, err
+	}
+
+	return charge, balance, nil
+}
+
+func (uc *ParkingUsageUsecase) priceParkingUsage(parkingUsage *entities.ParkingUsage, previous tariffEntities.ParkingCharges) *tariffEntities.ParkingCharge {
+	zone := parkingUsage.Zone
+	if zone == "" {
+		if parking, err := uc.ParkingRepository.SearchParkingByID(parkingUsage.ParkingID); err == nil {
+			zone = parking.Zone
+		}
+	}
+
+	customerID, customerType := uc.resolveUsageCustomer(parkingUsage)
+	charge, err := uc.TariffUsecase.PriceParkingUsage(parkingUsage, zone, customerType, customerID, previous)
+	if err != nil {
+		fmt.Printf("Error al calcular la tarifa del uso %d: %v\n", parkingUsage.ID, err)
+		return nil
+	}
+
+	return charge
+}
+
+func (uc *ParkingUsageUsecase) resolveUsageCustomer(parkingUsage *entities.ParkingUsage) (*string, string) {
+	customerID, err := uc.PaymentUsecase.UsageCustomerID(parkingUsage)
+	if err != nil || customerID == nil {
+		return nil, tariffEntities.VisitorCustomerType
+	}
+
+	customerType := ""
+	if user, err := uc.UserRepository.SearchUserByID(*customerID); err == nil && user.CustomerType != nil {
+		customerType = *user.CustomerType
+	}
+
+	return customerID, customerType
+}
+
+func StaleUsageThreshold() time.Duration {
+	threshold := 12 * time.Hour
+	if hoursStr := os.Getenv("STALE_USAGE_HOURS"); hoursStr != "" {
+		if hours, err := strconv.Atoi(hoursStr); err == nil && hours > 0 {
+			threshold = time.Duration(hours) * time.Hour
+		}
+	}
+	return threshold
+}
+
+func (uc *ParkingUsageUsecase) SearchStaleParkingUsages(maxOpen time.Duration) (*entities.ParkingUsages, error) {
+	activeUsages, err := uc.ParkingUsageRepository.SearchActiveParkingUsages()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := time.Now().Add(-maxOpen)
+	staleUsages := entities.ParkingUsages{}
+	for _, usage := range *activeUsages {
+		if usage.ExitTime == nil && usage.EntryTime != nil && usage.EntryTime.Before(limit) {
+			staleUsages = append(staleUsages, usage)
+		}
+	}
+
+	return &staleUsages, nil
+}
+
+func (uc *ParkingUsageUsecase) CloseStaleParkingUsage(parkingUsageID int, closedBy string) (*ExitResponse, error) {
+	parkingUsage, err := uc.ParkingUsageRepository.SearchParkingUsageByID(parkingUsageID)
+	if err != nil {
+		return &ExitResponse{
+			Success:   false,
+			Message:   "No se encontró el uso de estacionamiento",
+			ErrorCode: "PARKING_USAGE_NOT_FOUND",
+		}, nil
+	}
+
+	if parkingUsage.ExitTime != nil {
+		return &ExitResponse{
+			Success:   false,
+			Message:   "Este registro ya tiene una hora de salida registrada",
+			ErrorCode: "EXIT_ALREADY_REGISTERED",
+		}, nil
+	}
+
+	// Se cobra igual que en la salida por portería, pero el saldo pendiente no impide el cierre
+	charge, balance, err := uc.settleParkingUsage(parkingUsage)
+	if err != nil {
+		return nil, fmt.Errorf("error al liquidar el uso %d: %w", parkingUsage.ID, err)
+	}
+
+	if err := uc.closeParkingUsage(parkingUsage, entities.ExitTypeGuard); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Uso de estacionamiento %d cerrado manualmente por %s\n", parkingUsage.ID, closedBy)
+
 	response := &ExitResponse{
 		Success:      true,
-		Message:      "Salida registrada exitosamente",
+		Message:      "Uso de estacionamiento cerrado por revisión",
 		ParkingUsage: parkingUsage,
+		Charge:       charge,
+		Balance:      balance,
+	}
+
+	if uc.WebSocketService != nil {
+		uc.WebSocketService.BroadcastParkingUsage(map[string]interface{}{
+			"type":     "stale_usage_closed",
+			"closedBy": closedBy,
+			"response": response,
+		})
 	}
 
-	uc.notifyExitSuccess(response, request)
 	return response, nil
 }
 
-
-func (uc *ParkingUsageUsecase) findActiveParkingUsageByPlate(plate string) (*entities.ParkingUsage, error) {
-	
-	vehicle, err := uc.findVehicleByPlate(plate)
+func (uc *ParkingUsageUsecase) AutoCloseStaleParkingUsages(maxOpen time.Duration) (int, error) {
+	staleUsages, err := uc.SearchStaleParkingUsages(maxOpen)
 	if err != nil {
-		return nil, fmt.Errorf("error al buscar vehículo por patente %s: %w", plate, err)
+		return 0, err
 	}
 
-	if vehicle == nil {
-		
-		return nil, nil
+	closed := entities.ParkingUsages{}
+	for i := range *staleUsages {
+		usage := &(*staleUsages)[i]
+		if _, _, err := uc.settleParkingUsage(usage); err != nil {
+			fmt.Printf("Error al liquidar el uso %d antes del cierre automático: %v\n", usage.ID, err)
+			continue
+		}
+		if err := uc.closeParkingUsage(usage, entities.ExitTypeAuto); err != nil {
+			fmt.Printf("Error al cerrar automáticamente el uso %d: %v\n", usage.ID, err)
+			continue
+		}
+		closed = append(closed, *usage)
 	}
 
-	
+	if len(closed) > 0 && uc.WebSocketService != nil {
+		uc.WebSocketService.BroadcastAdminAlert("stale_usages_auto_closed", "medium", map[string]interface{}{
+			"parkingUsages": closed,
+		})
+	}
+
+	return len(closed), nil
+}
+
+func (uc *ParkingUsageUsecase) findActiveParkingUsageByPlate(plate string) (*entities.ParkingUsage, plateEntities.PlateCandidates, error) {
+	formats := uc.PlateFormatUsecase.ActivePlateFormats()
+	normalizedPlate := plateApplication.NormalizePlate(plate, formats)
+
 	activeUsages, err := uc.ParkingUsageRepository.SearchActiveParkingUsages()
 	if err != nil {
-		return nil, fmt.Errorf("error al buscar registros activos: %w", err)
+		return nil, nil, fmt.Errorf("error al buscar registros activos: %w", err)
+	}
+
+	vehicle, err := uc.findVehicleByPlate(normalizedPlate)
+	if err == nil && vehicle != nil {
+		for _, usage := range *activeUsages {
+			if usage.VehicleID != nil && *usage.VehicleID == vehicle.ID && usage.ExitTime == nil {
+				return &usage, nil, nil
+			}
+		}
 	}
 
-	
 	for _, usage := range *activeUsages {
-		if usage.VehicleID != nil && *usage.VehicleID == vehicle.ID && usage.ExitTime == nil {
-			return &usage, nil
+		if usage.ExitTime == nil && plateApplication.NormalizePlate(usage.OcrPlate, formats) == normalizedPlate {
+			return &usage, nil, nil
 		}
 	}
 
-	
-	
+	candidates := plateEntities.PlateCandidates{}
 	for _, usage := range *activeUsages {
-		if usage.ExitTime == nil && usage.OcrPlate == plate {
-			return &usage, nil
+		if usage.ExitTime != nil || usage.OcrPlate == "" {
+			continue
+		}
+		candidate := plateEntities.PlateCandidate{
+			ParkingUsageID: usage.ID,
+			Plate:          plateApplication.NormalizePlate(usage.OcrPlate, formats),
 		}
+		if usage.VehicleID != nil {
+			candidate.VehicleID = *usage.VehicleID
+		}
+		candidates = append(candidates, candidate)
 	}
 
-	return nil, nil
+	ranked := plateApplication.RankPlateCandidates(plate, candidates, formats)
+	selected, ambiguous := plateApplication.SelectPlateCandidate(plate, ranked)
+	if ambiguous {
+		return nil, ranked, nil
+	}
+	if selected == nil {
+		return nil, nil, nil
+	}
+
+	for _, usage := range *activeUsages {
+		if usage.ID == selected.ParkingUsageID {
+			fmt.Printf("Patente %s asociada por similitud a %s (costo %.2f)\n", plate, selected.Plate, selected.Cost)
+			return &usage, nil, nil
+		}
+	}
+
+	return nil, nil, nil
 }
 func (uc *ParkingUsageUsecase) isParkingAvailable(parkingID int) (bool, error) {
 	
@@ -276,6 +575,14 @@ func (uc *ParkingUsageUsecase) isParkingAvailable(parkingID int) (bool, error) {
 	now := time.Now()
 	sixHoursFromNow := now.Add(6 * time.Hour)
 
+	closed, err := uc.parkingClosedBetween(parking, now, sixHoursFromNow)
+	if err != nil {
+		return false, err
+	}
+	if closed {
+		return false, nil
+	}
+
 	hasPendingReservation, err := uc.parkingHasPendingReservationWithin(parkingID, sixHoursFromNow)
 	if err != nil {
 		return false, err
@@ -299,7 +606,7 @@ func (uc *ParkingUsageUsecase) isParkingAvailable(parkingID int) (bool, error) {
 }
 
 
-func (uc *ParkingUsageUsecase) findAvailableParking() (*parkingEntity.Parking, error) {
+func (uc *ParkingUsageUsecase) findAvailableParking(requirements parkingEntity.SpotRequirements) (*parkingEntity.Parking, error) {
 	allParkings, err := uc.ParkingRepository.SearchParkings()
 	if err != nil {
 		return nil, err
@@ -308,12 +615,21 @@ func (uc *ParkingUsageUsecase) findAvailableParking() (*parkingEntity.Parking, e
 	now := time.Now()
 	sixHoursFromNow := now.Add(6 * time.Hour)
 
-	for _, parking := range *allParkings {
+	closures, err := uc.searchClosuresBetween(now, sixHoursFromNow)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, parking := range parkingApplication.RankParkings(*allParkings, requirements) {
 		
 		if parking.IsActive {
 			continue 
 		}
 
+		if closures.Find(parking.ID, parking.Zone, now, sixHoursFromNow) != nil {
+			continue
+		}
+
 		
 		hasPendingReservationSoon, err := uc.parkingHasPendingReservationWithin(parking.ID, sixHoursFromNow)
 		if err != nil {
@@ -343,6 +659,22 @@ func (uc *ParkingUsageUsecase) findAvailableParking() (*parkingEntity.Parking, e
 
 	return nil, fmt.Errorf("no hay parkings disponibles sin conflictos de reserva")
 }
+
+func (uc *ParkingUsageUsecase) spotRequirementsForUsage(parkingUsage *entities.ParkingUsage) parkingEntity.SpotRequirements {
+	if parkingUsage.VehicleID != nil {
+		requirements, err := uc.SpotAllocator.RequirementsFor(*parkingUsage.VehicleID, "", time.Now())
+		if err == nil {
+			return requirements
+		}
+		fmt.Printf("Error al obtener requisitos de estacionamiento del vehículo %d: %v\n", *parkingUsage.VehicleID, err)
+	}
+
+	requirements := parkingEntity.SpotRequirements{VehicleType: parkingEntity.SpotTypeCar}
+	if _, format := uc.PlateFormatUsecase.DetectPlateFormat(parkingUsage.OcrPlate); format != nil {
+		requirements.VehicleType = format.VehicleType
+	}
+	return requirements
+}
 func (uc *ParkingUsageUsecase) isParkingAvailableForImmediate(parkingID int) (bool, *int, error) {
 	
 	parking, err := uc.ParkingRepository.SearchParkingByID(parkingID)
@@ -371,6 +703,14 @@ func (uc *ParkingUsageUsecase) isParkingAvailableForImmediate(parkingID int) (bo
 	
 	now := time.Now()
 	sixHoursFromNow := now.Add(6 * time.Hour)
+	closed, err := uc.parkingClosedBetween(parking, now, sixHoursFromNow)
+	if err != nil {
+		return false, nil, err
+	}
+	if closed {
+		return false, nil, nil
+	}
+
 	hasPendingReservationSoon, err := uc.parkingHasPendingReservationWithin(parkingID, sixHoursFromNow)
 	if err != nil {
 		return false, nil, err
@@ -385,6 +725,25 @@ func (uc *ParkingUsageUsecase) isParkingAvailableForImmediate(parkingID int) (bo
 }
 
 
+func (uc *ParkingUsageUsecase) searchClosuresBetween(start, end time.Time) (closureEntities.ParkingClosures, error) {
+	if uc.ClosureRepository == nil {
+		return closureEntities.ParkingClosures{}, nil
+	}
+	closures, err := uc.ClosureRepository.SearchClosuresBetween(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener cierres: %w", err)
+	}
+	return *closures, nil
+}
+
+func (uc *ParkingUsageUsecase) parkingClosedBetween(parking *parkingEntity.Parking, start, end time.Time) (bool, error) {
+	closures, err := uc.searchClosuresBetween(start, end)
+	if err != nil {
+		return false, err
+	}
+	return closures.Find(parking.ID, parking.Zone, start, end) != nil, nil
+}
+
 func (uc *ParkingUsageUsecase) parkingHasPendingReservationWithin(parkingID int, timeLimit time.Time) (bool, error) {
 	reservations, err := uc.ReservationRepository.SearchPendingReservationsByParkingAndTime(parkingID, timeLimit)
 	if err != nil {
@@ -446,10 +805,11 @@ type EntryRequest struct {
 }
 
 type EntryResponse struct {
-	Success      bool                   `json:"success"`
-	Message      string                 `json:"message"`
-	ParkingUsage *entities.ParkingUsage `json:"parkingUsage,omitempty"`
-	ErrorCode    string                 `json:"errorCode,omitempty"`
+	Success      bool                          `json:"success"`
+	Message      string                        `json:"message"`
+	ParkingUsage *entities.ParkingUsage        `json:"parkingUsage,omitempty"`
+	ErrorCode    string                        `json:"errorCode,omitempty"`
+	Candidates   plateEntities.PlateCandidates `json:"candidates,omitempty"`
 }
 
 
@@ -483,8 +843,42 @@ func (uc *ParkingUsageUsecase) processOCREntry(request *EntryRequest) (*EntryRes
 		}, nil
 	}
 
-	
-	vehicle, err := uc.findVehicleByPlate(request.Plate)
+	plate, plateFormat := uc.PlateFormatUsecase.DetectPlateFormat(request.Plate)
+	request.Plate = plate
+
+	watchlistEntry, err := uc.WatchlistUsecase.FindActiveEntry(request.Plate, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar lista de control: %w", err)
+	}
+
+	if isBlockedEntry(watchlistEntry) {
+		return uc.rejectBlockedPlate(watchlistEntry, request), nil
+	}
+
+	var vehicle *vehicleEntity.Vehicle
+	var candidates plateEntities.PlateCandidates
+	if request.VehicleID != 0 {
+		vehicle, err = uc.findVehicleByID(request.VehicleID)
+	} else if isAllowedEntry(watchlistEntry) {
+		vehicle, err = uc.findVehicleByPlate(request.Plate)
+		if err != nil || vehicle == nil {
+			return uc.processAllowlistedEntry(request, watchlistEntry, plateFormat)
+		}
+	} else {
+		vehicle, candidates, err = uc.resolveVehicleByPlate(request.Plate)
+	}
+
+	if err == nil && vehicle == nil && len(candidates) > 0 {
+		response := &EntryResponse{
+			Success:    false,
+			Message:    fmt.Sprintf("La patente %s es ambigua, se requiere confirmación del guardia", request.Plate),
+			ErrorCode:  "PLATE_CONFIRMATION_REQUIRED",
+			Candidates: candidates,
+		}
+		uc.notifyPlateConfirmationRequired("entry", request.Plate, candidates)
+		return response, nil
+	}
+
 	if err != nil || vehicle == nil {
 		response := &EntryResponse{
 			Success:   false,
@@ -495,6 +889,20 @@ func (uc *ParkingUsageUsecase) processOCREntry(request *EntryRequest) (*EntryRes
 		return response, nil
 	}
 
+	if blocked, err := uc.findBlockedEntry(vehicle.Plate); err != nil {
+		return nil, fmt.Errorf("error al consultar lista de control: %w", err)
+	} else if blocked != nil {
+		return uc.rejectBlockedPlate(blocked, request), nil
+	}
+
+	if !vehicle.IsVerified() && !isAllowedEntry(watchlistEntry) {
+		return uc.rejectUnverifiedVehicle(vehicle, request), nil
+	}
+
+	if !vehicle.IsValidAt(time.Now()) {
+		return uc.rejectVehicleOutsideValidity(vehicle, request), nil
+	}
+
 	
 	hasActiveEntry, err := uc.vehicleHasActiveEntry(vehicle.ID)
 	if err != nil {
@@ -524,6 +932,11 @@ func (uc *ParkingUsageUsecase) processOCREntry(request *EntryRequest) (*EntryRes
 		QrScanned:   false,
 		ManualEntry: false,
 		EntryTime:   nil, 
+		PlateFormat: plateFormatCode(plateFormat),
+	}
+
+	if request.RegisteredBy != "" {
+		parkingUsage.RegisteredBy = &request.RegisteredBy
 	}
 
 	
@@ -564,6 +977,20 @@ func (uc *ParkingUsageUsecase) processQREntry(request *EntryRequest) (*EntryResp
 		return response, nil
 	}
 
+	if blocked, err := uc.findBlockedEntry(vehicle.Plate); err != nil {
+		return nil, fmt.Errorf("error al consultar lista de control: %w", err)
+	} else if blocked != nil {
+		return uc.rejectBlockedPlate(blocked, request), nil
+	}
+
+	if !vehicle.IsVerified() {
+		return uc.rejectUnverifiedVehicle(vehicle, request), nil
+	}
+
+	if !vehicle.IsValidAt(time.Now()) {
+		return uc.rejectVehicleOutsideValidity(vehicle, request), nil
+	}
+
 	
 	hasActiveEntry, err := uc.vehicleHasActiveEntry(request.VehicleID)
 	if err != nil {
@@ -587,12 +1014,15 @@ func (uc *ParkingUsageUsecase) processQREntry(request *EntryRequest) (*EntryResp
 	}
 
 	
+	_, plateFormat := uc.PlateFormatUsecase.DetectPlateFormat(vehicle.Plate)
+
 	parkingUsage := &entities.ParkingUsage{
 		VehicleID:   &request.VehicleID,
 		OcrPlate:    vehicle.Plate,
 		QrScanned:   true,
 		ManualEntry: false,
 		EntryTime:   nil,
+		PlateFormat: plateFormatCode(plateFormat),
 	}
 
 	
@@ -619,8 +1049,36 @@ func (uc *ParkingUsageUsecase) processManualEntry(request *EntryRequest) (*Entry
 		}, nil
 	}
 
-	
-	
+	if request.VisitorRut != "" {
+		rut, err := utils.ParseRut(request.VisitorRut)
+		if err != nil {
+			return &EntryResponse{
+				Success:   false,
+				Message:   fmt.Sprintf("El RUT %s del visitante no es válido", request.VisitorRut),
+				ErrorCode: "INVALID_RUT",
+			}, nil
+		}
+		request.VisitorRut = rut.String()
+	}
+
+	plate, plateFormat, err := uc.PlateFormatUsecase.ValidatePlate(request.Plate)
+	if err != nil {
+		response := &EntryResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("La patente %s no corresponde a un formato registrado", request.Plate),
+			ErrorCode: "INVALID_PLATE_FORMAT",
+		}
+		uc.notifyEntryRejection(response, request)
+		return response, nil
+	}
+	request.Plate = plate
+
+	if blocked, err := uc.findBlockedEntry(request.Plate); err != nil {
+		return nil, fmt.Errorf("error al consultar lista de control: %w", err)
+	} else if blocked != nil {
+		return uc.rejectBlockedPlate(blocked, request), nil
+	}
+
 	vehicle, err := uc.findVehicleByPlate(request.Plate)
 	if err == nil && vehicle != nil {
 		
@@ -652,6 +1110,7 @@ func (uc *ParkingUsageUsecase) processManualEntry(request *EntryRequest) (*Entry
 		Zone:           request.Zone,
 		RegisteredBy:   &request.RegisteredBy,
 		EntryTime:      nil,
+		PlateFormat:    plateFormatCode(plateFormat),
 	}
 
 	
@@ -669,10 +1128,97 @@ func (uc *ParkingUsageUsecase) processManualEntry(request *EntryRequest) (*Entry
 		}
 	}
 
+	if request.ParkingID != 0 {
+		parking, err := uc.ParkingRepository.SearchParkingByID(request.ParkingID)
+		if err != nil {
+			return nil, fmt.Errorf("error al obtener el parking %d: %w", request.ParkingID, err)
+		}
+		if !parkingApplication.SpotAccepts(parking, uc.spotRequirementsForUsage(parkingUsage)) {
+			response := &EntryResponse{
+				Success:   false,
+				Message:   fmt.Sprintf("El estacionamiento %s no es apto para el vehículo %s", parking.Code, request.Plate),
+				ErrorCode: "PARKING_NOT_SUITABLE",
+			}
+			uc.notifyEntryRejection(response, request)
+			return response, nil
+		}
+	}
+
 	return uc.createParkingUsageEntry(parkingUsage, request)
 }
 
 
+func (uc *ParkingUsageUsecase) processAllowlistedEntry(request *EntryRequest, entry *watchlistEntities.WatchlistEntry, plateFormat *plateEntities.PlateFormat) (*EntryResponse, error) {
+	hasActiveEntry, err := uc.plateHasActiveEntry(request.Plate)
+	if err != nil {
+		return nil, fmt.Errorf("error al verificar ingreso activo: %w", err)
+	}
+
+	if hasActiveEntry {
+		response := &EntryResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("El vehículo %s ya tiene un ingreso activo", request.Plate),
+			ErrorCode: "VEHICLE_ALREADY_ACTIVE",
+		}
+		uc.notifyEntryRejection(response, request)
+		return response, nil
+	}
+
+	fmt.Printf("Patente %s autorizada por lista blanca (entrada %d): %s\n", request.Plate, entry.ID, entry.Reason)
+
+	parkingUsage := &entities.ParkingUsage{
+		ParkingID:   request.ParkingID,
+		OcrPlate:    request.Plate,
+		QrScanned:   false,
+		ManualEntry: false,
+		EntryTime:   nil,
+		PlateFormat: plateFormatCode(plateFormat),
+	}
+
+	if request.RegisteredBy != "" {
+		parkingUsage.RegisteredBy = &request.RegisteredBy
+	}
+
+	return uc.createParkingUsageEntry(parkingUsage, request)
+}
+
+func (uc *ParkingUsageUsecase) findBlockedEntry(plate string) (*watchlistEntities.WatchlistEntry, error) {
+	entry, err := uc.WatchlistUsecase.FindActiveEntry(plate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if isBlockedEntry(entry) {
+		return entry, nil
+	}
+	return nil, nil
+}
+
+func isBlockedEntry(entry *watchlistEntities.WatchlistEntry) bool {
+	return entry != nil && entry.ListType == watchlistEntities.WatchlistBlock
+}
+
+func isAllowedEntry(entry *watchlistEntities.WatchlistEntry) bool {
+	return entry != nil && entry.ListType == watchlistEntities.WatchlistAllow
+}
+
+func (uc *ParkingUsageUsecase) rejectBlockedPlate(entry *watchlistEntities.WatchlistEntry, request *EntryRequest) *EntryResponse {
+	response := &EntryResponse{
+		Success:   false,
+		Message:   fmt.Sprintf("El vehículo %s tiene el ingreso bloqueado: %s", entry.Plate, entry.Reason),
+		ErrorCode: "VEHICLE_BLOCKED",
+	}
+
+	uc.notifyEntryRejection(response, request)
+	if uc.WebSocketService != nil {
+		uc.WebSocketService.BroadcastAdminAlert("blocked_vehicle_detected", "high", map[string]interface{}{
+			"entry":   entry,
+			"request": request,
+		})
+	}
+
+	return response
+}
+
 func (uc *ParkingUsageUsecase) createParkingUsageEntry(parkingUsage *entities.ParkingUsage, request *EntryRequest) (*EntryResponse, error) {
 	
 	err := uc.CreateParkingUsage(parkingUsage)
@@ -686,6 +1232,14 @@ func (uc *ParkingUsageUsecase) createParkingUsageEntry(parkingUsage *entities.Pa
 		return response, nil
 	}
 
+	if parkingUsage.ReservationID == nil {
+		if customerID, _ := uc.resolveUsageCustomer(parkingUsage); customerID != nil {
+			if _, err := uc.QuotaUsecase.DebitEntry(*customerID, parkingUsage.ID, time.Now()); err != nil {
+				fmt.Printf("Error al descontar cuota del ingreso %d: %v\n", parkingUsage.ID, err)
+			}
+		}
+	}
+
 	
 	response := &EntryResponse{
 		Success:      true,
@@ -700,8 +1254,90 @@ func (uc *ParkingUsageUsecase) createParkingUsageEntry(parkingUsage *entities.Pa
 }
 
 
+func (uc *ParkingUsageUsecase) rejectUnverifiedVehicle(vehicle *vehicleEntity.Vehicle, request *EntryRequest) *EntryResponse {
+	message := fmt.Sprintf("El vehículo %s aún no ha sido verificado", vehicle.Plate)
+	if vehicle.VerificationStatus == vehicleEntity.VerificationStatusRejected {
+		message = fmt.Sprintf("El vehículo %s fue rechazado en la verificación", vehicle.Plate)
+	}
+	response := &EntryResponse{
+		Success:   false,
+		Message:   message,
+		ErrorCode: "VEHICLE_NOT_VERIFIED",
+	}
+	uc.notifyEntryRejection(response, request)
+	return response
+}
+
+func (uc *ParkingUsageUsecase) rejectVehicleOutsideValidity(vehicle *vehicleEntity.Vehicle, request *EntryRequest) *EntryResponse {
+	message := fmt.Sprintf("El vehículo temporal %s no está vigente", vehicle.Plate)
+	if vehicle.ValidFrom != nil && vehicle.ValidUntil != nil {
+		message = fmt.Sprintf("El vehículo temporal %s solo está habilitado entre %s y %s", vehicle.Plate,
+			utils.InCampus(*vehicle.ValidFrom).Format("2006-01-02 15:04"), utils.InCampus(*vehicle.ValidUntil).Format("2006-01-02 15:04"))
+	}
+	response := &EntryResponse{
+		Success:   false,
+		Message:   message,
+		ErrorCode: "VEHICLE_OUTSIDE_VALIDITY",
+	}
+	uc.notifyEntryRejection(response, request)
+	return response
+}
+
 func (uc *ParkingUsageUsecase) findVehicleByPlate(plate string) (*vehicleEntity.Vehicle, error) {
-	return uc.VehicleRepository.SearchVehicleByPlate(plate)
+	return uc.VehicleRepository.SearchVehicleByPlate(uc.PlateFormatUsecase.NormalizePlate(plate))
+}
+
+func (uc *ParkingUsageUsecase) resolveVehicleByPlate(plate string) (*vehicleEntity.Vehicle, plateEntities.PlateCandidates, error) {
+	vehicle, err := uc.findVehicleByPlate(plate)
+	if err == nil && vehicle != nil {
+		return vehicle, nil, nil
+	}
+
+	// Un error del OCR puede cambiar, agregar o quitar un carácter; se traen las patentes de largo parecido
+	// y el ranking decide cuáles quedan como candidatas
+	length := len([]rune(plateApplication.CleanPlate(plate)))
+	vehicles, err := uc.VehicleRepository.SearchVehiclesByPlateLength(length-1, length+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al buscar vehículos: %w", err)
+	}
+
+	formats := uc.PlateFormatUsecase.ActivePlateFormats()
+	candidates := plateEntities.PlateCandidates{}
+	now := time.Now()
+	for _, v := range *vehicles {
+		if !v.IsValidAt(now) {
+			continue
+		}
+		candidates = append(candidates, plateEntities.PlateCandidate{
+			VehicleID: v.ID,
+			Plate:     plateApplication.NormalizePlate(v.Plate, formats),
+		})
+	}
+
+	ranked := plateApplication.RankPlateCandidates(plate, candidates, formats)
+	selected, ambiguous := plateApplication.SelectPlateCandidate(plate, ranked)
+	if ambiguous {
+		return nil, ranked, nil
+	}
+	if selected == nil {
+		return nil, nil, nil
+	}
+
+	for i := range *vehicles {
+		if (*vehicles)[i].ID == selected.VehicleID {
+			fmt.Printf("Patente %s asociada por similitud a %s (costo %.2f)\n", plate, selected.Plate, selected.Cost)
+			return &(*vehicles)[i], nil, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+func plateFormatCode(format *plateEntities.PlateFormat) string {
+	if format == nil {
+		return ""
+	}
+	return format.Code
 }
 
 func (uc *ParkingUsageUsecase) findVehicleByID(vehicleID int) (*vehicleEntity.Vehicle, error) {
@@ -771,6 +1407,19 @@ func (uc *ParkingUsageUsecase) notifyEntrySuccess(response *EntryResponse, reque
 }
 
 
+func (uc *ParkingUsageUsecase) notifyPlateConfirmationRequired(operation string, plate string, candidates plateEntities.PlateCandidates) {
+	if uc.WebSocketService != nil {
+		notification := map[string]interface{}{
+			"type":       "plate_confirmation_required",
+			"operation":  operation,
+			"plate":      plate,
+			"candidates": candidates,
+			"timestamp":  time.Now(),
+		}
+		uc.WebSocketService.BroadcastParkingUsage(notification)
+	}
+}
+
 func (uc *ParkingUsageUsecase) notifyExitRejection(response *ExitResponse, request *ExitRequest) {
 	if uc.WebSocketService != nil {
 		notification := map[string]interface{}{
@@ -813,6 +1462,22 @@ func (uc *ParkingUsageUsecase) vehicleHasActiveEntry(vehicleID int) (bool, error
 }
 
 
+func (uc *ParkingUsageUsecase) plateHasActiveEntry(plate string) (bool, error) {
+	activeUsages, err := uc.ParkingUsageRepository.SearchActiveParkingUsages()
+	if err != nil {
+		return false, err
+	}
+
+	formats := uc.PlateFormatUsecase.ActivePlateFormats()
+	for _, usage := range *activeUsages {
+		if usage.ExitTime == nil && plateApplication.NormalizePlate(usage.OcrPlate, formats) == plate {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (uc *ParkingUsageUsecase) parkingHasActiveUsage(parkingID int) (bool, error) {
 	activeUsages, err := uc.ParkingUsageRepository.SearchActiveParkingUsages()
 	if err != nil {
@@ -836,6 +1501,15 @@ func (uc *ParkingUsageUsecase) GetParkingUsagesByCustomerID(customerID string, f
 		return nil, fmt.Errorf("error al buscar vehículos del cliente: %w", err)
 	}
 
+	shared, err := uc.VehicleRepository.SearchCarpoolVehiclesByMemberID(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar vehículos compartidos del cliente: %w", err)
+	}
+	if len(*shared) > 0 {
+		all := append(*vehicles, *shared...)
+		vehicles = &all
+	}
+
 	if len(*vehicles) == 0 {
 		
 		return &[]entities.ParkingUsage{}, nil
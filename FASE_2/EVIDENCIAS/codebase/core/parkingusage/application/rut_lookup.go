@@ -0,0 +1,44 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
+	userEntities "github.com/gonzalohonorato/servercorego/core/user/domain/entities"
+)
+
+type RutLookupResponse struct {
+	Rut          string                 `json:"rut"`
+	FormattedRut string                 `json:"formattedRut"`
+	User         *userEntities.User     `json:"user,omitempty"`
+	Visits       entities.ParkingUsages `json:"visits"`
+}
+
+func (r *RutLookupResponse) Found() bool {
+	return r.User != nil || len(r.Visits) > 0
+}
+
+// Busca a la persona como usuario registrado y en los ingresos manuales donde se registró como visita
+func (uc *ParkingUsageUsecase) LookupByRut(raw string) (*RutLookupResponse, error) {
+	rut, err := utils.ParseRut(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.UserRepository.SearchUserByRut(rut.String())
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar usuario por RUT: %w", err)
+	}
+	visits, err := uc.ParkingUsageRepository.SearchParkingUsagesByVisitorRut(rut.String())
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar visitas por RUT: %w", err)
+	}
+
+	return &RutLookupResponse{
+		Rut:          rut.String(),
+		FormattedRut: rut.Formatted(),
+		User:         user,
+		Visits:       *visits,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package application
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+)
+
+type StaleUsageScheduler struct {
+	usecase       *ParkingUsageUsecase
+	flagged       map[int]bool
+	lastAutoClose string
+	stop          chan bool
+	running       bool
+}
+
+func NewStaleUsageScheduler(usecase *ParkingUsageUsecase) *StaleUsageScheduler {
+	return &StaleUsageScheduler{
+		usecase: usecase,
+		flagged: make(map[int]bool),
+		stop:    make(chan bool),
+		running: false,
+	}
+}
+
+func (s *StaleUsageScheduler) Start() {
+	if s.running {
+		log.Println("El scheduler de usos abiertos ya está en ejecución")
+		return
+	}
+
+	s.running = true
+	log.Println("Iniciando scheduler de revisión de usos abiertos...")
+
+	s.runStaleUsageTask()
+
+	go s.startStaleUsageScheduler()
+}
+
+func (s *StaleUsageScheduler) startStaleUsageScheduler() {
+	intervalStr := os.Getenv("STALE_USAGE_CHECK_INTERVAL")
+	interval := 30 * time.Minute
+
+	if intervalStr != "" {
+		if minutes, err := strconv.Atoi(intervalStr); err == nil && minutes > 0 {
+			interval = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	log.Printf("Iniciando scheduler de usos abiertos con intervalo de %v", interval)
+	ticker := time.NewTicker(interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runStaleUsageTask()
+		case <-s.stop:
+			ticker.Stop()
+			log.Println("Scheduler de usos abiertos detenido")
+			return
+		}
+	}
+}
+
+func (s *StaleUsageScheduler) Stop() {
+	if s.running {
+		close(s.stop)
+		s.running = false
+		log.Println("Deteniendo scheduler de usos abiertos...")
+	}
+}
+
+func (s *StaleUsageScheduler) runStaleUsageTask() {
+	threshold := StaleUsageThreshold()
+
+	if s.shouldAutoClose(time.Now()) {
+		log.Println("Ejecutando cierre automático de usos abiertos...")
+		count, err := s.usecase.AutoCloseStaleParkingUsages(threshold)
+		if err != nil {
+			log.Printf("Error al cerrar usos abiertos: %v", err)
+		} else {
+			s.lastAutoClose = utils.InCampus(time.Now()).Format("2006-01-02")
+			if count > 0 {
+				log.Printf("Proceso completado: %d usos cerrados automáticamente", count)
+			}
+		}
+	}
+
+	staleUsages, err := s.usecase.SearchStaleParkingUsages(threshold)
+	if err != nil {
+		log.Printf("Error al buscar usos abiertos: %v", err)
+		return
+	}
+
+	current := make(map[int]bool)
+	newlyFlagged := 0
+	for _, usage := range *staleUsages {
+		current[usage.ID] = true
+		if !s.flagged[usage.ID] {
+			newlyFlagged++
+		}
+	}
+	s.flagged = current
+
+	if newlyFlagged > 0 && s.usecase.WebSocketService != nil {
+		log.Printf("%d usos abiertos por más de %v requieren revisión", newlyFlagged, threshold)
+		s.usecase.WebSocketService.BroadcastAdminAlert("stale_parking_usages", "medium", map[string]interface{}{
+			"parkingUsages":  staleUsages,
+			"thresholdHours": threshold.Hours(),
+		})
+	}
+}
+
+func (s *StaleUsageScheduler) shouldAutoClose(now time.Time) bool {
+	if os.Getenv("STALE_USAGE_AUTO_CLOSE") != "true" {
+		return false
+	}
+
+	now = utils.InCampus(now)
+	if s.lastAutoClose == now.Format("2006-01-02") {
+		return false
+	}
+
+	closeTime := os.Getenv("STALE_USAGE_AUTO_CLOSE_TIME")
+	if closeTime == "" {
+		closeTime = "23:30"
+	}
+
+	clock, err := time.Parse("15:04", closeTime)
+	if err != nil {
+		return false
+	}
+
+	scheduled := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	return !now.Before(scheduled)
+}
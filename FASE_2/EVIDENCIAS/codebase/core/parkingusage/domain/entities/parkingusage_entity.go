@@ -2,6 +2,12 @@ package entities
 
 import "time"
 
+const (
+	ExitTypeGate  = "gate"
+	ExitTypeGuard = "guard"
+	ExitTypeAuto  = "auto"
+)
+
 type ParkingUsage struct {
 	ID             int        `json:"id"`
 	ReservationID  *int       `json:"reservationId"`
@@ -17,6 +23,8 @@ type ParkingUsage struct {
 	VisitorRut     string     `json:"visitorRut"`
 	VisitorContact string     `json:"visitorContact"`
 	Zone           string     `json:"zone"`
+	PlateFormat    string     `json:"plateFormat"`
+	ExitType       string     `json:"exitType"`
 }
 
 type ParkingUsages []ParkingUsage
@@ -7,6 +7,7 @@ type ParkingUsageRepository interface {
 	SearchParkingUsages() (*entities.ParkingUsages, error)
 	SearchParkingUsagesByVehicleID(id int) (*entities.ParkingUsages, error)
 	SearchActiveParkingUsages() (*entities.ParkingUsages, error)
+	SearchParkingUsagesByVisitorRut(rut string) (*entities.ParkingUsages, error)
 	CreateParkingUsage(parkingUsage *entities.ParkingUsage) error
 	UpdateParkingUsageByID(parkingUsage *entities.ParkingUsage) error
 	DeleteParkingUsageByID(id int) error
@@ -23,13 +23,13 @@ func (r *TimescaleParkingUsageRepository) SearchParkingUsageByID(id int) (*entit
 	ctx := context.Background()
 	query := `SELECT id, reservation_id, vehicle_id, parking_id, entry_time, exit_time, 
 			  ocr_plate, qr_scanned, registered_by, manual_entry, visitor_name, visitor_rut, 
-			  visitor_contact, zone FROM parking_usage WHERE id = $1`
+			  visitor_contact, zone, plate_format, exit_type FROM parking_usage WHERE id = $1`
 	row := r.dbPool.QueryRow(ctx, query, id)
 	var p entities.ParkingUsage
 	err := row.Scan(
 		&p.ID, &p.ReservationID, &p.VehicleID, &p.ParkingID, &p.EntryTime, &p.ExitTime,
 		&p.OcrPlate, &p.QrScanned, &p.RegisteredBy, &p.ManualEntry, &p.VisitorName,
-		&p.VisitorRut, &p.VisitorContact, &p.Zone,
+		&p.VisitorRut, &p.VisitorContact, &p.Zone, &p.PlateFormat, &p.ExitType,
 	)
 	if err != nil {
 		return nil, err
@@ -41,7 +41,7 @@ func (r *TimescaleParkingUsageRepository) SearchParkingUsages() (*entities.Parki
 	ctx := context.Background()
 	query := `SELECT id, reservation_id, vehicle_id, parking_id, entry_time, exit_time, 
               ocr_plate, qr_scanned, registered_by, manual_entry, visitor_name, visitor_rut, 
-              visitor_contact, zone FROM parking_usage`
+              visitor_contact, zone, plate_format, exit_type FROM parking_usage`
 	rows, err := r.dbPool.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -54,7 +54,7 @@ func (r *TimescaleParkingUsageRepository) SearchParkingUsages() (*entities.Parki
 		if err := rows.Scan(
 			&p.ID, &p.ReservationID, &p.VehicleID, &p.ParkingID, &p.EntryTime, &p.ExitTime,
 			&p.OcrPlate, &p.QrScanned, &p.RegisteredBy, &p.ManualEntry, &p.VisitorName,
-			&p.VisitorRut, &p.VisitorContact, &p.Zone,
+			&p.VisitorRut, &p.VisitorContact, &p.Zone, &p.PlateFormat, &p.ExitType,
 		); err != nil {
 			return nil, err
 		}
@@ -69,7 +69,7 @@ func (r *TimescaleParkingUsageRepository) SearchActiveParkingUsages() (*entities
 	ctx := context.Background()
 	query := `SELECT id, reservation_id, vehicle_id, parking_id, entry_time, exit_time, 
               ocr_plate, qr_scanned, registered_by, manual_entry, visitor_name, visitor_rut, 
-              visitor_contact, zone FROM active_parking_usages`
+              visitor_contact, zone, plate_format, exit_type FROM active_parking_usages`
 	rows, err := r.dbPool.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -82,7 +82,7 @@ func (r *TimescaleParkingUsageRepository) SearchActiveParkingUsages() (*entities
 		if err := rows.Scan(
 			&p.ID, &p.ReservationID, &p.VehicleID, &p.ParkingID, &p.EntryTime, &p.ExitTime,
 			&p.OcrPlate, &p.QrScanned, &p.RegisteredBy, &p.ManualEntry, &p.VisitorName,
-			&p.VisitorRut, &p.VisitorContact, &p.Zone,
+			&p.VisitorRut, &p.VisitorContact, &p.Zone, &p.PlateFormat, &p.ExitType,
 		); err != nil {
 			return nil, err
 		}
@@ -97,7 +97,7 @@ func (r *TimescaleParkingUsageRepository) SearchParkingUsagesByVehicleID(id int)
 	ctx := context.Background()
 	query := `SELECT id, reservation_id, vehicle_id, parking_id, entry_time, exit_time,
 			  ocr_plate, qr_scanned, registered_by, manual_entry, visitor_name, visitor_rut,	
-			  visitor_contact, zone FROM parking_usage WHERE vehicle_id = $1`
+			  visitor_contact, zone, plate_format, exit_type FROM parking_usage WHERE vehicle_id = $1`
 	rows, err := r.dbPool.Query(ctx, query, id)
 	if err != nil {
 		return nil, err
@@ -109,7 +109,7 @@ func (r *TimescaleParkingUsageRepository) SearchParkingUsagesByVehicleID(id int)
 		if err := rows.Scan(
 			&p.ID, &p.ReservationID, &p.VehicleID, &p.ParkingID, &p.EntryTime, &p.ExitTime,
 			&p.OcrPlate, &p.QrScanned, &p.RegisteredBy, &p.ManualEntry, &p.VisitorName,
-			&p.VisitorRut, &p.VisitorContact, &p.Zone,
+			&p.VisitorRut, &p.VisitorContact, &p.Zone, &p.PlateFormat, &p.ExitType,
 		); err != nil {
 			return nil, err
 		}
@@ -120,15 +120,43 @@ func (r *TimescaleParkingUsageRepository) SearchParkingUsagesByVehicleID(id int)
 	}
 	return &parkingUsages, nil 
 }
+func (r *TimescaleParkingUsageRepository) SearchParkingUsagesByVisitorRut(rut string) (*entities.ParkingUsages, error) {
+	ctx := context.Background()
+	query := `SELECT id, reservation_id, vehicle_id, parking_id, entry_time, exit_time,
+			  ocr_plate, qr_scanned, registered_by, manual_entry, visitor_name, visitor_rut,
+			  visitor_contact, zone, plate_format, exit_type FROM parking_usage
+			  WHERE visitor_rut = $1 ORDER BY entry_time DESC NULLS LAST`
+	rows, err := r.dbPool.Query(ctx, query, rut)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	parkingUsages := entities.ParkingUsages{}
+	for rows.Next() {
+		var p entities.ParkingUsage
+		if err := rows.Scan(
+			&p.ID, &p.ReservationID, &p.VehicleID, &p.ParkingID, &p.EntryTime, &p.ExitTime,
+			&p.OcrPlate, &p.QrScanned, &p.RegisteredBy, &p.ManualEntry, &p.VisitorName,
+			&p.VisitorRut, &p.VisitorContact, &p.Zone, &p.PlateFormat, &p.ExitType,
+		); err != nil {
+			return nil, err
+		}
+		parkingUsages = append(parkingUsages, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &parkingUsages, nil
+}
 func (r *TimescaleParkingUsageRepository) CreateParkingUsage(parkingUsage *entities.ParkingUsage) error {
 	ctx := context.Background()
 	query := `
 	INSERT INTO parking_usage (
 		reservation_id, vehicle_id, parking_id, entry_time, exit_time, 
 		ocr_plate, qr_scanned, registered_by, manual_entry, visitor_name, 
-		visitor_rut, visitor_contact, zone
+		visitor_rut, visitor_contact, zone, plate_format, exit_type
 	) VALUES (
-		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 	) RETURNING id;
 `
 	err := r.dbPool.QueryRow(ctx, query,
@@ -136,7 +164,7 @@ func (r *TimescaleParkingUsageRepository) CreateParkingUsage(parkingUsage *entit
 		parkingUsage.EntryTime, parkingUsage.ExitTime, parkingUsage.OcrPlate,
 		parkingUsage.QrScanned, parkingUsage.RegisteredBy, parkingUsage.ManualEntry,
 		parkingUsage.VisitorName, parkingUsage.VisitorRut, parkingUsage.VisitorContact,
-		parkingUsage.Zone).Scan(&parkingUsage.ID)
+		parkingUsage.Zone, parkingUsage.PlateFormat, parkingUsage.ExitType).Scan(&parkingUsage.ID)
 	return err
 }
 
@@ -146,13 +174,13 @@ func (r *TimescaleParkingUsageRepository) UpdateParkingUsageByID(p *entities.Par
 		reservation_id = $2, vehicle_id = $3, parking_id = $4, entry_time = $5, 
 		exit_time = $6, ocr_plate = $7, qr_scanned = $8, registered_by = $9, 
 		manual_entry = $10, visitor_name = $11, visitor_rut = $12, 
-		visitor_contact = $13, zone = $14 
+		visitor_contact = $13, zone = $14, plate_format = $15, exit_type = $16
 	WHERE id = $1`
 
 	_, err := r.dbPool.Exec(ctx, query,
 		p.ID, p.ReservationID, p.VehicleID, p.ParkingID, p.EntryTime, p.ExitTime,
 		p.OcrPlate, p.QrScanned, p.RegisteredBy, p.ManualEntry, p.VisitorName,
-		p.VisitorRut, p.VisitorContact, p.Zone)
+		p.VisitorRut, p.VisitorContact, p.Zone, p.PlateFormat, p.ExitType)
 	return err
 }
 
@@ -181,7 +209,7 @@ func (r *TimescaleParkingUsageRepository) SearchParkingUsagesByVehicleIDs(vehicl
 	baseQuery := fmt.Sprintf(`
         SELECT id, reservation_id, vehicle_id, parking_id, entry_time, exit_time,
         ocr_plate, qr_scanned, registered_by, manual_entry, visitor_name, visitor_rut,
-        visitor_contact, zone 
+        visitor_contact, zone, plate_format, exit_type
         FROM parking_usage 
         WHERE vehicle_id IN (%s)
     `, strings.Join(placeholders, ","))
@@ -239,7 +267,7 @@ func (r *TimescaleParkingUsageRepository) SearchParkingUsagesByVehicleIDs(vehicl
 		if err := rows.Scan(
 			&p.ID, &p.ReservationID, &p.VehicleID, &p.ParkingID, &p.EntryTime, &p.ExitTime,
 			&p.OcrPlate, &p.QrScanned, &p.RegisteredBy, &p.ManualEntry, &p.VisitorName,
-			&p.VisitorRut, &p.VisitorContact, &p.Zone,
+			&p.VisitorRut, &p.VisitorContact, &p.Zone, &p.PlateFormat, &p.ExitType,
 		); err != nil {
 			return nil, fmt.Errorf("error al escanear fila: %w", err)
 		}
@@ -1,20 +1,37 @@
 package controllers
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"regexp"
 	"strconv"
+	"time"
 
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	chargingGateways "github.com/gonzalohonorato/servercorego/core/charging/domain/gateways"
+	chargingRepositories "github.com/gonzalohonorato/servercorego/core/charging/domain/repositories"
+	closureRepositories "github.com/gonzalohonorato/servercorego/core/closure/domain/repositories"
 	parkingRepository "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/parkingusage/application"
 	"github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	paymentGateways "github.com/gonzalohonorato/servercorego/core/payment/domain/gateways"
+	paymentRepositories "github.com/gonzalohonorato/servercorego/core/payment/domain/repositories"
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	plateEntities "github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	quotaRepositories "github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
 	reservationRepository "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	tariffRepositories "github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
 	vehicleRepository "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	watchlistRepository "github.com/gonzalohonorato/servercorego/core/watchlist/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
 	"github.com/gorilla/mux"
 )
@@ -29,6 +46,20 @@ func NewParkingUsageController(
 	vehicleRepository vehicleRepository.VehicleRepository,
 	reservationRepository reservationRepository.ReservationRepository,
 	wsService *infrastructure.WebSocketService,
+	plateFormatRepository plateRepositories.PlateFormatRepository,
+	watchlistRepository watchlistRepository.WatchlistRepository,
+	userRepository userRepositories.UserRepository,
+	tariffRepository tariffRepositories.TariffRepository,
+	parkingChargeRepository tariffRepositories.ParkingChargeRepository,
+	ledgerRepository paymentRepositories.LedgerRepository,
+	paymentGateway paymentGateways.PaymentGateway,
+	quotaPolicyRepository quotaRepositories.QuotaPolicyRepository,
+	quotaMovementRepository quotaRepositories.QuotaMovementRepository,
+	accessibilityPermitRepository parkingRepository.AccessibilityPermitRepository,
+	closureRepository closureRepositories.ClosureRepository,
+	calendarEventRepository calendarRepositories.CalendarEventRepository,
+	chargingSessionRepository chargingRepositories.ChargingSessionRepository,
+	chargerClient chargingGateways.ChargerClient,
 ) *ParkingUsageController {
 	parkingUsageUseCase := application.NewParkingUsageUsecase(
 		parkingUsageRepository,
@@ -36,6 +67,20 @@ func NewParkingUsageController(
 		vehicleRepository,
 		reservationRepository,
 		wsService,
+		plateFormatRepository,
+		watchlistRepository,
+		userRepository,
+		tariffRepository,
+		parkingChargeRepository,
+		ledgerRepository,
+		paymentGateway,
+		quotaPolicyRepository,
+		quotaMovementRepository,
+		accessibilityPermitRepository,
+		closureRepository,
+		calendarEventRepository,
+		chargingSessionRepository,
+		chargerClient,
 	)
 
 	return &ParkingUsageController{
@@ -59,6 +104,23 @@ func (uc *ParkingUsageController) GetParkingUsageByID(w http.ResponseWriter, r *
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(parkingUsage)
 }
+func (uc *ParkingUsageController) GetRutLookup(w http.ResponseWriter, r *http.Request) {
+	result, err := uc.ParkingUsageUsecase.LookupByRut(mux.Vars(r)["rut"])
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidRut) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Error searching by rut", http.StatusInternalServerError)
+		return
+	}
+	if !result.Found() {
+		http.Error(w, "Rut not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
 func (uc *ParkingUsageController) GetParkingUsages(w http.ResponseWriter, r *http.Request) {
 	parkingUsages, err := uc.ParkingUsageUsecase.SearchParkingUsages()
 	if err != nil {
@@ -99,6 +161,60 @@ func (uc *ParkingUsageController) PostRegisterExitTime(w http.ResponseWriter, r
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+func (uc *ParkingUsageController) GetStaleParkingUsages(w http.ResponseWriter, r *http.Request) {
+	threshold := application.StaleUsageThreshold()
+	if hoursStr := r.URL.Query().Get("hours"); hoursStr != "" {
+		hours, err := strconv.Atoi(hoursStr)
+		if err != nil || hours <= 0 {
+			http.Error(w, "Invalid hours parameter", http.StatusBadRequest)
+			return
+		}
+		threshold = time.Duration(hours) * time.Hour
+	}
+
+	parkingUsages, err := uc.ParkingUsageUsecase.SearchStaleParkingUsages(threshold)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "ParkingUsages not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(parkingUsages)
+}
+
+func (uc *ParkingUsageController) PostCloseStaleParkingUsage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		ClosedBy string `json:"closedBy"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.ClosedBy == "" {
+		http.Error(w, "closedBy is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := uc.ParkingUsageUsecase.CloseStaleParkingUsage(idInt, request.ClosedBy)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error closing parking usage: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !response.Success {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
 func (uc *ParkingUsageController) GetActiveParkingUsages(w http.ResponseWriter, r *http.Request) {
 	parkingUsages, err := uc.ParkingUsageUsecase.SearchActiveParkingUsages()
 	if err != nil {
@@ -202,6 +318,43 @@ func (uc *ParkingUsageController) PostOCREntry(w http.ResponseWriter, r *http.Re
 	json.NewEncoder(w).Encode(response)
 }
 
+func (uc *ParkingUsageController) PostOCREntryConfirmation(w http.ResponseWriter, r *http.Request) {
+	var confirmation struct {
+		Plate        string `json:"plate"`
+		VehicleID    int    `json:"vehicleId"`
+		RegisteredBy string `json:"registeredBy"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&confirmation); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if confirmation.VehicleID == 0 {
+		http.Error(w, "vehicleId is required", http.StatusBadRequest)
+		return
+	}
+
+	entryRequest := &application.EntryRequest{
+		EntryType:    "ocr",
+		Plate:        confirmation.Plate,
+		VehicleID:    confirmation.VehicleID,
+		RegisteredBy: confirmation.RegisteredBy,
+	}
+
+	response, err := uc.ParkingUsageUsecase.ProcessParkingEntry(entryRequest)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error processing entry: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !response.Success {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
 func (uc *ParkingUsageController) PostParkingEntry(w http.ResponseWriter, r *http.Request) {
 	var entryRequest application.EntryRequest
 	if err := json.NewDecoder(r.Body).Decode(&entryRequest); err != nil {
@@ -282,13 +435,14 @@ type OCRRequest struct {
 }
 
 type OCRResponse struct {
-	Success      bool                   `json:"success"`
-	Message      string                 `json:"message"`
-	Plate        string                 `json:"plate,omitempty"`
-	OcrPlate     string                 `json:"ocrPlate,omitempty"`
-	Confidence   float64                `json:"confidence,omitempty"`
-	ParkingUsage *entities.ParkingUsage `json:"parkingUsage,omitempty"`
-	ErrorCode    string                 `json:"errorCode,omitempty"`
+	Success      bool                          `json:"success"`
+	Message      string                        `json:"message"`
+	Plate        string                        `json:"plate,omitempty"`
+	OcrPlate     string                        `json:"ocrPlate,omitempty"`
+	Confidence   float64                       `json:"confidence,omitempty"`
+	ParkingUsage *entities.ParkingUsage        `json:"parkingUsage,omitempty"`
+	ErrorCode    string                        `json:"errorCode,omitempty"`
+	Candidates   plateEntities.PlateCandidates `json:"candidates,omitempty"`
 }
 
 type TogetherAIResponse struct {
@@ -363,6 +517,7 @@ func (uc *ParkingUsageController) PostOCREntryWithImage(w http.ResponseWriter, r
 		Confidence:   confidence,
 		ParkingUsage: entryResponse.ParkingUsage,
 		ErrorCode:    entryResponse.ErrorCode,
+		Candidates:   entryResponse.Candidates,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -435,6 +590,7 @@ func (uc *ParkingUsageController) PostOCRExit(w http.ResponseWriter, r *http.Req
 		Confidence:   confidence,
 		ParkingUsage: exitResponse.ParkingUsage,
 		ErrorCode:    exitResponse.ErrorCode,
+		Candidates:   exitResponse.Candidates,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -463,7 +619,7 @@ func (uc *ParkingUsageController) processImageWithTogetherAI(base64Image string)
 						Si no puedes detectar una patente claramente, responde:
 						{"plate": "", "confidence": 0.0}
 						
-						La patente debe estar en formato chileno (6-7 caracteres alfanuméricos).
+						La patente puede ser chilena (autos, motos o remolques) o extranjera (5-7 caracteres alfanuméricos).
 						NO agregues explicaciones adicionales, solo el JSON.`,
 					},
 					{
@@ -484,7 +640,7 @@ func (uc *ParkingUsageController) processImageWithTogetherAI(base64Image string)
 		return "", 0, fmt.Errorf("error marshaling payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", "https:")
+	req, err := http.NewRequest("POST", "https:", bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		return "", 0, fmt.Errorf("error creating request: %w", err)
 	}
@@ -539,8 +695,10 @@ func (uc *ParkingUsageController) processImageWithTogetherAI(base64Image string)
 		return "", 0, fmt.Errorf("could not parse plate from response: %s", content)
 	}
 
-	if plateResult.Plate != "" && uc.isValidChileanPlate(plateResult.Plate) {
-		return plateResult.Plate, plateResult.Confidence, nil
+	if plateResult.Plate != "" {
+		if plate, format := uc.ParkingUsageUsecase.PlateFormatUsecase.DetectPlateFormat(plateResult.Plate); format != nil {
+			return plate, plateResult.Confidence, nil
+		}
 	}
 
 	return "", 0, nil
@@ -548,40 +706,13 @@ func (uc *ParkingUsageController) processImageWithTogetherAI(base64Image string)
 
 func (uc *ParkingUsageController) extractPlateFromText(text string) string {
 
-	patterns := []string{
-		`[A-Z]{4}[0-9]{2}`,
-		`[A-Z]{2}[0-9]{4}`,
-		`[A-Z]{3}[0-9]{3}`,
-	}
-
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		if match := re.FindString(text); match != "" {
-			return match
+	formats := uc.ParkingUsageUsecase.PlateFormatUsecase.ActivePlateFormats()
+	re := regexp.MustCompile(`[A-Z0-9][A-Z0-9\-.·]{3,8}[A-Z0-9]`)
+	for _, match := range re.FindAllString(text, -1) {
+		if plateApplication.IsValidPlate(match, formats) {
+			return plateApplication.CleanPlate(match)
 		}
 	}
 
 	return ""
 }
-
-func (uc *ParkingUsageController) isValidChileanPlate(plate string) bool {
-
-	if len(plate) < 6 || len(plate) > 7 {
-		return false
-	}
-
-	patterns := []string{
-		`^[A-Z]{4}[0-9]{2}$`,
-		`^[A-Z]{2}[0-9]{4}$`,
-		`^[A-Z]{3}[0-9]{3}$`,
-	}
-
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		if re.MatchString(plate) {
-			return true
-		}
-	}
-
-	return false
-}
@@ -13,19 +13,36 @@ func ParkingUsageRoutes(router *mux.Router, container *injector.Container) {
 		container.ProvideVehicleRepository(),
 		container.ProvideReservationRepository(),
 		container.ProvideWebSocketService(),
+		container.ProvidePlateFormatRepository(),
+		container.ProvideWatchlistRepository(),
+		container.ProvideUserRepository(),
+		container.ProvideTariffRepository(),
+		container.ProvideParkingChargeRepository(),
+		container.ProvideLedgerRepository(),
+		container.ProvidePaymentGateway(),
+		container.ProvideQuotaPolicyRepository(),
+		container.ProvideQuotaMovementRepository(),
+		container.ProvideAccessibilityPermitRepository(),
+		container.ProvideClosureRepository(),
+		container.ProvideCalendarEventRepository(),
+		container.ProvideChargingSessionRepository(),
+		container.ProvideChargerClient(),
 	)
 
 	
 	router.HandleFunc("/parking-usages", controller.PutParkingUsage).Methods("PUT")
 	router.HandleFunc("/parking-usages", controller.GetParkingUsages).Methods("GET")
 	router.HandleFunc("/active-parking-usages", controller.GetActiveParkingUsages).Methods("GET")
+	router.HandleFunc("/parking-usages/stale", controller.GetStaleParkingUsages).Methods("GET")
 	router.HandleFunc("/parking-usages/vehicle/{id}", controller.GetParkingUsagesByVehicleID).Methods("GET")
 	router.HandleFunc("/parking-usages/customer/{customerID}", controller.GetParkingUsagesByCustomerID).Methods("GET")
+	router.HandleFunc("/rut-lookup/{rut}", controller.GetRutLookup).Methods("GET")
 
 	router.HandleFunc("/parking-usages/{id}", controller.DeleteParkingUsageByID).Methods("DELETE")
 	router.HandleFunc("/parking-usages/{id}", controller.GetParkingUsageByID).Methods("GET")
 	router.HandleFunc("/parking-usages", controller.PostParkingUsage).Methods("POST")
 	router.HandleFunc("/parking-usages/{id}/exit", controller.PostRegisterExitTime).Methods("POST")
+	router.HandleFunc("/parking-usages/{id}/close", controller.PostCloseStaleParkingUsage).Methods("POST")
 
 	
 	router.HandleFunc("/parking-entries", controller.PostParkingEntry).Methods("POST")
@@ -34,5 +51,6 @@ func ParkingUsageRoutes(router *mux.Router, container *injector.Container) {
 
 	
 	router.HandleFunc("/parking-entries/ocr", controller.PostOCREntryWithImage).Methods("POST")
+	router.HandleFunc("/parking-entries/ocr/confirm", controller.PostOCREntryConfirmation).Methods("POST")
 	router.HandleFunc("/ocr/exits", controller.PostOCRExit).Methods("POST")
 }
@@ -0,0 +1,307 @@
+package application
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	parkingUsageEntities "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
+	parkingUsageRepositories "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/payment/domain/gateways"
+	"github.com/gonzalohonorato/servercorego/core/payment/domain/repositories"
+	tariffEntities "github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	tariffRepositories "github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+)
+
+const defaultCurrency = "CLP"
+
+var (
+	ErrPaymentDeclined = fmt.Errorf("pago rechazado por la pasarela")
+	ErrInvalidAmount   = fmt.Errorf("el monto debe ser mayor a cero")
+	ErrRefundExceeded  = fmt.Errorf("los reembolsos superarían el monto pagado")
+	ErrUsageNotFound   = fmt.Errorf("uso de estacionamiento no encontrado")
+)
+
+type PaymentUsecase struct {
+	LedgerRepository        repositories.LedgerRepository
+	ParkingChargeRepository tariffRepositories.ParkingChargeRepository
+	PaymentGateway          gateways.PaymentGateway
+	ParkingUsageRepository  parkingUsageRepositories.ParkingUsageRepository
+	VehicleRepository       vehicleRepositories.VehicleRepository
+}
+
+func NewPaymentUsecase(
+	ledgerRepo repositories.LedgerRepository,
+	chargeRepo tariffRepositories.ParkingChargeRepository,
+	gateway gateways.PaymentGateway,
+	parkingUsageRepo parkingUsageRepositories.ParkingUsageRepository,
+	vehicleRepo vehicleRepositories.VehicleRepository,
+) *PaymentUsecase {
+	return &PaymentUsecase{
+		LedgerRepository:        ledgerRepo,
+		ParkingChargeRepository: chargeRepo,
+		PaymentGateway:          gateway,
+		ParkingUsageRepository:  parkingUsageRepo,
+		VehicleRepository:       vehicleRepo,
+	}
+}
+
+func (uc *PaymentUsecase) RecordCharge(charge *tariffEntities.ParkingCharge) (*entities.LedgerEntry, error) {
+	usageID := charge.ParkingUsageID
+	entry := &entities.LedgerEntry{
+		CustomerID:     charge.CustomerID,
+		VisitorRut:     charge.VisitorRut,
+		ParkingUsageID: &usageID,
+		EntryType:      entities.LedgerCharge,
+		Amount:         charge.Amount,
+		Currency:       charge.Currency,
+		Reference:      fmt.Sprintf("charge-%d", charge.ID),
+		Description:    charge.Description,
+		CreatedAt:      charge.CreatedAt,
+	}
+
+	if err := uc.LedgerRepository.CreateLedgerEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (uc *PaymentUsecase) RecordAdditionalCharge(entry *entities.LedgerEntry) error {
+	if entry.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+	entry.EntryType = entities.LedgerCharge
+	if entry.Currency == "" {
+		entry.Currency = defaultCurrency
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	return uc.LedgerRepository.CreateLedgerEntry(entry)
+}
+
+func (uc *PaymentUsecase) Pay(request *entities.PaymentRequest) (*entities.LedgerEntry, error) {
+	if request.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if request.CustomerID == nil && request.ParkingUsageID == nil {
+		return nil, fmt.Errorf("se requiere un cliente o un uso de estacionamiento")
+	}
+	if request.Currency == "" {
+		request.Currency = defaultCurrency
+	}
+	if request.VisitorRut != "" {
+		rut, err := utils.ParseRut(request.VisitorRut)
+		if err != nil {
+			return nil, err
+		}
+		request.VisitorRut = rut.String()
+	}
+	if request.ParkingUsageID != nil {
+		if err := uc.attachUsageOwner(request); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := uc.PaymentGateway.Charge(request)
+	if err != nil {
+		return nil, fmt.Errorf("error al procesar el pago: %w", err)
+	}
+	if !result.Approved {
+		return nil, fmt.Errorf("%w: %s", ErrPaymentDeclined, result.Message)
+	}
+
+	entry := &entities.LedgerEntry{
+		CustomerID:     request.CustomerID,
+		VisitorRut:     request.VisitorRut,
+		ParkingUsageID: request.ParkingUsageID,
+		EntryType:      entities.LedgerPayment,
+		Amount:         request.Amount,
+		Currency:       request.Currency,
+		Method:         request.Method,
+		Reference:      result.Reference,
+		Description:    result.Message,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := uc.LedgerRepository.CreateLedgerEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (uc *PaymentUsecase) Refund(paymentID int, request *entities.RefundRequest) (*entities.LedgerEntry, error) {
+	payment, err := uc.LedgerRepository.SearchLedgerEntryByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.EntryType != entities.LedgerPayment {
+		return nil, fmt.Errorf("solo se pueden reembolsar pagos")
+	}
+
+	amount := request.Amount
+	if amount == 0 {
+		amount = payment.Amount
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	// El reembolso queda reservado antes de llamar a la pasarela para que dos solicitudes simultáneas
+	// no puedan devolver más de lo pagado; si la pasarela falla la reserva se libera
+	entry := &entities.LedgerEntry{
+		CustomerID:     payment.CustomerID,
+		VisitorRut:     payment.VisitorRut,
+		ParkingUsageID: payment.ParkingUsageID,
+		EntryType:      entities.LedgerRefund,
+		Amount:         amount,
+		Currency:       payment.Currency,
+		Method:         payment.Method,
+		Description:    request.Reason,
+		CreatedAt:      time.Now(),
+	}
+	reserved, err := uc.LedgerRepository.ReserveRefund(paymentID, entry)
+	if err != nil {
+		return nil, fmt.Errorf("error al reservar el reembolso: %w", err)
+	}
+	if !reserved {
+		return nil, ErrRefundExceeded
+	}
+
+	result, err := uc.PaymentGateway.Refund(payment.Reference, amount, payment.Currency)
+	if err == nil && !result.Approved {
+		err = fmt.Errorf("%w: %s", ErrPaymentDeclined, result.Message)
+	}
+	if err != nil {
+		if deleteErr := uc.LedgerRepository.DeleteLedgerEntryByID(entry.ID); deleteErr != nil {
+			log.Printf("Error al liberar reembolso reservado %d del pago %d: %v", entry.ID, paymentID, deleteErr)
+		}
+		return nil, fmt.Errorf("error al procesar el reembolso: %w", err)
+	}
+
+	entry.Reference = result.Reference
+	if err := uc.LedgerRepository.ConfirmRefund(entry.ID, entry.Reference); err != nil {
+		log.Printf("Reembolso %d aprobado por la pasarela (%s) pero no se pudo guardar la referencia: %v", entry.ID, entry.Reference, err)
+	}
+	return entry, nil
+}
+
+func (uc *PaymentUsecase) SearchLedgerEntriesByCustomerID(customerID string) (*entities.LedgerEntries, error) {
+	return uc.LedgerRepository.SearchLedgerEntriesByCustomerID(customerID)
+}
+
+// Un pago hecho solo con el uso (por ejemplo, en el tótem) se imputa al dueño del vehículo o al visitante,
+// porque la salida revisa el saldo del cliente y no el del uso
+func (uc *PaymentUsecase) attachUsageOwner(request *entities.PaymentRequest) error {
+	usage, err := uc.ParkingUsageRepository.SearchParkingUsageByID(*request.ParkingUsageID)
+	if err != nil {
+		return fmt.Errorf("%w: %d", ErrUsageNotFound, *request.ParkingUsageID)
+	}
+
+	customerID, err := uc.UsageCustomerID(usage)
+	if err != nil {
+		return err
+	}
+	if customerID != nil {
+		if request.CustomerID != nil && *request.CustomerID != *customerID {
+			return fmt.Errorf("el uso %d no pertenece al cliente %s", usage.ID, *request.CustomerID)
+		}
+		request.CustomerID = customerID
+	}
+	if request.VisitorRut == "" {
+		request.VisitorRut = usage.VisitorRut
+	}
+	return nil
+}
+
+// Cliente dueño del vehículo del uso; nil si el uso es de un visitante o el vehículo no tiene dueño
+func (uc *PaymentUsecase) UsageCustomerID(usage *parkingUsageEntities.ParkingUsage) (*string, error) {
+	if usage.VehicleID == nil {
+		return nil, nil
+	}
+	vehicle, err := uc.VehicleRepository.SearchVehicleByID(*usage.VehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener el vehículo del uso %d: %w", usage.ID, err)
+	}
+	if vehicle.CustomerID == "" {
+		return nil, nil
+	}
+	return &vehicle.CustomerID, nil
+}
+
+func (uc *PaymentUsecase) SearchLedgerEntriesByVisitorRut(visitorRut string) (*entities.LedgerEntries, error) {
+	rut, err := utils.ParseRut(visitorRut)
+	if err != nil {
+		return nil, err
+	}
+	return uc.LedgerRepository.SearchLedgerEntriesByVisitorRut(rut.String())
+}
+
+func (uc *PaymentUsecase) CustomerBalance(customerID string) (*entities.Balance, error) {
+	entries, err := uc.LedgerRepository.SearchLedgerEntriesByCustomerID(customerID)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeBalance(*entries), nil
+}
+
+func (uc *PaymentUsecase) ParkingUsageBalance(parkingUsageID int) (*entities.Balance, error) {
+	entries, err := uc.LedgerRepository.SearchLedgerEntriesByParkingUsageID(parkingUsageID)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeBalance(*entries), nil
+}
+
+func (uc *PaymentUsecase) OutstandingBalance(customerID *string, parkingUsageID int) (float64, error) {
+	var balance *entities.Balance
+	var err error
+	if customerID != nil {
+		balance, err = uc.CustomerBalance(*customerID)
+	} else {
+		balance, err = uc.ParkingUsageBalance(parkingUsageID)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return balance.Outstanding, nil
+}
+
+func (uc *PaymentUsecase) ReceiptByParkingUsageID(parkingUsageID int) (*entities.Receipt, error) {
+	charges, err := uc.ParkingChargeRepository.SearchParkingChargesByParkingUsageID(parkingUsageID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.LedgerRepository.SearchLedgerEntriesByParkingUsageID(parkingUsageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Receipt{
+		ParkingUsageID: parkingUsageID,
+		Charges:        *charges,
+		Entries:        *entries,
+		Balance:        *ComputeBalance(*entries),
+		IssuedAt:       time.Now(),
+	}, nil
+}
+
+func ComputeBalance(entries entities.LedgerEntries) *entities.Balance {
+	balance := &entities.Balance{}
+	for _, entry := range entries {
+		switch entry.EntryType {
+		case entities.LedgerCharge:
+			balance.TotalCharged += entry.Amount
+		case entities.LedgerPayment:
+			balance.TotalPaid += entry.Amount
+		case entities.LedgerRefund:
+			balance.TotalRefunded += entry.Amount
+		}
+	}
+	balance.Outstanding = balance.TotalCharged - balance.TotalPaid + balance.TotalRefunded
+	return balance
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"time"
+
+	tariffEntities "github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+)
+
+const (
+	LedgerCharge  = "charge"
+	LedgerPayment = "payment"
+	LedgerRefund  = "refund"
+)
+
+type LedgerEntry struct {
+	ID             int       `json:"id"`
+	CustomerID     *string   `json:"customerId"`
+	VisitorRut     string    `json:"visitorRut"`
+	ParkingUsageID *int      `json:"parkingUsageId"`
+	EntryType      string    `json:"entryType"`
+	Amount         float64   `json:"amount"`
+	Currency       string    `json:"currency"`
+	Method         string    `json:"method"`
+	Reference      string    `json:"reference"`
+	Description    string    `json:"description"`
+	RefundOfID     *int      `json:"refundOfId,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type LedgerEntries []LedgerEntry
+
+type PaymentRequest struct {
+	ParkingUsageID *int    `json:"parkingUsageId"`
+	CustomerID     *string `json:"customerId"`
+	VisitorRut     string  `json:"visitorRut"`
+	Amount         float64 `json:"amount"`
+	Currency       string  `json:"currency"`
+	Method         string  `json:"method"`
+}
+
+type RefundRequest struct {
+	Amount float64 `json:"amount"`
+	Reason string  `json:"reason"`
+}
+
+type GatewayResult struct {
+	Approved  bool   `json:"approved"`
+	Reference string `json:"reference"`
+	Message   string `json:"message"`
+}
+
+type Balance struct {
+	TotalCharged  float64 `json:"totalCharged"`
+	TotalPaid     float64 `json:"totalPaid"`
+	TotalRefunded float64 `json:"totalRefunded"`
+	Outstanding   float64 `json:"outstanding"`
+}
+
+type Receipt struct {
+	ParkingUsageID int                           `json:"parkingUsageId"`
+	Charges        tariffEntities.ParkingCharges `json:"charges"`
+	Entries        LedgerEntries                 `json:"entries"`
+	Balance        Balance                       `json:"balance"`
+	IssuedAt       time.Time                     `json:"issuedAt"`
+}
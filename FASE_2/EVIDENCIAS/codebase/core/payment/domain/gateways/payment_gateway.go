@@ -0,0 +1,8 @@
+package gateways
+
+import "github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+
+type PaymentGateway interface {
+	Charge(request *entities.PaymentRequest) (*entities.GatewayResult, error)
+	Refund(reference string, amount float64, currency string) (*entities.GatewayResult, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+
+type LedgerRepository interface {
+	SearchLedgerEntryByID(id int) (*entities.LedgerEntry, error)
+	SearchLedgerEntriesByCustomerID(customerID string) (*entities.LedgerEntries, error)
+	SearchLedgerEntriesByVisitorRut(visitorRut string) (*entities.LedgerEntries, error)
+	SearchLedgerEntriesByParkingUsageID(parkingUsageID int) (*entities.LedgerEntries, error)
+	CreateLedgerEntry(entry *entities.LedgerEntry) error
+	ReserveRefund(paymentID int, entry *entities.LedgerEntry) (bool, error)
+	ConfirmRefund(id int, reference string) error
+	DeleteLedgerEntryByID(id int) error
+}
@@ -0,0 +1,51 @@
+package gateways
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+)
+
+type LocalPaymentGateway struct{}
+
+func NewLocalPaymentGateway() *LocalPaymentGateway {
+	return &LocalPaymentGateway{}
+}
+
+func (g *LocalPaymentGateway) Charge(request *entities.PaymentRequest) (*entities.GatewayResult, error) {
+	if request.Amount <= 0 {
+		return &entities.GatewayResult{
+			Approved: false,
+			Message:  "Monto inválido",
+		}, nil
+	}
+
+	reference := fmt.Sprintf("local-pay-%d", time.Now().UnixNano())
+	log.Printf("Pago local aprobado por %.0f %s (%s)", request.Amount, request.Currency, reference)
+
+	return &entities.GatewayResult{
+		Approved:  true,
+		Reference: reference,
+		Message:   "Pago aprobado",
+	}, nil
+}
+
+func (g *LocalPaymentGateway) Refund(reference string, amount float64, currency string) (*entities.GatewayResult, error) {
+	if amount <= 0 {
+		return &entities.GatewayResult{
+			Approved: false,
+			Message:  "Monto inválido",
+		}, nil
+	}
+
+	refundReference := fmt.Sprintf("local-refund-%d", time.Now().UnixNano())
+	log.Printf("Reembolso local aprobado por %.0f %s sobre %s (%s)", amount, currency, reference, refundReference)
+
+	return &entities.GatewayResult{
+		Approved:  true,
+		Reference: refundReference,
+		Message:   "Reembolso aprobado",
+	}, nil
+}
@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleLedgerRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleLedgerRepository(pool *pgxpool.Pool) *TimescaleLedgerRepository {
+	return &TimescaleLedgerRepository{
+		dbPool: pool,
+	}
+}
+
+const ledgerColumns = `id, customer_id, visitor_rut, parking_usage_id, entry_type, amount, currency,
+              method, reference, description, refund_of_id, created_at`
+
+func (r *TimescaleLedgerRepository) SearchLedgerEntryByID(id int) (*entities.LedgerEntry, error) {
+	ctx := context.Background()
+	query := `SELECT ` + ledgerColumns + ` FROM payment_ledger WHERE id = $1`
+	var e entities.LedgerEntry
+	err := r.dbPool.QueryRow(ctx, query, id).Scan(&e.ID, &e.CustomerID, &e.VisitorRut, &e.ParkingUsageID, &e.EntryType,
+		&e.Amount, &e.Currency, &e.Method, &e.Reference, &e.Description, &e.RefundOfID, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *TimescaleLedgerRepository) SearchLedgerEntriesByCustomerID(customerID string) (*entities.LedgerEntries, error) {
+	query := `SELECT ` + ledgerColumns + ` FROM payment_ledger WHERE customer_id = $1 ORDER BY created_at`
+	return r.searchLedgerEntries(query, customerID)
+}
+
+func (r *TimescaleLedgerRepository) SearchLedgerEntriesByVisitorRut(visitorRut string) (*entities.LedgerEntries, error) {
+	query := `SELECT ` + ledgerColumns + ` FROM payment_ledger WHERE visitor_rut = $1 ORDER BY created_at`
+	return r.searchLedgerEntries(query, visitorRut)
+}
+
+func (r *TimescaleLedgerRepository) SearchLedgerEntriesByParkingUsageID(parkingUsageID int) (*entities.LedgerEntries, error) {
+	query := `SELECT ` + ledgerColumns + ` FROM payment_ledger WHERE parking_usage_id = $1 ORDER BY created_at`
+	return r.searchLedgerEntries(query, parkingUsageID)
+}
+
+func (r *TimescaleLedgerRepository) searchLedgerEntries(query string, args ...interface{}) (*entities.LedgerEntries, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := entities.LedgerEntries{}
+	for rows.Next() {
+		var e entities.LedgerEntry
+		if err := rows.Scan(&e.ID, &e.CustomerID, &e.VisitorRut, &e.ParkingUsageID, &e.EntryType,
+			&e.Amount, &e.Currency, &e.Method, &e.Reference, &e.Description, &e.RefundOfID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &entries, nil
+}
+
+func (r *TimescaleLedgerRepository) CreateLedgerEntry(entry *entities.LedgerEntry) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO payment_ledger (
+		customer_id, visitor_rut, parking_usage_id, entry_type, amount, currency,
+		method, reference, description, refund_of_id, created_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+	) RETURNING id;
+`
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	return r.dbPool.QueryRow(ctx, query,
+		entry.CustomerID, entry.VisitorRut, entry.ParkingUsageID, entry.EntryType, entry.Amount,
+		entry.Currency, entry.Method, entry.Reference, entry.Description, entry.RefundOfID, entry.CreatedAt,
+	).Scan(&entry.ID)
+}
+
+// Bloquea el pago y registra el reembolso solo si la suma de reembolsos no supera lo pagado;
+// devuelve false sin escribir nada cuando el monto excede el saldo reembolsable
+func (r *TimescaleLedgerRepository) ReserveRefund(paymentID int, entry *entities.LedgerEntry) (bool, error) {
+	ctx := context.Background()
+	tx, err := r.dbPool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var paid float64
+	if err := tx.QueryRow(ctx, `SELECT amount FROM payment_ledger WHERE id = $1 FOR UPDATE`, paymentID).Scan(&paid); err != nil {
+		return false, err
+	}
+	var refunded float64
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(SUM(amount), 0) FROM payment_ledger WHERE refund_of_id = $1`,
+		paymentID).Scan(&refunded); err != nil {
+		return false, err
+	}
+	if refunded+entry.Amount > paid {
+		return false, nil
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.RefundOfID = &paymentID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO payment_ledger (
+		customer_id, visitor_rut, parking_usage_id, entry_type, amount, currency,
+		method, reference, description, refund_of_id, created_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+	) RETURNING id`,
+		entry.CustomerID, entry.VisitorRut, entry.ParkingUsageID, entry.EntryType, entry.Amount,
+		entry.Currency, entry.Method, entry.Reference, entry.Description, entry.RefundOfID, entry.CreatedAt,
+	).Scan(&entry.ID)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+func (r *TimescaleLedgerRepository) ConfirmRefund(id int, reference string) error {
+	ctx := context.Background()
+	_, err := r.dbPool.Exec(ctx, `UPDATE payment_ledger SET reference = $2 WHERE id = $1`, id, reference)
+	return err
+}
+
+func (r *TimescaleLedgerRepository) DeleteLedgerEntryByID(id int) error {
+	ctx := context.Background()
+	_, err := r.dbPool.Exec(ctx, `DELETE FROM payment_ledger WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	parkingUsageRepositories "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/payment/application"
+	"github.com/gonzalohonorato/servercorego/core/payment/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/payment/domain/gateways"
+	"github.com/gonzalohonorato/servercorego/core/payment/domain/repositories"
+	tariffRepositories "github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+type PaymentController struct {
+	PaymentUsecase *application.PaymentUsecase
+}
+
+func NewPaymentController(
+	ledgerRepository repositories.LedgerRepository,
+	parkingChargeRepository tariffRepositories.ParkingChargeRepository,
+	paymentGateway gateways.PaymentGateway,
+	parkingUsageRepository parkingUsageRepositories.ParkingUsageRepository,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+) *PaymentController {
+	paymentUseCase := application.NewPaymentUsecase(ledgerRepository, parkingChargeRepository, paymentGateway,
+		parkingUsageRepository, vehicleRepository)
+
+	return &PaymentController{
+		PaymentUsecase: paymentUseCase,
+	}
+}
+
+func (uc *PaymentController) PostPayment(w http.ResponseWriter, r *http.Request) {
+	var request entities.PaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := uc.PaymentUsecase.Pay(&request)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, application.ErrPaymentDeclined) {
+			status = http.StatusPaymentRequired
+		} else if errors.Is(err, application.ErrUsageNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, "Error processing payment: "+err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (uc *PaymentController) PostRefund(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request entities.RefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := uc.PaymentUsecase.Refund(idInt, &request)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, application.ErrRefundExceeded) {
+			status = http.StatusConflict
+		} else if errors.Is(err, application.ErrPaymentDeclined) {
+			status = http.StatusPaymentRequired
+		}
+		http.Error(w, "Error processing refund: "+err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (uc *PaymentController) GetLedgerByCustomerID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entries, err := uc.PaymentUsecase.SearchLedgerEntriesByCustomerID(vars["customerID"])
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Ledger entries not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"entries": entries,
+		"balance": application.ComputeBalance(*entries),
+	})
+}
+
+func (uc *PaymentController) GetLedgerByVisitorRut(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entries, err := uc.PaymentUsecase.SearchLedgerEntriesByVisitorRut(vars["rut"])
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidRut) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Println(err)
+		http.Error(w, "Ledger entries not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"entries": entries,
+		"balance": application.ComputeBalance(*entries),
+	})
+}
+
+func (uc *PaymentController) GetReceiptByParkingUsageID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	receipt, err := uc.PaymentUsecase.ReceiptByParkingUsageID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Receipt not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(receipt)
+}
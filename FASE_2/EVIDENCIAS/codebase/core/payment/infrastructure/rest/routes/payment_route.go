@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/payment/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func PaymentRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewPaymentController(
+		container.ProvideLedgerRepository(),
+		container.ProvideParkingChargeRepository(),
+		container.ProvidePaymentGateway(),
+		container.ProvideParkingUsageRepository(),
+		container.ProvideVehicleRepository(),
+	)
+
+	router.HandleFunc("/payments", controller.PostPayment).Methods("POST")
+	router.HandleFunc("/payments/{id}/refund", controller.PostRefund).Methods("POST")
+	router.HandleFunc("/ledger/customer/{customerID}", controller.GetLedgerByCustomerID).Methods("GET")
+	router.HandleFunc("/ledger/visitor/{rut}", controller.GetLedgerByVisitorRut).Methods("GET")
+	router.HandleFunc("/receipts/usage/{id}", controller.GetReceiptByParkingUsageID).Methods("GET")
+}
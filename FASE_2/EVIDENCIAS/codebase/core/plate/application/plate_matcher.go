@@ -0,0 +1,103 @@
+package application
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+)
+
+const (
+	confusionCost    = 0.25
+	editCost         = 1.0
+	CandidateMaxCost = 2.0
+)
+
+// Reduce la patente a una clave en la que los caracteres que el OCR confunde (O/0, I/1, B/8...) son iguales,
+// así dos patentes que solo difieren en confusiones comparten clave
+func PlateKey(raw string) string {
+	var b strings.Builder
+	for _, r := range CleanPlate(raw) {
+		if digit, ok := letterToDigit[r]; ok {
+			r = digit
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func PlateDistance(a, b string) float64 {
+	x := []rune(CleanPlate(a))
+	y := []rune(CleanPlate(b))
+
+	previous := make([]float64, len(y)+1)
+	current := make([]float64, len(y)+1)
+	for j := range previous {
+		previous[j] = float64(j) * editCost
+	}
+
+	for i := 1; i <= len(x); i++ {
+		current[0] = float64(i) * editCost
+		for j := 1; j <= len(y); j++ {
+			substitution := previous[j-1] + substitutionCost(x[i-1], y[j-1])
+			deletion := previous[j] + editCost
+			insertion := current[j-1] + editCost
+			current[j] = min(substitution, deletion, insertion)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(y)]
+}
+
+func substitutionCost(a, b rune) float64 {
+	if a == b {
+		return 0
+	}
+	if letterToDigit[a] == b || letterToDigit[b] == a || digitToLetter[a] == b || digitToLetter[b] == a {
+		return confusionCost
+	}
+	return editCost
+}
+
+func RankPlateCandidates(raw string, candidates entities.PlateCandidates, formats entities.PlateFormats) entities.PlateCandidates {
+	clean := CleanPlate(raw)
+	normalized := NormalizePlate(raw, formats)
+
+	ranked := entities.PlateCandidates{}
+	for _, candidate := range candidates {
+		cost := min(PlateDistance(clean, candidate.Plate), PlateDistance(normalized, candidate.Plate))
+		if cost > CandidateMaxCost {
+			continue
+		}
+		candidate.Cost = cost
+		ranked = append(ranked, candidate)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Cost == ranked[j].Cost {
+			return ranked[i].Plate < ranked[j].Plate
+		}
+		return ranked[i].Cost < ranked[j].Cost
+	})
+
+	return ranked
+}
+
+// Solo se acepta sin confirmación una coincidencia exacta única, o un candidato único que difiere de la lectura
+// únicamente en confusiones del OCR; el resto queda para revisión del guardia
+func SelectPlateCandidate(raw string, ranked entities.PlateCandidates) (*entities.PlateCandidate, bool) {
+	if len(ranked) == 0 {
+		return nil, false
+	}
+
+	best := ranked[0]
+	if best.Cost == 0 && (len(ranked) == 1 || ranked[1].Cost > 0) {
+		return &best, false
+	}
+	if len(ranked) == 1 && PlateKey(best.Plate) == PlateKey(raw) {
+		return &best, false
+	}
+
+	return nil, true
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+)
+
+func TestPlateKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{a: "BCDF10", b: "8CDF1O", same: true},
+		{a: "ab-12-34", b: "AB1234", same: true},
+		{a: "GHST12", b: "6H5712", same: true},
+		{a: "BCDF10", b: "BCDF11", same: false},
+		{a: "BCDF10", b: "BCDF1", same: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := PlateKey(tt.a) == PlateKey(tt.b); got != tt.same {
+				t.Errorf("PlateKey(%s)=%s, PlateKey(%s)=%s; misma clave %v, se esperaba %v",
+					tt.a, PlateKey(tt.a), tt.b, PlateKey(tt.b), got, tt.same)
+			}
+		})
+	}
+}
+
+func TestSelectPlateCandidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		candidates    []string
+		wantPlate     string
+		wantAmbiguous bool
+	}{
+		{name: "sin candidatos", raw: "BCDF10"},
+		{name: "coincidencia exacta única", raw: "BCDF10", candidates: []string{"BCDF10", "BCDF1O"}, wantPlate: "BCDF10"},
+		{name: "dos coincidencias exactas", raw: "BCDF10", candidates: []string{"BCDF10", "BCDF10"}, wantAmbiguous: true},
+		{name: "candidato único con confusiones", raw: "8CDF1O", candidates: []string{"BCDF10"}, wantPlate: "BCDF10"},
+		{name: "exacta tras normalizar gana a confusión", raw: "BCDF1O", candidates: []string{"BCDF10", "8CDF10"}, wantPlate: "BCDF10"},
+		{name: "candidato único con edición real", raw: "BCDF19", candidates: []string{"BCDF10"}, wantAmbiguous: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := entities.PlateCandidates{}
+			for i, plate := range tt.candidates {
+				candidates = append(candidates, entities.PlateCandidate{VehicleID: i + 1, Plate: plate})
+			}
+			ranked := RankPlateCandidates(tt.raw, candidates, DefaultPlateFormats)
+
+			selected, ambiguous := SelectPlateCandidate(tt.raw, ranked)
+			if ambiguous != tt.wantAmbiguous {
+				t.Fatalf("ambigua = %v, se esperaba %v (candidatos %+v)", ambiguous, tt.wantAmbiguous, ranked)
+			}
+			if tt.wantPlate == "" {
+				if selected != nil {
+					t.Errorf("se seleccionó %s, no se esperaba selección", selected.Plate)
+				}
+				return
+			}
+			if selected == nil || selected.Plate != tt.wantPlate {
+				t.Errorf("seleccionada %+v, se esperaba %s", selected, tt.wantPlate)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+)
+
+var DefaultPlateFormats = entities.PlateFormats{
+	{Code: "CL_LLLLNN", Pattern: "LLLLNN", Country: "CL", VehicleType: "car", Priority: 10, IsActive: true},
+	{Code: "CL_LLNNNN", Pattern: "LLNNNN", Country: "CL", VehicleType: "car", Priority: 20, IsActive: true},
+	{Code: "CL_LLLNN", Pattern: "LLLNN", Country: "CL", VehicleType: "motorcycle", Priority: 30, IsActive: true},
+	{Code: "CL_LLNNN", Pattern: "LLNNN", Country: "CL", VehicleType: "motorcycle", Priority: 40, IsActive: true},
+	{Code: "CL_LLLNNN", Pattern: "LLLNNN", Country: "CL", VehicleType: "trailer", Priority: 50, IsActive: true},
+	{Code: "AR_LLNNNLL", Pattern: "LLNNNLL", Country: "AR", VehicleType: "car", Priority: 60, IsActive: true},
+	{Code: "AR_LNNNLLL", Pattern: "LNNNLLL", Country: "AR", VehicleType: "motorcycle", Priority: 70, IsActive: true},
+	{Code: "PE_LALNNN", Pattern: "LALNNN", Country: "PE", VehicleType: "car", Priority: 80, IsActive: true},
+}
+
+var ErrInvalidPlateFormat = errors.New("la patente no coincide con ningún formato registrado")
+
+var digitToLetter = map[rune]rune{
+	'0': 'O', '1': 'I', '2': 'Z', '4': 'A', '5': 'S', '6': 'G', '7': 'T', '8': 'B',
+}
+
+var letterToDigit = map[rune]rune{
+	'O': '0', 'Q': '0', 'D': '0', 'I': '1', 'L': '1', 'Z': '2',
+	'A': '4', 'S': '5', 'G': '6', 'T': '7', 'B': '8',
+}
+
+func CleanPlate(raw string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(raw) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func NormalizePlate(raw string, formats entities.PlateFormats) string {
+	canonical, _ := MatchPlateFormat(raw, formats)
+	return canonical
+}
+
+func MatchPlateFormat(raw string, formats entities.PlateFormats) (string, *entities.PlateFormat) {
+	clean := CleanPlate(raw)
+
+	var bestFormat *entities.PlateFormat
+	bestPlate := clean
+	bestSubstitutions := -1
+
+	for i := range formats {
+		coerced, substitutions, ok := coercePlate(clean, formats[i].Pattern)
+		if !ok {
+			continue
+		}
+		if bestSubstitutions == -1 || substitutions < bestSubstitutions {
+			bestFormat = &formats[i]
+			bestPlate = coerced
+			bestSubstitutions = substitutions
+		}
+	}
+
+	return bestPlate, bestFormat
+}
+
+func FindExactPlateFormat(raw string, formats entities.PlateFormats) *entities.PlateFormat {
+	clean := CleanPlate(raw)
+	for i := range formats {
+		if _, substitutions, ok := coercePlate(clean, formats[i].Pattern); ok && substitutions == 0 {
+			return &formats[i]
+		}
+	}
+	return nil
+}
+
+func IsValidPlate(raw string, formats entities.PlateFormats) bool {
+	return FindExactPlateFormat(raw, formats) != nil
+}
+
+func IsValidPlatePattern(pattern string) bool {
+	if pattern == "" {
+		return false
+	}
+	for _, slot := range pattern {
+		if slot != 'L' && slot != 'N' && slot != 'A' {
+			return false
+		}
+	}
+	return true
+}
+
+func coercePlate(clean, pattern string) (string, int, bool) {
+	chars := []rune(clean)
+	slots := []rune(pattern)
+	if len(chars) != len(slots) {
+		return "", 0, false
+	}
+
+	substitutions := 0
+	for i, slot := range slots {
+		c := chars[i]
+		switch slot {
+		case 'L':
+			if isLetter(c) {
+				continue
+			}
+			letter, ok := digitToLetter[c]
+			if !ok {
+				return "", 0, false
+			}
+			chars[i] = letter
+			substitutions++
+		case 'N':
+			if isDigit(c) {
+				continue
+			}
+			digit, ok := letterToDigit[c]
+			if !ok {
+				return "", 0, false
+			}
+			chars[i] = digit
+			substitutions++
+		case 'A':
+			continue
+		default:
+			if c != slot {
+				return "", 0, false
+			}
+		}
+	}
+
+	return string(chars), substitutions, true
+}
+
+func isLetter(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
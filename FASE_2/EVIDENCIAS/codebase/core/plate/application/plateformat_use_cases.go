@@ -0,0 +1,94 @@
+package application
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+)
+
+type PlateFormatUsecase struct {
+	PlateFormatRepository repositories.PlateFormatRepository
+}
+
+func NewPlateFormatUsecase(repo repositories.PlateFormatRepository) *PlateFormatUsecase {
+	return &PlateFormatUsecase{PlateFormatRepository: repo}
+}
+
+func (uc *PlateFormatUsecase) SearchPlateFormatByID(id int) (*entities.PlateFormat, error) {
+	return uc.PlateFormatRepository.SearchPlateFormatByID(id)
+}
+
+func (uc *PlateFormatUsecase) SearchPlateFormats() (*entities.PlateFormats, error) {
+	return uc.PlateFormatRepository.SearchPlateFormats()
+}
+
+func (uc *PlateFormatUsecase) CreatePlateFormat(format *entities.PlateFormat) error {
+	if err := validatePlateFormat(format); err != nil {
+		return err
+	}
+	return uc.PlateFormatRepository.CreatePlateFormat(format)
+}
+
+func (uc *PlateFormatUsecase) UpdatePlateFormatByID(format *entities.PlateFormat) error {
+	if err := validatePlateFormat(format); err != nil {
+		return err
+	}
+	return uc.PlateFormatRepository.UpdatePlateFormatByID(format)
+}
+
+func (uc *PlateFormatUsecase) DeletePlateFormatByID(id int) error {
+	return uc.PlateFormatRepository.DeletePlateFormatByID(id)
+}
+
+func (uc *PlateFormatUsecase) ActivePlateFormats() entities.PlateFormats {
+	if uc == nil || uc.PlateFormatRepository == nil {
+		return DefaultPlateFormats
+	}
+
+	formats, err := uc.PlateFormatRepository.SearchActivePlateFormats()
+	if err != nil {
+		log.Printf("Error al obtener formatos de patente, usando formatos por defecto: %v", err)
+		return DefaultPlateFormats
+	}
+
+	if formats == nil || len(*formats) == 0 {
+		return DefaultPlateFormats
+	}
+
+	return *formats
+}
+
+func (uc *PlateFormatUsecase) NormalizePlate(raw string) string {
+	return NormalizePlate(raw, uc.ActivePlateFormats())
+}
+
+func (uc *PlateFormatUsecase) DetectPlateFormat(raw string) (string, *entities.PlateFormat) {
+	return MatchPlateFormat(raw, uc.ActivePlateFormats())
+}
+
+// Para registrar una patente se exige coincidencia exacta; la corrección de confusiones (O por 0, I por 1)
+// queda reservada a las lecturas OCR
+func (uc *PlateFormatUsecase) ValidatePlate(raw string) (string, *entities.PlateFormat, error) {
+	formats := uc.ActivePlateFormats()
+	plate := CleanPlate(raw)
+	if format := FindExactPlateFormat(plate, formats); format != nil {
+		return plate, format, nil
+	}
+
+	if suggestion, format := MatchPlateFormat(plate, formats); format != nil {
+		return plate, nil, fmt.Errorf("%w: %s (¿quiso decir %s?)", ErrInvalidPlateFormat, raw, suggestion)
+	}
+	return plate, nil, fmt.Errorf("%w: %s", ErrInvalidPlateFormat, raw)
+}
+
+func validatePlateFormat(format *entities.PlateFormat) error {
+	if format.Code == "" || format.Country == "" || format.VehicleType == "" {
+		return fmt.Errorf("código, país y tipo de vehículo son requeridos")
+	}
+	if !IsValidPlatePattern(format.Pattern) {
+		return fmt.Errorf("patrón inválido %q: solo se permiten L (letra), N (dígito) y A (alfanumérico)", format.Pattern)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidatePlateRequiresExactMatch(t *testing.T) {
+	tests := []struct {
+		raw        string
+		wantPlate  string
+		wantFormat string
+	}{
+		{raw: "BCDF10", wantPlate: "BCDF10", wantFormat: "CL_LLLLNN"},
+		{raw: "bc-df-10", wantPlate: "BCDF10", wantFormat: "CL_LLLLNN"},
+		{raw: "AB1234", wantPlate: "AB1234", wantFormat: "CL_LLNNNN"},
+		{raw: "BCDF1O", wantPlate: "BCDF1O"},
+		{raw: "8CDF10", wantPlate: "8CDF10"},
+		{raw: "A81234", wantPlate: "A81234"},
+		{raw: "XYZ", wantPlate: "XYZ"},
+	}
+
+	var uc *PlateFormatUsecase
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			plate, format, err := uc.ValidatePlate(tt.raw)
+			if plate != tt.wantPlate {
+				t.Errorf("patente = %s, se esperaba %s", plate, tt.wantPlate)
+			}
+			if tt.wantFormat == "" {
+				if !errors.Is(err, ErrInvalidPlateFormat) {
+					t.Errorf("se esperaba ErrInvalidPlateFormat, se obtuvo %v (formato %v)", err, format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if format.Code != tt.wantFormat {
+				t.Errorf("formato = %s, se esperaba %s", format.Code, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestMatchPlateFormatStillCoercesOCRReads(t *testing.T) {
+	plate, format := MatchPlateFormat("BCDF1O", DefaultPlateFormats)
+	if format == nil || plate != "BCDF10" {
+		t.Errorf("MatchPlateFormat(BCDF1O) = %s, %v; se esperaba BCDF10 con formato", plate, format)
+	}
+}
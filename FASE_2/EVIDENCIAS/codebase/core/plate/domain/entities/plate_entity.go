@@ -0,0 +1,23 @@
+package entities
+
+type PlateFormat struct {
+	ID          int    `json:"id"`
+	Code        string `json:"code"`
+	Pattern     string `json:"pattern"`
+	Country     string `json:"country"`
+	VehicleType string `json:"vehicleType"`
+	Description string `json:"description"`
+	Priority    int    `json:"priority"`
+	IsActive    bool   `json:"isActive"`
+}
+
+type PlateFormats []PlateFormat
+
+type PlateCandidate struct {
+	VehicleID      int     `json:"vehicleId,omitempty"`
+	ParkingUsageID int     `json:"parkingUsageId,omitempty"`
+	Plate          string  `json:"plate"`
+	Cost           float64 `json:"cost"`
+}
+
+type PlateCandidates []PlateCandidate
@@ -0,0 +1,12 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+
+type PlateFormatRepository interface {
+	SearchPlateFormatByID(id int) (*entities.PlateFormat, error)
+	SearchPlateFormats() (*entities.PlateFormats, error)
+	SearchActivePlateFormats() (*entities.PlateFormats, error)
+	CreatePlateFormat(format *entities.PlateFormat) error
+	UpdatePlateFormatByID(format *entities.PlateFormat) error
+	DeletePlateFormatByID(id int) error
+}
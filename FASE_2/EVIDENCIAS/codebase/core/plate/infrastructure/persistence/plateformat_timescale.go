@@ -0,0 +1,91 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescalePlateFormatRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescalePlateFormatRepository(pool *pgxpool.Pool) *TimescalePlateFormatRepository {
+	return &TimescalePlateFormatRepository{
+		dbPool: pool,
+	}
+}
+
+func (r *TimescalePlateFormatRepository) SearchPlateFormatByID(id int) (*entities.PlateFormat, error) {
+	ctx := context.Background()
+	query := `SELECT id, code, pattern, country, vehicle_type, description, priority, is_active FROM plate_format WHERE id = $1`
+	row := r.dbPool.QueryRow(ctx, query, id)
+	var f entities.PlateFormat
+	err := row.Scan(&f.ID, &f.Code, &f.Pattern, &f.Country, &f.VehicleType, &f.Description, &f.Priority, &f.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *TimescalePlateFormatRepository) SearchPlateFormats() (*entities.PlateFormats, error) {
+	ctx := context.Background()
+	query := `SELECT id, code, pattern, country, vehicle_type, description, priority, is_active FROM plate_format ORDER BY priority, id`
+	return r.searchPlateFormats(ctx, query)
+}
+
+func (r *TimescalePlateFormatRepository) SearchActivePlateFormats() (*entities.PlateFormats, error) {
+	ctx := context.Background()
+	query := `SELECT id, code, pattern, country, vehicle_type, description, priority, is_active FROM plate_format WHERE is_active = TRUE ORDER BY priority, id`
+	return r.searchPlateFormats(ctx, query)
+}
+
+func (r *TimescalePlateFormatRepository) searchPlateFormats(ctx context.Context, query string) (*entities.PlateFormats, error) {
+	rows, err := r.dbPool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	formats := entities.PlateFormats{}
+	for rows.Next() {
+		var f entities.PlateFormat
+		if err := rows.Scan(&f.ID, &f.Code, &f.Pattern, &f.Country, &f.VehicleType, &f.Description, &f.Priority, &f.IsActive); err != nil {
+			return nil, err
+		}
+		formats = append(formats, f)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &formats, nil
+}
+
+func (r *TimescalePlateFormatRepository) CreatePlateFormat(format *entities.PlateFormat) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO plate_format (
+		code, pattern, country, vehicle_type, description, priority, is_active
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query, format.Code, format.Pattern, format.Country, format.VehicleType,
+		format.Description, format.Priority, format.IsActive).Scan(&format.ID)
+}
+
+func (r *TimescalePlateFormatRepository) UpdatePlateFormatByID(f *entities.PlateFormat) error {
+	ctx := context.Background()
+	query := `UPDATE plate_format SET code = $2, pattern = $3, country = $4, vehicle_type = $5, description = $6, priority = $7, is_active = $8 WHERE id = $1`
+
+	_, err := r.dbPool.Exec(ctx, query, f.ID, f.Code, f.Pattern, f.Country, f.VehicleType, f.Description, f.Priority, f.IsActive)
+	return err
+}
+
+func (r *TimescalePlateFormatRepository) DeletePlateFormatByID(id int) error {
+	ctx := context.Background()
+	query := `DELETE FROM plate_format WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, id)
+	return err
+}
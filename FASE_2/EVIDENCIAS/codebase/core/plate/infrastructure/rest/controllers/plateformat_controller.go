@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gonzalohonorato/servercorego/core/plate/application"
+	"github.com/gonzalohonorato/servercorego/core/plate/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+type PlateFormatController struct {
+	PlateFormatUsecase *application.PlateFormatUsecase
+}
+
+func NewPlateFormatController(plateFormatRepository repositories.PlateFormatRepository) *PlateFormatController {
+	plateFormatUseCase := application.NewPlateFormatUsecase(plateFormatRepository)
+
+	return &PlateFormatController{
+		PlateFormatUsecase: plateFormatUseCase,
+	}
+}
+
+func (uc *PlateFormatController) GetPlateFormatByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	formatID := vars["id"]
+	idInt, err := strconv.Atoi(formatID)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	format, err := uc.PlateFormatUsecase.SearchPlateFormatByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "PlateFormat not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(format)
+}
+
+func (uc *PlateFormatController) GetPlateFormats(w http.ResponseWriter, r *http.Request) {
+	formats, err := uc.PlateFormatUsecase.SearchPlateFormats()
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "PlateFormats not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(formats)
+}
+
+func (uc *PlateFormatController) PostPlateFormat(w http.ResponseWriter, r *http.Request) {
+	var newFormat entities.PlateFormat
+	if err := json.NewDecoder(r.Body).Decode(&newFormat); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.PlateFormatUsecase.CreatePlateFormat(&newFormat); err != nil {
+		http.Error(w, "Error creating plate format: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newFormat)
+}
+
+func (uc *PlateFormatController) PutPlateFormat(w http.ResponseWriter, r *http.Request) {
+	var format entities.PlateFormat
+	if err := json.NewDecoder(r.Body).Decode(&format); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.PlateFormatUsecase.UpdatePlateFormatByID(&format); err != nil {
+		http.Error(w, "Error update plate format: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *PlateFormatController) DeletePlateFormatByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	formatID := vars["id"]
+	idInt, err := strconv.Atoi(formatID)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	err = uc.PlateFormatUsecase.DeletePlateFormatByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "PlateFormat not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+}
+
+func (uc *PlateFormatController) GetPlateValidation(w http.ResponseWriter, r *http.Request) {
+	plate := r.URL.Query().Get("plate")
+	if plate == "" {
+		http.Error(w, "plate query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	normalized, format, err := uc.PlateFormatUsecase.ValidatePlate(plate)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"plate":  normalized,
+		"valid":  err == nil,
+		"format": format,
+	})
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/plate/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func PlateFormatRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewPlateFormatController(container.ProvidePlateFormatRepository())
+	router.HandleFunc("/plate-formats", controller.PutPlateFormat).Methods("PUT")
+	router.HandleFunc("/plate-formats", controller.GetPlateFormats).Methods("GET")
+	router.HandleFunc("/plate-formats/validate", controller.GetPlateValidation).Methods("GET")
+	router.HandleFunc("/plate-formats/{id}", controller.DeletePlateFormatByID).Methods("DELETE")
+	router.HandleFunc("/plate-formats/{id}", controller.GetPlateFormatByID).Methods("GET")
+	router.HandleFunc("/plate-formats", controller.PostPlateFormat).Methods("POST")
+}
@@ -0,0 +1,30 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuotaPeriod(t *testing.T) {
+	tests := []struct {
+		at   string
+		want string
+	}{
+		{at: "2024-05-31T22:00:00-04:00", want: "2024-05"},
+		{at: "2024-06-01T02:00:00Z", want: "2024-05"},
+		{at: "2024-06-01T00:00:00-04:00", want: "2024-06"},
+		{at: "2024-12-31T23:30:00-03:00", want: "2024-12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.at, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, tt.at)
+			if err != nil {
+				t.Fatalf("fecha de prueba inválida: %v", err)
+			}
+			if got := QuotaPeriod(at); got != tt.want {
+				t.Errorf("QuotaPeriod(%s) = %s, se esperaba %s", tt.at, got, tt.want)
+			}
+		})
+	}
+}
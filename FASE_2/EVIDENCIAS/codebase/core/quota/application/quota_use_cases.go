@@ -0,0 +1,424 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/quota/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+)
+
+var ErrQuotaExhausted = errors.New("cuota mensual agotada")
+
+type QuotaUsecase struct {
+	QuotaPolicyRepository   repositories.QuotaPolicyRepository
+	QuotaMovementRepository repositories.QuotaMovementRepository
+	UserRepository          userRepositories.UserRepository
+}
+
+func NewQuotaUsecase(
+	policyRepo repositories.QuotaPolicyRepository,
+	movementRepo repositories.QuotaMovementRepository,
+	userRepo userRepositories.UserRepository,
+) *QuotaUsecase {
+	return &QuotaUsecase{
+		QuotaPolicyRepository:   policyRepo,
+		QuotaMovementRepository: movementRepo,
+		UserRepository:          userRepo,
+	}
+}
+
+func (uc *QuotaUsecase) SearchQuotaPolicyByID(id int) (*entities.QuotaPolicy, error) {
+	return uc.QuotaPolicyRepository.SearchQuotaPolicyByID(id)
+}
+
+func (uc *QuotaUsecase) SearchQuotaPolicies() (*entities.QuotaPolicies, error) {
+	return uc.QuotaPolicyRepository.SearchQuotaPolicies()
+}
+
+func (uc *QuotaUsecase) CreateQuotaPolicy(policy *entities.QuotaPolicy) error {
+	if err := validateQuotaPolicy(policy); err != nil {
+		return err
+	}
+	return uc.QuotaPolicyRepository.CreateQuotaPolicy(policy)
+}
+
+func (uc *QuotaUsecase) UpdateQuotaPolicyByID(policy *entities.QuotaPolicy) error {
+	if err := validateQuotaPolicy(policy); err != nil {
+		return err
+	}
+	return uc.QuotaPolicyRepository.UpdateQuotaPolicyByID(policy)
+}
+
+func (uc *QuotaUsecase) DeleteQuotaPolicyByID(id int) error {
+	return uc.QuotaPolicyRepository.DeleteQuotaPolicyByID(id)
+}
+
+func (uc *QuotaUsecase) SearchQuotaMovementsByCustomerID(customerID string) (*entities.QuotaMovements, error) {
+	return uc.QuotaMovementRepository.SearchQuotaMovementsByCustomerID(customerID)
+}
+
+func (uc *QuotaUsecase) Balance(customerID string, at time.Time) (*entities.QuotaBalance, error) {
+	policy, customerType, err := uc.policyForCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	period := QuotaPeriod(at)
+	balance := &entities.QuotaBalance{
+		CustomerID:   customerID,
+		CustomerType: customerType,
+		Period:       period,
+	}
+
+	if policy == nil {
+		balance.Unlimited = true
+		return balance, nil
+	}
+
+	movements, err := uc.QuotaMovementRepository.SearchQuotaMovementsByCustomerIDAndPeriod(customerID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	balance.Unit = policy.Unit
+	balance.Allowance = policy.MonthlyAllowance
+	for _, movement := range *movements {
+		switch movement.MovementType {
+		case entities.QuotaDebit:
+			balance.Used += movement.Amount
+		case entities.QuotaRefund:
+			balance.Used -= movement.Amount
+		case entities.QuotaAdjustment:
+			balance.Allowance += movement.Amount
+		}
+	}
+	balance.Available = balance.Allowance - balance.Used
+
+	return balance, nil
+}
+
+func (uc *QuotaUsecase) DebitReservation(reservation *reservationEntities.Reservation) (*entities.QuotaMovement, error) {
+	policy, _, err := uc.policyForCustomer(reservation.CustomerID)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+
+	reservationID := reservation.ID
+	movement := &entities.QuotaMovement{
+		CustomerID:    reservation.CustomerID,
+		Period:        QuotaPeriod(reservation.StartTime),
+		MovementType:  entities.QuotaDebit,
+		Amount:        reservationCost(policy, reservation),
+		Unit:          policy.Unit,
+		ReservationID: &reservationID,
+		Description:   fmt.Sprintf("Reserva %d", reservation.ID),
+		CreatedAt:     time.Now(),
+	}
+
+	if err := uc.debit(policy, movement); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// El saldo se verifica y descuenta en la misma transacción; CheckReservation solo adelanta el rechazo
+func (uc *QuotaUsecase) debit(policy *entities.QuotaPolicy, movement *entities.QuotaMovement) error {
+	debited, err := uc.QuotaMovementRepository.CreateQuotaDebit(movement, policy.MonthlyAllowance)
+	if err != nil {
+		return err
+	}
+	if debited {
+		return nil
+	}
+
+	available := 0.0
+	if balance, err := uc.Balance(movement.CustomerID, QuotaPeriodStart(movement.Period)); err == nil {
+		available = balance.Available
+	}
+	return fmt.Errorf("%w: disponible %.2f %s, requerido %.2f", ErrQuotaExhausted, available, policy.Unit, movement.Amount)
+}
+
+func (uc *QuotaUsecase) CheckReservation(reservation *reservationEntities.Reservation) error {
+	policy, _, err := uc.policyForCustomer(reservation.CustomerID)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	balance, err := uc.Balance(reservation.CustomerID, reservation.StartTime)
+	if err != nil {
+		return err
+	}
+
+	amount := reservationCost(policy, reservation)
+	if balance.Available < amount {
+		return fmt.Errorf("%w: disponible %.2f %s, requerido %.2f", ErrQuotaExhausted, balance.Available, policy.Unit, amount)
+	}
+	return nil
+}
+
+func (uc *QuotaUsecase) RefundReservation(reservation *reservationEntities.Reservation, at time.Time) (*entities.QuotaMovement, error) {
+	return uc.refundReservation(reservation, &at, fmt.Sprintf("Cancelación de reserva %d", reservation.ID))
+}
+
+// Las cancelaciones que no decide el cliente (por ejemplo, un cierre) devuelven el cupo sin plazo de cancelación
+func (uc *QuotaUsecase) ForceRefundReservation(reservation *reservationEntities.Reservation, description string) (*entities.QuotaMovement, error) {
+	return uc.refundReservation(reservation, nil, description)
+}
+
+// Devuelve lo que el cliente tiene descontado neto en la reserva; se identifica por reserva y cliente para que
+// una reserva que vuelve a su titular tras una transferencia pueda reembolsarse de nuevo
+func (uc *QuotaUsecase) refundReservation(reservation *reservationEntities.Reservation, at *time.Time, description string) (*entities.QuotaMovement, error) {
+	debit, outstanding, err := uc.reservationDebit(reservation.ID, reservation.CustomerID)
+	if err != nil || debit == nil || outstanding <= 0 {
+		return nil, err
+	}
+
+	if at != nil {
+		policy, _, err := uc.policyForCustomer(reservation.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+
+		cutoff := 0
+		if policy != nil {
+			cutoff = policy.RefundCutoffMinutes
+		}
+		if at.After(reservation.StartTime.Add(-time.Duration(cutoff) * time.Minute)) {
+			return nil, nil
+		}
+	}
+
+	reservationID := reservation.ID
+	refund := &entities.QuotaMovement{
+		CustomerID:    debit.CustomerID,
+		Period:        debit.Period,
+		MovementType:  entities.QuotaRefund,
+		Amount:        outstanding,
+		Unit:          debit.Unit,
+		ReservationID: &reservationID,
+		Description:   description,
+		CreatedAt:     time.Now(),
+	}
+
+	if err := uc.QuotaMovementRepository.CreateQuotaMovement(refund); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// Primer descuento del cliente en la reserva y lo que sigue descontado después de sus reembolsos
+func (uc *QuotaUsecase) reservationDebit(reservationID int, customerID string) (*entities.QuotaMovement, float64, error) {
+	movements, err := uc.QuotaMovementRepository.SearchQuotaMovementsByReservationID(reservationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var debit *entities.QuotaMovement
+	outstanding := 0.0
+	for i := range *movements {
+		movement := &(*movements)[i]
+		if movement.CustomerID != customerID {
+			continue
+		}
+		switch movement.MovementType {
+		case entities.QuotaDebit:
+			if debit == nil {
+				debit = movement
+			}
+			outstanding += movement.Amount
+		case entities.QuotaRefund:
+			outstanding -= movement.Amount
+		}
+	}
+	return debit, outstanding, nil
+}
+
+// Al transferir una reserva se descuenta primero al nuevo titular, así un rechazo por cuota no deja al anterior
+// reembolsado; después el titular anterior recupera su cupo sin plazo de cancelación
+func (uc *QuotaUsecase) TransferReservation(reservation *reservationEntities.Reservation, previousCustomerID string) error {
+	debit, debited, err := uc.reservationDebit(reservation.ID, previousCustomerID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.DebitReservation(reservation); err != nil {
+		return err
+	}
+
+	if debit != nil && debited > 0 {
+		reservationID := reservation.ID
+		refund := &entities.QuotaMovement{
+			CustomerID:    previousCustomerID,
+			Period:        debit.Period,
+			MovementType:  entities.QuotaRefund,
+			Amount:        debited,
+			Unit:          debit.Unit,
+			ReservationID: &reservationID,
+			Description:   fmt.Sprintf("Transferencia de reserva %d", reservation.ID),
+			CreatedAt:     time.Now(),
+		}
+		if err := uc.QuotaMovementRepository.CreateQuotaMovement(refund); err != nil {
+			description := fmt.Sprintf("Reversión de transferencia de reserva %d", reservation.ID)
+			if _, undoErr := uc.ForceRefundReservation(reservation, description); undoErr != nil {
+				return fmt.Errorf("%w (no se pudo revertir el descuento: %v)", err, undoErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *QuotaUsecase) CheckExtension(reservation *reservationEntities.Reservation, previousEnd time.Time) error {
+	policy, _, err := uc.policyForCustomer(reservation.CustomerID)
+	if err != nil || policy == nil || policy.Unit != entities.QuotaUnitHours {
+		return err
+	}
+
+	balance, err := uc.Balance(reservation.CustomerID, reservation.StartTime)
+	if err != nil {
+		return err
+	}
+
+	amount := extensionCost(reservation.EndTime, previousEnd)
+	if balance.Available < amount {
+		return fmt.Errorf("%w: disponible %.2f %s, requerido %.2f", ErrQuotaExhausted, balance.Available, policy.Unit, amount)
+	}
+	return nil
+}
+
+func (uc *QuotaUsecase) DebitExtension(reservation *reservationEntities.Reservation, previousEnd time.Time) (*entities.QuotaMovement, error) {
+	policy, _, err := uc.policyForCustomer(reservation.CustomerID)
+	if err != nil || policy == nil || policy.Unit != entities.QuotaUnitHours {
+		return nil, err
+	}
+
+	reservationID := reservation.ID
+	movement := &entities.QuotaMovement{
+		CustomerID:    reservation.CustomerID,
+		Period:        QuotaPeriod(reservation.StartTime),
+		MovementType:  entities.QuotaDebit,
+		Amount:        extensionCost(reservation.EndTime, previousEnd),
+		Unit:          policy.Unit,
+		ReservationID: &reservationID,
+		Description:   fmt.Sprintf("Extensión de reserva %d", reservation.ID),
+		CreatedAt:     time.Now(),
+	}
+
+	if err := uc.debit(policy, movement); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+func (uc *QuotaUsecase) DebitEntry(customerID string, parkingUsageID int, at time.Time) (*entities.QuotaMovement, error) {
+	policy, _, err := uc.policyForCustomer(customerID)
+	if err != nil || policy == nil || policy.Unit != entities.QuotaUnitEntries {
+		return nil, err
+	}
+
+	movement := &entities.QuotaMovement{
+		CustomerID:     customerID,
+		Period:         QuotaPeriod(at),
+		MovementType:   entities.QuotaDebit,
+		Amount:         1,
+		Unit:           policy.Unit,
+		ParkingUsageID: &parkingUsageID,
+		Description:    fmt.Sprintf("Ingreso sin reserva %d", parkingUsageID),
+		CreatedAt:      time.Now(),
+	}
+
+	if err := uc.debit(policy, movement); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+func (uc *QuotaUsecase) AdjustQuota(customerID string, amount float64, description string) (*entities.QuotaMovement, error) {
+	policy, _, err := uc.policyForCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("el cliente %s no tiene una política de cuota", customerID)
+	}
+
+	movement := &entities.QuotaMovement{
+		CustomerID:   customerID,
+		Period:       QuotaPeriod(time.Now()),
+		MovementType: entities.QuotaAdjustment,
+		Amount:       amount,
+		Unit:         policy.Unit,
+		Description:  description,
+		CreatedAt:    time.Now(),
+	}
+
+	if err := uc.QuotaMovementRepository.CreateQuotaMovement(movement); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+func (uc *QuotaUsecase) policyForCustomer(customerID string) (*entities.QuotaPolicy, string, error) {
+	if customerID == "" {
+		return nil, "", nil
+	}
+
+	user, err := uc.UserRepository.SearchUserByID(customerID)
+	if err != nil {
+		return nil, "", fmt.Errorf("error al obtener cliente: %w", err)
+	}
+	if user.CustomerType == nil || *user.CustomerType == "" {
+		return nil, "", nil
+	}
+
+	policy, err := uc.QuotaPolicyRepository.SearchActiveQuotaPolicyByCustomerType(*user.CustomerType)
+	if err != nil {
+		return nil, "", err
+	}
+	return policy, *user.CustomerType, nil
+}
+
+// El mes se cuenta en la hora del campus: una reserva a las 22:00 del último día no cae en el mes siguiente
+func QuotaPeriod(at time.Time) string {
+	return utils.InCampus(at).Format("2006-01")
+}
+
+func QuotaPeriodStart(period string) time.Time {
+	start, err := time.ParseInLocation("2006-01", period, utils.CampusLocation())
+	if err != nil {
+		return time.Now()
+	}
+	return start
+}
+
+func reservationCost(policy *entities.QuotaPolicy, reservation *reservationEntities.Reservation) float64 {
+	if policy.Unit == entities.QuotaUnitEntries {
+		return 1
+	}
+	hours := reservation.EndTime.Sub(reservation.StartTime).Hours()
+	return math.Ceil(hours*100) / 100
+}
+
+func extensionCost(newEnd, previousEnd time.Time) float64 {
+	hours := newEnd.Sub(previousEnd).Hours()
+	return math.Ceil(hours*100) / 100
+}
+
+func validateQuotaPolicy(policy *entities.QuotaPolicy) error {
+	if policy.CustomerType == "" {
+		return fmt.Errorf("el tipo de cliente es requerido")
+	}
+	if policy.Unit != entities.QuotaUnitHours && policy.Unit != entities.QuotaUnitEntries {
+		return fmt.Errorf("unidad de cuota inválida, use %q o %q", entities.QuotaUnitHours, entities.QuotaUnitEntries)
+	}
+	if policy.MonthlyAllowance < 0 || policy.RefundCutoffMinutes < 0 {
+		return fmt.Errorf("los valores de la cuota no pueden ser negativos")
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package application
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/quota/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	userEntities "github.com/gonzalohonorato/servercorego/core/user/domain/entities"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+)
+
+type fakeQuotaPolicyRepository struct {
+	repositories.QuotaPolicyRepository
+	policy *entities.QuotaPolicy
+}
+
+func (r *fakeQuotaPolicyRepository) SearchActiveQuotaPolicyByCustomerType(customerType string) (*entities.QuotaPolicy, error) {
+	return r.policy, nil
+}
+
+type fakeQuotaMovementRepository struct {
+	repositories.QuotaMovementRepository
+	mu        sync.Mutex
+	movements entities.QuotaMovements
+}
+
+func (r *fakeQuotaMovementRepository) filter(match func(entities.QuotaMovement) bool) *entities.QuotaMovements {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	movements := entities.QuotaMovements{}
+	for _, movement := range r.movements {
+		if match(movement) {
+			movements = append(movements, movement)
+		}
+	}
+	return &movements
+}
+
+func (r *fakeQuotaMovementRepository) SearchQuotaMovementsByCustomerIDAndPeriod(customerID string, period string) (*entities.QuotaMovements, error) {
+	return r.filter(func(m entities.QuotaMovement) bool { return m.CustomerID == customerID && m.Period == period }), nil
+}
+
+func (r *fakeQuotaMovementRepository) SearchQuotaMovementsByReservationID(reservationID int) (*entities.QuotaMovements, error) {
+	return r.filter(func(m entities.QuotaMovement) bool {
+		return m.ReservationID != nil && *m.ReservationID == reservationID
+	}), nil
+}
+
+func (r *fakeQuotaMovementRepository) CreateQuotaMovement(movement *entities.QuotaMovement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	movement.ID = len(r.movements) + 1
+	r.movements = append(r.movements, *movement)
+	return nil
+}
+
+func (r *fakeQuotaMovementRepository) CreateQuotaDebit(movement *entities.QuotaMovement, monthlyAllowance float64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	available := monthlyAllowance
+	for _, m := range r.movements {
+		if m.CustomerID != movement.CustomerID || m.Period != movement.Period {
+			continue
+		}
+		switch m.MovementType {
+		case entities.QuotaDebit:
+			available -= m.Amount
+		case entities.QuotaRefund, entities.QuotaAdjustment:
+			available += m.Amount
+		}
+	}
+	if available < movement.Amount {
+		return false, nil
+	}
+	movement.ID = len(r.movements) + 1
+	r.movements = append(r.movements, *movement)
+	return true, nil
+}
+
+func (r *fakeQuotaMovementRepository) count(customerID, movementType string) int {
+	return len(*r.filter(func(m entities.QuotaMovement) bool {
+		return m.CustomerID == customerID && m.MovementType == movementType
+	}))
+}
+
+type fakeQuotaUserRepository struct {
+	userRepositories.UserRepository
+}
+
+func (r *fakeQuotaUserRepository) SearchUserByID(id string) (*userEntities.User, error) {
+	customerType := "student"
+	return &userEntities.User{ID: id, CustomerType: &customerType}, nil
+}
+
+func newQuotaTestUsecase(unit string, allowance float64) (*QuotaUsecase, *fakeQuotaMovementRepository) {
+	movements := &fakeQuotaMovementRepository{}
+	policy := &entities.QuotaPolicy{CustomerType: "student", Unit: unit, MonthlyAllowance: allowance, IsActive: true}
+	return NewQuotaUsecase(&fakeQuotaPolicyRepository{policy: policy}, movements, &fakeQuotaUserRepository{}), movements
+}
+
+func TestDebitEntryStopsAtExhaustion(t *testing.T) {
+	uc, movements := newQuotaTestUsecase(entities.QuotaUnitEntries, 2)
+	at := time.Now()
+
+	for usageID := 1; usageID <= 2; usageID++ {
+		if _, err := uc.DebitEntry("c1", usageID, at); err != nil {
+			t.Fatalf("el ingreso %d debería descontarse: %v", usageID, err)
+		}
+	}
+	if _, err := uc.DebitEntry("c1", 3, at); !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("el tercer ingreso debería rechazarse por cuota agotada, obtuvo %v", err)
+	}
+	if got := movements.count("c1", entities.QuotaDebit); got != 2 {
+		t.Fatalf("se esperaban 2 descuentos, hay %d", got)
+	}
+}
+
+func TestDebitReservationConcurrentNeverOverdraws(t *testing.T) {
+	uc, movements := newQuotaTestUsecase(entities.QuotaUnitHours, 3)
+	start := time.Now().Add(24 * time.Hour)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	exhausted := 0
+	for id := 1; id <= 8; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			reservation := &reservationEntities.Reservation{ID: id, CustomerID: "c1", StartTime: start, EndTime: start.Add(time.Hour)}
+			if err := uc.CheckReservation(reservation); err != nil {
+				return
+			}
+			if _, err := uc.DebitReservation(reservation); errors.Is(err, ErrQuotaExhausted) {
+				mu.Lock()
+				exhausted++
+				mu.Unlock()
+			} else if err != nil {
+				t.Errorf("error inesperado en la reserva %d: %v", id, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if got := movements.count("c1", entities.QuotaDebit); got != 3 {
+		t.Fatalf("se esperaban exactamente 3 horas descontadas, hay %d descuentos", got)
+	}
+	balance, err := uc.Balance("c1", start)
+	if err != nil {
+		t.Fatalf("error inesperado al consultar saldo: %v", err)
+	}
+	if balance.Available != 0 {
+		t.Fatalf("el saldo no debería quedar negativo ni sobrante, disponible %.2f", balance.Available)
+	}
+}
+
+func TestRefundReservationAfterTransferBack(t *testing.T) {
+	uc, _ := newQuotaTestUsecase(entities.QuotaUnitHours, 10)
+	start := time.Now().Add(48 * time.Hour)
+	reservation := &reservationEntities.Reservation{ID: 5, CustomerID: "a", StartTime: start, EndTime: start.Add(2 * time.Hour)}
+
+	if _, err := uc.DebitReservation(reservation); err != nil {
+		t.Fatalf("error inesperado al descontar: %v", err)
+	}
+	for _, transfer := range []struct{ from, to string }{{"a", "b"}, {"b", "a"}} {
+		reservation.CustomerID = transfer.to
+		if err := uc.TransferReservation(reservation, transfer.from); err != nil {
+			t.Fatalf("error inesperado al transferir de %s a %s: %v", transfer.from, transfer.to, err)
+		}
+	}
+
+	refund, err := uc.RefundReservation(reservation, time.Now())
+	if err != nil {
+		t.Fatalf("error inesperado al reembolsar: %v", err)
+	}
+	if refund == nil || refund.Amount != 2 {
+		t.Fatalf("la reserva devuelta a su titular debería reembolsarle 2 horas, obtuvo %+v", refund)
+	}
+
+	again, err := uc.RefundReservation(reservation, time.Now())
+	if err != nil || again != nil {
+		t.Fatalf("un segundo reembolso no debería registrarse: %+v, %v", again, err)
+	}
+
+	for _, customerID := range []string{"a", "b"} {
+		balance, err := uc.Balance(customerID, start)
+		if err != nil {
+			t.Fatalf("error inesperado al consultar saldo de %s: %v", customerID, err)
+		}
+		if balance.Used != 0 {
+			t.Errorf("el cliente %s debería quedar sin uso tras el reembolso, usado %.2f", customerID, balance.Used)
+		}
+	}
+}
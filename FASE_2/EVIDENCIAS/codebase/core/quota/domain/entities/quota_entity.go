@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+const (
+	QuotaUnitHours   = "hours"
+	QuotaUnitEntries = "entries"
+
+	QuotaDebit      = "debit"
+	QuotaRefund     = "refund"
+	QuotaAdjustment = "adjustment"
+)
+
+type QuotaPolicy struct {
+	ID                  int     `json:"id"`
+	CustomerType        string  `json:"customerType"`
+	Unit                string  `json:"unit"`
+	MonthlyAllowance    float64 `json:"monthlyAllowance"`
+	RefundCutoffMinutes int     `json:"refundCutoffMinutes"`
+	IsActive            bool    `json:"isActive"`
+}
+
+type QuotaPolicies []QuotaPolicy
+
+type QuotaMovement struct {
+	ID             int       `json:"id"`
+	CustomerID     string    `json:"customerId"`
+	Period         string    `json:"period"`
+	MovementType   string    `json:"movementType"`
+	Amount         float64   `json:"amount"`
+	Unit           string    `json:"unit"`
+	ReservationID  *int      `json:"reservationId"`
+	ParkingUsageID *int      `json:"parkingUsageId"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type QuotaMovements []QuotaMovement
+
+type QuotaBalance struct {
+	CustomerID   string  `json:"customerId"`
+	CustomerType string  `json:"customerType"`
+	Period       string  `json:"period"`
+	Unit         string  `json:"unit"`
+	Allowance    float64 `json:"allowance"`
+	Used         float64 `json:"used"`
+	Available    float64 `json:"available"`
+	Unlimited    bool    `json:"unlimited"`
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/quota/domain/entities"
+
+type QuotaMovementRepository interface {
+	SearchQuotaMovementsByCustomerID(customerID string) (*entities.QuotaMovements, error)
+	SearchQuotaMovementsByCustomerIDAndPeriod(customerID string, period string) (*entities.QuotaMovements, error)
+	SearchQuotaMovementsByReservationID(reservationID int) (*entities.QuotaMovements, error)
+	CreateQuotaMovement(movement *entities.QuotaMovement) error
+	CreateQuotaDebit(movement *entities.QuotaMovement, monthlyAllowance float64) (bool, error)
+}
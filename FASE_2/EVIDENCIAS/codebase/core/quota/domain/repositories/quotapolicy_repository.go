@@ -0,0 +1,12 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/quota/domain/entities"
+
+type QuotaPolicyRepository interface {
+	SearchQuotaPolicyByID(id int) (*entities.QuotaPolicy, error)
+	SearchQuotaPolicies() (*entities.QuotaPolicies, error)
+	SearchActiveQuotaPolicyByCustomerType(customerType string) (*entities.QuotaPolicy, error)
+	CreateQuotaPolicy(policy *entities.QuotaPolicy) error
+	UpdateQuotaPolicyByID(policy *entities.QuotaPolicy) error
+	DeleteQuotaPolicyByID(id int) error
+}
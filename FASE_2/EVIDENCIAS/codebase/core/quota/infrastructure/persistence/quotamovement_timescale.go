@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/quota/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleQuotaMovementRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleQuotaMovementRepository(pool *pgxpool.Pool) *TimescaleQuotaMovementRepository {
+	return &TimescaleQuotaMovementRepository{
+		dbPool: pool,
+	}
+}
+
+const quotaMovementColumns = `id, customer_id, period, movement_type, amount, unit, reservation_id, parking_usage_id, description, created_at`
+
+func (r *TimescaleQuotaMovementRepository) SearchQuotaMovementsByCustomerID(customerID string) (*entities.QuotaMovements, error) {
+	query := `SELECT ` + quotaMovementColumns + ` FROM quota_movement WHERE customer_id = $1 ORDER BY created_at DESC`
+	return r.searchQuotaMovements(query, customerID)
+}
+
+func (r *TimescaleQuotaMovementRepository) SearchQuotaMovementsByCustomerIDAndPeriod(customerID string, period string) (*entities.QuotaMovements, error) {
+	query := `SELECT ` + quotaMovementColumns + ` FROM quota_movement WHERE customer_id = $1 AND period = $2 ORDER BY created_at DESC`
+	return r.searchQuotaMovements(query, customerID, period)
+}
+
+func (r *TimescaleQuotaMovementRepository) SearchQuotaMovementsByReservationID(reservationID int) (*entities.QuotaMovements, error) {
+	query := `SELECT ` + quotaMovementColumns + ` FROM quota_movement WHERE reservation_id = $1 ORDER BY created_at`
+	return r.searchQuotaMovements(query, reservationID)
+}
+
+func (r *TimescaleQuotaMovementRepository) searchQuotaMovements(query string, args ...interface{}) (*entities.QuotaMovements, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := entities.QuotaMovements{}
+	for rows.Next() {
+		var m entities.QuotaMovement
+		if err := rows.Scan(&m.ID, &m.CustomerID, &m.Period, &m.MovementType, &m.Amount, &m.Unit,
+			&m.ReservationID, &m.ParkingUsageID, &m.Description, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		movements = append(movements, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &movements, nil
+}
+
+func (r *TimescaleQuotaMovementRepository) CreateQuotaMovement(movement *entities.QuotaMovement) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO quota_movement (
+		customer_id, period, movement_type, amount, unit, reservation_id, parking_usage_id, description, created_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
+	) RETURNING id;
+`
+	if movement.CreatedAt.IsZero() {
+		movement.CreatedAt = time.Now()
+	}
+	return r.dbPool.QueryRow(ctx, query, movement.CustomerID, movement.Period, movement.MovementType, movement.Amount,
+		movement.Unit, movement.ReservationID, movement.ParkingUsageID, movement.Description, movement.CreatedAt,
+	).Scan(&movement.ID)
+}
+
+// Descuenta solo si el saldo del periodo alcanza. El candado por cliente y periodo serializa los descuentos
+// concurrentes para que dos reservas simultáneas no consuman el mismo saldo
+func (r *TimescaleQuotaMovementRepository) CreateQuotaDebit(movement *entities.QuotaMovement, monthlyAllowance float64) (bool, error) {
+	ctx := context.Background()
+	tx, err := r.dbPool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`, movement.CustomerID, movement.Period); err != nil {
+		return false, err
+	}
+
+	var available float64
+	err = tx.QueryRow(ctx, `
+	SELECT $3 + COALESCE(SUM(CASE movement_type
+		WHEN $4 THEN -amount
+		WHEN $5 THEN amount
+		WHEN $6 THEN amount
+		ELSE 0 END), 0)
+	FROM quota_movement WHERE customer_id = $1 AND period = $2`,
+		movement.CustomerID, movement.Period, monthlyAllowance,
+		entities.QuotaDebit, entities.QuotaRefund, entities.QuotaAdjustment,
+	).Scan(&available)
+	if err != nil {
+		return false, err
+	}
+	if available < movement.Amount {
+		return false, nil
+	}
+
+	if movement.CreatedAt.IsZero() {
+		movement.CreatedAt = time.Now()
+	}
+	err = tx.QueryRow(ctx, `
+	INSERT INTO quota_movement (
+		customer_id, period, movement_type, amount, unit, reservation_id, parking_usage_id, description, created_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
+	) RETURNING id`,
+		movement.CustomerID, movement.Period, movement.MovementType, movement.Amount,
+		movement.Unit, movement.ReservationID, movement.ParkingUsageID, movement.Description, movement.CreatedAt,
+	).Scan(&movement.ID)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/quota/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleQuotaPolicyRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleQuotaPolicyRepository(pool *pgxpool.Pool) *TimescaleQuotaPolicyRepository {
+	return &TimescaleQuotaPolicyRepository{
+		dbPool: pool,
+	}
+}
+
+func (r *TimescaleQuotaPolicyRepository) SearchQuotaPolicyByID(id int) (*entities.QuotaPolicy, error) {
+	ctx := context.Background()
+	query := `SELECT id, customer_type, unit, monthly_allowance, refund_cutoff_minutes, is_active FROM quota_policy WHERE id = $1`
+	var p entities.QuotaPolicy
+	err := r.dbPool.QueryRow(ctx, query, id).Scan(&p.ID, &p.CustomerType, &p.Unit, &p.MonthlyAllowance, &p.RefundCutoffMinutes, &p.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *TimescaleQuotaPolicyRepository) SearchQuotaPolicies() (*entities.QuotaPolicies, error) {
+	ctx := context.Background()
+	query := `SELECT id, customer_type, unit, monthly_allowance, refund_cutoff_minutes, is_active FROM quota_policy ORDER BY customer_type`
+	rows, err := r.dbPool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := entities.QuotaPolicies{}
+	for rows.Next() {
+		var p entities.QuotaPolicy
+		if err := rows.Scan(&p.ID, &p.CustomerType, &p.Unit, &p.MonthlyAllowance, &p.RefundCutoffMinutes, &p.IsActive); err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &policies, nil
+}
+
+func (r *TimescaleQuotaPolicyRepository) SearchActiveQuotaPolicyByCustomerType(customerType string) (*entities.QuotaPolicy, error) {
+	ctx := context.Background()
+	query := `SELECT id, customer_type, unit, monthly_allowance, refund_cutoff_minutes, is_active FROM quota_policy
+	WHERE customer_type = $1 AND is_active = TRUE`
+	var p entities.QuotaPolicy
+	err := r.dbPool.QueryRow(ctx, query, customerType).Scan(&p.ID, &p.CustomerType, &p.Unit, &p.MonthlyAllowance, &p.RefundCutoffMinutes, &p.IsActive)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *TimescaleQuotaPolicyRepository) CreateQuotaPolicy(policy *entities.QuotaPolicy) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO quota_policy (customer_type, unit, monthly_allowance, refund_cutoff_minutes, is_active)
+	VALUES ($1, $2, $3, $4, $5) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query, policy.CustomerType, policy.Unit, policy.MonthlyAllowance,
+		policy.RefundCutoffMinutes, policy.IsActive).Scan(&policy.ID)
+}
+
+func (r *TimescaleQuotaPolicyRepository) UpdateQuotaPolicyByID(policy *entities.QuotaPolicy) error {
+	ctx := context.Background()
+	query := `
+	UPDATE quota_policy SET
+		customer_type = $1, unit = $2, monthly_allowance = $3, refund_cutoff_minutes = $4, is_active = $5
+	WHERE id = $6;
+`
+	_, err := r.dbPool.Exec(ctx, query, policy.CustomerType, policy.Unit, policy.MonthlyAllowance,
+		policy.RefundCutoffMinutes, policy.IsActive, policy.ID)
+	return err
+}
+
+func (r *TimescaleQuotaPolicyRepository) DeleteQuotaPolicyByID(id int) error {
+	ctx := context.Background()
+	query := `DELETE FROM quota_policy WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, id)
+	return err
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/quota/application"
+	"github.com/gonzalohonorato/servercorego/core/quota/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+type QuotaController struct {
+	QuotaUsecase *application.QuotaUsecase
+}
+
+func NewQuotaController(
+	quotaPolicyRepository repositories.QuotaPolicyRepository,
+	quotaMovementRepository repositories.QuotaMovementRepository,
+	userRepository userRepositories.UserRepository,
+) *QuotaController {
+	quotaUseCase := application.NewQuotaUsecase(quotaPolicyRepository, quotaMovementRepository, userRepository)
+
+	return &QuotaController{
+		QuotaUsecase: quotaUseCase,
+	}
+}
+
+func (uc *QuotaController) GetQuotaPolicyByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	policy, err := uc.QuotaUsecase.SearchQuotaPolicyByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Quota policy not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(policy)
+}
+
+func (uc *QuotaController) GetQuotaPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := uc.QuotaUsecase.SearchQuotaPolicies()
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Quota policies not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(policies)
+}
+
+func (uc *QuotaController) PostQuotaPolicy(w http.ResponseWriter, r *http.Request) {
+	var newPolicy entities.QuotaPolicy
+	if err := json.NewDecoder(r.Body).Decode(&newPolicy); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.QuotaUsecase.CreateQuotaPolicy(&newPolicy); err != nil {
+		http.Error(w, "Error creating quota policy: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newPolicy)
+}
+
+func (uc *QuotaController) PutQuotaPolicy(w http.ResponseWriter, r *http.Request) {
+	var policy entities.QuotaPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.QuotaUsecase.UpdateQuotaPolicyByID(&policy); err != nil {
+		http.Error(w, "Error update quota policy: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *QuotaController) DeleteQuotaPolicyByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.QuotaUsecase.DeleteQuotaPolicyByID(idInt); err != nil {
+		http.Error(w, "Error deleting quota policy", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *QuotaController) GetQuotaBalance(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	balance, err := uc.QuotaUsecase.Balance(vars["customerID"], time.Now())
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Quota balance not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(balance)
+}
+
+func (uc *QuotaController) GetQuotaHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	movements, err := uc.QuotaUsecase.SearchQuotaMovementsByCustomerID(vars["customerID"])
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Quota history not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(movements)
+}
+
+func (uc *QuotaController) PostQuotaAdjustment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var request struct {
+		Amount      float64 `json:"amount"`
+		Description string  `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Amount == 0 {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	movement, err := uc.QuotaUsecase.AdjustQuota(vars["customerID"], request.Amount, request.Description)
+	if err != nil {
+		http.Error(w, "Error adjusting quota: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(movement)
+}
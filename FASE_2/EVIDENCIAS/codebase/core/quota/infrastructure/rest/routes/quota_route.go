@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/quota/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func QuotaRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewQuotaController(
+		container.ProvideQuotaPolicyRepository(),
+		container.ProvideQuotaMovementRepository(),
+		container.ProvideUserRepository(),
+	)
+
+	router.HandleFunc("/quota-policies", controller.PutQuotaPolicy).Methods("PUT")
+	router.HandleFunc("/quota-policies", controller.GetQuotaPolicies).Methods("GET")
+	router.HandleFunc("/quota-policies/{id}", controller.DeleteQuotaPolicyByID).Methods("DELETE")
+	router.HandleFunc("/quota-policies/{id}", controller.GetQuotaPolicyByID).Methods("GET")
+	router.HandleFunc("/quota-policies", controller.PostQuotaPolicy).Methods("POST")
+
+	router.HandleFunc("/quotas/customer/{customerID}", controller.GetQuotaBalance).Methods("GET")
+	router.HandleFunc("/quotas/customer/{customerID}/history", controller.GetQuotaHistory).Methods("GET")
+	router.HandleFunc("/quotas/customer/{customerID}/adjust", controller.PostQuotaAdjustment).Methods("POST")
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	parkingEntities "github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+)
+
+var (
+	ErrFeedTokenNotFound = fmt.Errorf("el enlace del calendario no existe o fue revocado")
+	ErrFeedForbidden     = fmt.Errorf("solo el dueño o un funcionario puede acceder a este calendario")
+)
+
+type ReservationFeedUsecase struct {
+	CalendarFeedTokenRepository repositories.CalendarFeedTokenRepository
+	ReservationRepository       repositories.ReservationRepository
+	ParkingRepository           parkingRepositories.ParkingRepository
+	UserRepository              userRepositories.UserRepository
+}
+
+func NewReservationFeedUsecase(
+	feedTokenRepo repositories.CalendarFeedTokenRepository,
+	reservationRepo repositories.ReservationRepository,
+	parkingRepo parkingRepositories.ParkingRepository,
+	userRepo userRepositories.UserRepository,
+) *ReservationFeedUsecase {
+	return &ReservationFeedUsecase{
+		CalendarFeedTokenRepository: feedTokenRepo,
+		ReservationRepository:       reservationRepo,
+		ParkingRepository:           parkingRepo,
+		UserRepository:              userRepo,
+	}
+}
+
+func ReservationFeedDays() int {
+	if days, err := strconv.Atoi(os.Getenv("RESERVATION_FEED_DAYS")); err == nil && days > 0 {
+		return days
+	}
+	return 180
+}
+
+// El token es secreto: solo el dueño o un funcionario pueden consultarlo, emitirlo o revocarlo
+func (uc *ReservationFeedUsecase) authorize(requesterID, ownerID string) error {
+	if requesterID == "" {
+		return ErrFeedForbidden
+	}
+	if requesterID == ownerID {
+		return nil
+	}
+	requester, err := uc.UserRepository.SearchUserByID(requesterID)
+	if err != nil || requester == nil || requester.Type != "employee" {
+		return ErrFeedForbidden
+	}
+	return nil
+}
+
+func (uc *ReservationFeedUsecase) SearchFeedTokenByUserID(requesterID, userID string) (*entities.CalendarFeedToken, error) {
+	if err := uc.authorize(requesterID, userID); err != nil {
+		return nil, err
+	}
+	return uc.CalendarFeedTokenRepository.SearchCalendarFeedTokenByUserID(userID)
+}
+
+// Genera un token nuevo; si el usuario ya tenía uno, el enlace anterior deja de funcionar
+func (uc *ReservationFeedUsecase) IssueFeedToken(requesterID, userID string) (*entities.CalendarFeedToken, error) {
+	if err := uc.authorize(requesterID, userID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.UserRepository.SearchUserByID(userID); err != nil {
+		return nil, fmt.Errorf("usuario no encontrado: %w", err)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("error al generar token: %w", err)
+	}
+
+	feedToken := &entities.CalendarFeedToken{
+		UserID:    userID,
+		Token:     hex.EncodeToString(raw),
+		CreatedAt: time.Now(),
+	}
+	if err := uc.CalendarFeedTokenRepository.SaveCalendarFeedToken(feedToken); err != nil {
+		return nil, err
+	}
+	return feedToken, nil
+}
+
+func (uc *ReservationFeedUsecase) RevokeFeedToken(requesterID, userID string) error {
+	if err := uc.authorize(requesterID, userID); err != nil {
+		return err
+	}
+	return uc.CalendarFeedTokenRepository.DeleteCalendarFeedTokenByUserID(userID)
+}
+
+func (uc *ReservationFeedUsecase) BuildUserFeed(token string) (string, error) {
+	feedToken, err := uc.CalendarFeedTokenRepository.SearchCalendarFeedTokenByToken(token)
+	if err != nil {
+		return "", err
+	}
+	if feedToken == nil {
+		return "", ErrFeedTokenNotFound
+	}
+
+	now := time.Now()
+	today := utils.InCampus(now)
+	from := today.AddDate(0, 0, -ReservationFeedDays())
+	reservations, err := uc.ReservationRepository.SearchReservationByUserIDAndDates(
+		feedToken.UserID, from.Format("2006-01-02"), today.Format("2006-01-02"),
+	)
+	if err != nil {
+		return "", fmt.Errorf("error al obtener reservas: %w", err)
+	}
+
+	return BuildReservationsICS("Reservas de estacionamiento", *reservations, uc.parkingsFor(*reservations), now), nil
+}
+
+func (uc *ReservationFeedUsecase) BuildReservationICS(requesterID string, reservationID int) (string, error) {
+	reservation, err := uc.ReservationRepository.SearchReservationByID(reservationID)
+	if err != nil {
+		return "", err
+	}
+	if err := uc.authorize(requesterID, reservation.CustomerID); err != nil {
+		return "", err
+	}
+
+	reservations := entities.Reservations{*reservation}
+	return BuildReservationsICS("Reserva de estacionamiento", reservations, uc.parkingsFor(reservations), time.Now()), nil
+}
+
+func (uc *ReservationFeedUsecase) parkingsFor(reservations entities.Reservations) map[int]*parkingEntities.Parking {
+	parkings := make(map[int]*parkingEntities.Parking)
+	for _, reservation := range reservations {
+		if _, ok := parkings[reservation.ParkingID]; ok {
+			continue
+		}
+		parking, err := uc.ParkingRepository.SearchParkingByID(reservation.ParkingID)
+		if err != nil {
+			log.Printf("Error al obtener estacionamiento %d para el calendario: %v", reservation.ParkingID, err)
+		}
+		parkings[reservation.ParkingID] = parking
+	}
+	return parkings
+}
@@ -0,0 +1,172 @@
+package application
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	parkingApplication "github.com/gonzalohonorato/servercorego/core/parking/application"
+	"github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+)
+
+// Solo una reserva pendiente que aún no comienza puede cambiar de titular o de estacionamiento
+func (uc *ReservationUsecase) CheckHandOver(reservation *entities.Reservation, now time.Time) error {
+	if reservation == nil {
+		return fmt.Errorf("reserva no encontrada")
+	}
+	if reservation.Status != "pending" {
+		return fmt.Errorf("solo se pueden transferir o intercambiar reservas pendientes (reserva %d en estado %s)", reservation.ID, reservation.Status)
+	}
+	if !reservation.StartTime.After(now) {
+		return fmt.Errorf("la reserva %d ya comenzó y no puede transferirse", reservation.ID)
+	}
+
+	used, err := uc.ReservationRepository.HasParkingUsage(reservation.ID)
+	if err != nil {
+		return fmt.Errorf("error al verificar uso de la reserva: %w", err)
+	}
+	if used {
+		return fmt.Errorf("la reserva %d ya registra un ingreso", reservation.ID)
+	}
+	return nil
+}
+
+func (uc *ReservationUsecase) TransferReservation(reservation *entities.Reservation, toCustomerID string, vehicleID int) error {
+	now := time.Now()
+	if err := uc.CheckHandOver(reservation, now); err != nil {
+		return err
+	}
+	if toCustomerID == reservation.CustomerID {
+		return fmt.Errorf("la reserva ya pertenece al cliente %s", toCustomerID)
+	}
+
+	candidate := *reservation
+	candidate.CustomerID = toCustomerID
+	candidate.VehicleID = vehicleID
+
+	if err := uc.NoShowUsecase.CheckBookingAllowed(toCustomerID, now); err != nil {
+		return err
+	}
+	if err := uc.BookingRuleUsecase.ValidateReservation(&candidate, now); err != nil {
+		return err
+	}
+	if err := uc.QuotaUsecase.CheckReservation(&candidate); err != nil {
+		return err
+	}
+	if err := uc.checkVehicleValidity(vehicleID, candidate.StartTime, candidate.EndTime); err != nil {
+		return err
+	}
+
+	requirements, err := uc.SpotAllocator.RequirementsFor(vehicleID, toCustomerID, candidate.StartTime)
+	if err != nil {
+		return err
+	}
+	ok, err := uc.parkingMeetsRequirements(candidate.ParkingID, requirements)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		alt, err := uc.findAvailableParkingForReservation(candidate.StartTime, candidate.EndTime, false, requirements)
+		if err != nil {
+			return fmt.Errorf("el vehículo no es compatible con el estacionamiento reservado y no hay otro disponible: %w", err)
+		}
+		log.Printf("Reserva ID %d reasignada del parking %d al %d por cambio de vehículo", candidate.ID, candidate.ParkingID, alt.ID)
+		candidate.ParkingID = alt.ID
+	}
+
+	if err := uc.ReservationRepository.UpdateReservationByID(&candidate); err != nil {
+		return fmt.Errorf("error al transferir reserva: %w", err)
+	}
+
+	if err := uc.QuotaUsecase.TransferReservation(&candidate, reservation.CustomerID); err != nil {
+		if revertErr := uc.ReservationRepository.UpdateReservationByID(reservation); revertErr != nil {
+			log.Printf("Error al revertir la transferencia de la reserva %d: %v", reservation.ID, revertErr)
+		}
+		return fmt.Errorf("error al traspasar cuota de la reserva: %w", err)
+	}
+
+	previousCustomerID := reservation.CustomerID
+	*reservation = candidate
+	log.Printf("Reserva ID %d transferida de %s a %s", reservation.ID, previousCustomerID, toCustomerID)
+	return nil
+}
+
+func (uc *ReservationUsecase) CheckSwap(first, second *entities.Reservation, now time.Time) error {
+	if err := uc.CheckHandOver(first, now); err != nil {
+		return err
+	}
+	if err := uc.CheckHandOver(second, now); err != nil {
+		return err
+	}
+	if first.ID == second.ID || first.CustomerID == second.CustomerID {
+		return fmt.Errorf("el intercambio requiere reservas de clientes distintos")
+	}
+	if first.ParkingID == second.ParkingID {
+		return fmt.Errorf("ambas reservas ya usan el estacionamiento %d", first.ParkingID)
+	}
+
+	if err := uc.checkSwapTarget(first, second); err != nil {
+		return err
+	}
+	return uc.checkSwapTarget(second, first)
+}
+
+func (uc *ReservationUsecase) SwapReservationSpots(first, second *entities.Reservation) error {
+	if err := uc.CheckSwap(first, second, time.Now()); err != nil {
+		return err
+	}
+
+	firstParkingID, secondParkingID := first.ParkingID, second.ParkingID
+	first.ParkingID, second.ParkingID = secondParkingID, firstParkingID
+
+	if err := uc.ReservationRepository.UpdateReservationByID(first); err != nil {
+		first.ParkingID, second.ParkingID = firstParkingID, secondParkingID
+		return fmt.Errorf("error al intercambiar reservas: %w", err)
+	}
+	if err := uc.ReservationRepository.UpdateReservationByID(second); err != nil {
+		first.ParkingID = firstParkingID
+		if rollbackErr := uc.ReservationRepository.UpdateReservationByID(first); rollbackErr != nil {
+			log.Printf("Error al revertir intercambio de la reserva %d: %v", first.ID, rollbackErr)
+		}
+		second.ParkingID = secondParkingID
+		return fmt.Errorf("error al intercambiar reservas: %w", err)
+	}
+
+	log.Printf("Reservas ID %d y %d intercambiaron los parkings %d y %d", first.ID, second.ID, firstParkingID, secondParkingID)
+	return nil
+}
+
+// Verifica que la reserva pueda ocupar el estacionamiento de la otra durante su propio horario
+func (uc *ReservationUsecase) checkSwapTarget(reservation, other *entities.Reservation) error {
+	parking, err := uc.ParkingRepository.SearchParkingByID(other.ParkingID)
+	if err != nil {
+		return fmt.Errorf("error al obtener parking: %w", err)
+	}
+
+	requirements, err := uc.requirementsForReservation(reservation)
+	if err != nil {
+		return err
+	}
+	if !parkingApplication.SpotAccepts(parking, requirements) {
+		return fmt.Errorf("el vehículo de la reserva %d no es compatible con el estacionamiento %s", reservation.ID, parking.Code)
+	}
+
+	overlaps, err := uc.ReservationRepository.SearchOverlappingReservations(parking.ID, reservation.StartTime, reservation.EndTime)
+	if err != nil {
+		return fmt.Errorf("error al verificar disponibilidad: %w", err)
+	}
+	for _, overlap := range *overlaps {
+		if overlap.ID != other.ID && overlap.ID != reservation.ID {
+			return fmt.Errorf("el estacionamiento %s está reservado durante el horario de la reserva %d", parking.Code, reservation.ID)
+		}
+	}
+
+	closure, err := uc.findParkingClosure(parking, reservation.StartTime, reservation.EndTime)
+	if err != nil {
+		return fmt.Errorf("error al verificar cierres: %w", err)
+	}
+	if closure != nil {
+		return fmt.Errorf("el estacionamiento %s estará cerrado durante la reserva %d: %s", parking.Code, reservation.ID, closure.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	parkingEntities "github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+)
+
+const icsTimeLayout = "20060102T150405Z"
+
+var reservationStatusLabels = map[string]string{
+	"pending":   "Pendiente",
+	"active":    "Activa",
+	"completed": "Completada",
+	"cancelled": "Cancelada",
+	"no_show":   "Inasistencia",
+}
+
+func BuildReservationsICS(calendarName string, reservations entities.Reservations, parkings map[int]*parkingEntities.Parking, now time.Time) string {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//servercorego//Reservas de estacionamiento//ES")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(calendarName))
+	writeICSLine(&b, "X-WR-TIMEZONE:"+utils.CampusLocation().String())
+	// Los clientes vuelven a consultar el feed para reflejar cancelaciones del scheduler
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT15M")
+	writeICSLine(&b, "X-PUBLISHED-TTL:PT15M")
+
+	for _, reservation := range reservations {
+		writeReservationEvent(&b, reservation, parkings[reservation.ParkingID], now)
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+func writeReservationEvent(b *strings.Builder, reservation entities.Reservation, parking *parkingEntities.Parking, now time.Time) {
+	code, zone, location := fmt.Sprintf("#%d", reservation.ParkingID), "", ""
+	if parking != nil {
+		code, zone, location = parking.Code, parking.Zone, parking.Location
+	}
+
+	status := reservationStatusLabels[reservation.Status]
+	if status == "" {
+		status = reservation.Status
+	}
+
+	summary := "Estacionamiento " + code
+	if zone != "" {
+		summary += " (" + zone + ")"
+	}
+	icsStatus := "CONFIRMED"
+	if reservation.Status == "cancelled" || reservation.Status == "no_show" {
+		summary = status + ": " + summary
+		icsStatus = "CANCELLED"
+	}
+
+	description := fmt.Sprintf("Reserva #%d\nEstacionamiento: %s\nZona: %s\nEstado: %s", reservation.ID, code, zone, status)
+
+	writeICSLine(b, "BEGIN:VEVENT")
+	writeICSLine(b, fmt.Sprintf("UID:reservation-%d@servercorego", reservation.ID))
+	writeICSLine(b, "DTSTAMP:"+now.UTC().Format(icsTimeLayout))
+	writeICSLine(b, "DTSTART:"+reservation.StartTime.UTC().Format(icsTimeLayout))
+	writeICSLine(b, "DTEND:"+reservation.EndTime.UTC().Format(icsTimeLayout))
+	writeICSLine(b, "SUMMARY:"+escapeICSText(summary))
+	writeICSLine(b, "DESCRIPTION:"+escapeICSText(description))
+	if location != "" || zone != "" {
+		writeICSLine(b, "LOCATION:"+escapeICSText(strings.Trim(location+", "+zone, ", ")))
+	}
+	writeICSLine(b, "STATUS:"+icsStatus)
+	writeICSLine(b, "END:VEVENT")
+}
+
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// RFC 5545 limita las líneas a 75 octetos; las siguientes comienzan con un espacio
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
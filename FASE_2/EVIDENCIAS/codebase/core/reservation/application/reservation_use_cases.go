@@ -3,24 +3,83 @@ package application
 import (
 	"fmt"
 	"log"
+	"os"
+	"strconv"
 	"time"
 
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	bookingRuleApplication "github.com/gonzalohonorato/servercorego/core/bookingrule/application"
+	bookingRuleRepositories "github.com/gonzalohonorato/servercorego/core/bookingrule/domain/repositories"
+	calendarApplication "github.com/gonzalohonorato/servercorego/core/calendar/application"
+	calendarEntities "github.com/gonzalohonorato/servercorego/core/calendar/domain/entities"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	closureEntities "github.com/gonzalohonorato/servercorego/core/closure/domain/entities"
+	closureRepositories "github.com/gonzalohonorato/servercorego/core/closure/domain/repositories"
+	noShowApplication "github.com/gonzalohonorato/servercorego/core/noshow/application"
+	noShowRepositories "github.com/gonzalohonorato/servercorego/core/noshow/domain/repositories"
+	parkingApplication "github.com/gonzalohonorato/servercorego/core/parking/application"
 	parkingEntities "github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
 	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	quotaApplication "github.com/gonzalohonorato/servercorego/core/quota/application"
+	quotaRepositories "github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
 )
 
+type ExtensionConflictError struct {
+	CurrentEndTime time.Time
+	LatestEndTime  time.Time
+}
+
+func (e *ExtensionConflictError) Error() string {
+	if !e.LatestEndTime.After(e.CurrentEndTime) {
+		return "el estacionamiento está reservado a continuación, no es posible extender la reserva"
+	}
+	return fmt.Sprintf("el estacionamiento está reservado, la reserva puede extenderse como máximo hasta %s",
+		utils.InCampus(e.LatestEndTime).Format("2006-01-02 15:04"))
+}
+
 type ReservationUsecase struct {
 	ReservationRepository repositories.ReservationRepository
 	ParkingRepository     parkingRepositories.ParkingRepository
+	QuotaUsecase          *quotaApplication.QuotaUsecase
+	BookingRuleUsecase    *bookingRuleApplication.BookingRuleUsecase
+	NoShowUsecase         *noShowApplication.NoShowUsecase
+	SpotAllocator         *parkingApplication.SpotAllocator
+	ClosureRepository     closureRepositories.ClosureRepository
+	WebSocketService      *infrastructure.WebSocketService
+	Calendar              *calendarApplication.CalendarUsecase
 }
 
 
-func NewReservationUsecase(reservationRepo repositories.ReservationRepository, parkingRepo parkingRepositories.ParkingRepository) *ReservationUsecase {
+func NewReservationUsecase(
+	reservationRepo repositories.ReservationRepository,
+	parkingRepo parkingRepositories.ParkingRepository,
+	userRepo userRepositories.UserRepository,
+	quotaPolicyRepo quotaRepositories.QuotaPolicyRepository,
+	quotaMovementRepo quotaRepositories.QuotaMovementRepository,
+	bookingRuleRepo bookingRuleRepositories.BookingRuleRepository,
+	noShowRepo noShowRepositories.NoShowRepository,
+	suspensionRepo noShowRepositories.BookingSuspensionRepository,
+	wsService *infrastructure.WebSocketService,
+	vehicleRepo vehicleRepositories.VehicleRepository,
+	permitRepo parkingRepositories.AccessibilityPermitRepository,
+	closureRepo closureRepositories.ClosureRepository,
+	calendarRepo calendarRepositories.CalendarEventRepository,
+) *ReservationUsecase {
 	return &ReservationUsecase{
 		ReservationRepository: reservationRepo,
 		ParkingRepository:     parkingRepo,
+		QuotaUsecase:          quotaApplication.NewQuotaUsecase(quotaPolicyRepo, quotaMovementRepo, userRepo),
+		BookingRuleUsecase:    bookingRuleApplication.NewBookingRuleUsecase(bookingRuleRepo, reservationRepo, userRepo, calendarRepo),
+		NoShowUsecase:         noShowApplication.NewNoShowUsecase(noShowRepo, suspensionRepo, wsService),
+		SpotAllocator:         parkingApplication.NewSpotAllocator(vehicleRepo, permitRepo),
+		ClosureRepository:     closureRepo,
+		WebSocketService:      wsService,
+		Calendar:              calendarApplication.NewCalendarUsecase(calendarRepo),
 	}
 }
 
@@ -39,6 +98,15 @@ func (uc *ReservationUsecase) SearchReservationByUserIDAndDates(userID string, s
 	return uc.ReservationRepository.SearchReservationByUserIDAndDates(userID, startDate, endDate)
 }
 func (uc *ReservationUsecase) CreateReservation(reservation *entities.Reservation) error {
+	return uc.createReservation(reservation, false)
+}
+
+// Las reservas compartidas tienen prioridad sobre los estacionamientos dedicados a carpool
+func (uc *ReservationUsecase) CreateCarpoolReservation(reservation *entities.Reservation) error {
+	return uc.createReservation(reservation, true)
+}
+
+func (uc *ReservationUsecase) createReservation(reservation *entities.Reservation, carpool bool) error {
 	now := time.Now()
 	timeUntilStart := reservation.StartTime.Sub(now)
 	isImmediate := timeUntilStart <= time.Hour && timeUntilStart > 0
@@ -48,6 +116,28 @@ func (uc *ReservationUsecase) CreateReservation(reservation *entities.Reservatio
 		return fmt.Errorf("la fecha de inicio no puede ser en el pasado")
 	}
 
+	if err := uc.NoShowUsecase.CheckBookingAllowed(reservation.CustomerID, now); err != nil {
+		return err
+	}
+
+	if err := uc.BookingRuleUsecase.ValidateReservation(reservation, now); err != nil {
+		return err
+	}
+
+	if err := uc.QuotaUsecase.CheckReservation(reservation); err != nil {
+		return err
+	}
+
+	if err := uc.checkVehicleValidity(reservation.VehicleID, reservation.StartTime, reservation.EndTime); err != nil {
+		return err
+	}
+
+	requirements, err := uc.SpotAllocator.RequirementsFor(reservation.VehicleID, reservation.CustomerID, reservation.StartTime)
+	if err != nil {
+		return err
+	}
+	requirements.IsCarpool = carpool
+
 	
 	if reservation.ParkingID > 0 {
 		
@@ -55,9 +145,15 @@ func (uc *ReservationUsecase) CreateReservation(reservation *entities.Reservatio
 		if err != nil {
 			return err
 		}
+		if ok {
+			ok, err = uc.parkingMeetsRequirements(reservation.ParkingID, requirements)
+			if err != nil {
+				return err
+			}
+		}
 		if !ok {
 			
-			alt, err := uc.findAvailableParkingForReservation(reservation.StartTime, reservation.EndTime, isImmediate)
+			alt, err := uc.findAvailableParkingForReservation(reservation.StartTime, reservation.EndTime, isImmediate, requirements)
 			if err != nil {
 				return fmt.Errorf("no hay otro parking disponible: %w", err)
 			}
@@ -65,7 +161,7 @@ func (uc *ReservationUsecase) CreateReservation(reservation *entities.Reservatio
 		}
 	} else {
 		
-		p, err := uc.findAvailableParkingForReservation(reservation.StartTime, reservation.EndTime, isImmediate)
+		p, err := uc.findAvailableParkingForReservation(reservation.StartTime, reservation.EndTime, isImmediate, requirements)
 		if err != nil {
 			return fmt.Errorf("no hay estacionamientos disponibles: %w", err)
 		}
@@ -75,18 +171,118 @@ func (uc *ReservationUsecase) CreateReservation(reservation *entities.Reservatio
 	
 	if isImmediate {
 		reservation.Status = "active"
-		if err := uc.activateParkingForReservation(reservation.ParkingID); err != nil {
-			return fmt.Errorf("error al activar parking inmediato: %w", err)
-		}
 	} else {
 		reservation.Status = "pending"
 	}
 
 	
-	return uc.ReservationRepository.CreateReservation(reservation)
+	if err := uc.ReservationRepository.CreateReservation(reservation); err != nil {
+		return err
+	}
+
+	// El cupo se confirma al descontarlo: otra reserva simultánea pudo agotarlo después de CheckReservation
+	if _, err := uc.QuotaUsecase.DebitReservation(reservation); err != nil {
+		uc.discardReservation(reservation)
+		return err
+	}
+
+	if isImmediate {
+		if err := uc.activateParkingForReservation(reservation.ParkingID); err != nil {
+			uc.discardReservation(reservation)
+			return fmt.Errorf("error al activar parking inmediato: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *ReservationUsecase) discardReservation(reservation *entities.Reservation) {
+	if _, err := uc.QuotaUsecase.ForceRefundReservation(reservation, fmt.Sprintf("Reserva %d descartada", reservation.ID)); err != nil {
+		log.Printf("Error al devolver cuota de la reserva descartada %d: %v", reservation.ID, err)
+	}
+	if err := uc.ReservationRepository.DeleteReservationByID(reservation.ID); err != nil {
+		log.Printf("Error al descartar reserva %d: %v", reservation.ID, err)
+	}
 }
 
 
+func (uc *ReservationUsecase) ExtendReservation(id int, newEndTime time.Time) (*entities.Reservation, error) {
+	reservation, err := uc.SearchReservationByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar reserva: %w", err)
+	}
+	if reservation == nil {
+		return nil, fmt.Errorf("reserva con ID %d no encontrada", id)
+	}
+
+	if reservation.Status != "pending" && reservation.Status != "active" {
+		return nil, fmt.Errorf("solo se pueden extender reservas pendientes o activas")
+	}
+	if !newEndTime.After(reservation.EndTime) {
+		return nil, fmt.Errorf("la nueva hora de término debe ser posterior a la actual")
+	}
+	if err := uc.checkVehicleValidity(reservation.VehicleID, reservation.StartTime, newEndTime); err != nil {
+		return nil, err
+	}
+
+	overlaps, err := uc.ReservationRepository.SearchOverlappingReservations(reservation.ParkingID, reservation.EndTime, newEndTime)
+	if err != nil {
+		return nil, fmt.Errorf("error al verificar disponibilidad: %w", err)
+	}
+
+	latestEndTime := newEndTime
+	for _, other := range *overlaps {
+		if other.ID != reservation.ID && other.StartTime.Before(latestEndTime) {
+			latestEndTime = other.StartTime
+		}
+	}
+
+	parking, err := uc.ParkingRepository.SearchParkingByID(reservation.ParkingID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener parking: %w", err)
+	}
+	closure, err := uc.findParkingClosure(parking, reservation.EndTime, newEndTime)
+	if err != nil {
+		return nil, fmt.Errorf("error al verificar cierres: %w", err)
+	}
+	if closure != nil && closure.StartsAt.Before(latestEndTime) {
+		latestEndTime = closure.StartsAt
+		if latestEndTime.Before(reservation.EndTime) {
+			latestEndTime = reservation.EndTime
+		}
+	}
+	if latestEndTime.Before(newEndTime) {
+		return nil, &ExtensionConflictError{CurrentEndTime: reservation.EndTime, LatestEndTime: latestEndTime}
+	}
+
+	previousEndTime := reservation.EndTime
+	reservation.EndTime = newEndTime
+
+	if err := uc.BookingRuleUsecase.ValidateExtension(reservation); err != nil {
+		return nil, err
+	}
+	if err := uc.QuotaUsecase.CheckExtension(reservation, previousEndTime); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ReservationRepository.UpdateReservationByID(reservation); err != nil {
+		return nil, fmt.Errorf("error al extender reserva: %w", err)
+	}
+
+	if _, err := uc.QuotaUsecase.DebitExtension(reservation, previousEndTime); err != nil {
+		extendedEndTime := reservation.EndTime
+		reservation.EndTime = previousEndTime
+		if revertErr := uc.ReservationRepository.UpdateReservationByID(reservation); revertErr != nil {
+			log.Printf("Error al revertir la extensión de la reserva %d a %v: %v", reservation.ID, previousEndTime, revertErr)
+		}
+		log.Printf("Extensión de la reserva %d hasta %v rechazada: %v", reservation.ID, extendedEndTime, err)
+		return nil, err
+	}
+
+	log.Printf("Reserva ID %d extendida de %v a %v", reservation.ID, previousEndTime, newEndTime)
+	return reservation, nil
+}
+
 func (uc *ReservationUsecase) activateParkingForReservation(parkingID int) error {
 	parking, err := uc.ParkingRepository.SearchParkingByID(parkingID)
 	if err != nil {
@@ -166,6 +362,10 @@ func (uc *ReservationUsecase) UpdateReservationStatus(id int, newStatus string)
 		if reservation.Status != "active" {
 			return fmt.Errorf("solo se pueden completar reservas activas")
 		}
+	case "no_show":
+		if reservation.Status != "pending" && reservation.Status != "active" {
+			return fmt.Errorf("solo se pueden marcar como inasistencia reservas pendientes o activas")
+		}
 	default:
 		
 	}
@@ -174,7 +374,23 @@ func (uc *ReservationUsecase) UpdateReservationStatus(id int, newStatus string)
 	reservation.Status = newStatus
 
 	
-	return uc.UpdateReservationById(reservation)
+	if err := uc.UpdateReservationById(reservation); err != nil {
+		return err
+	}
+
+	if newStatus == "cancelled" {
+		if _, err := uc.QuotaUsecase.RefundReservation(reservation, time.Now()); err != nil {
+			log.Printf("Error al reembolsar cuota de la reserva %d: %v", reservation.ID, err)
+		}
+	}
+
+	if newStatus == "no_show" {
+		if _, _, err := uc.NoShowUsecase.RecordNoShow(reservation); err != nil {
+			log.Printf("Error al registrar inasistencia de la reserva %d: %v", reservation.ID, err)
+		}
+	}
+
+	return nil
 }
 
 
@@ -192,14 +408,21 @@ func (uc *ReservationUsecase) CancelExpiredPendingReservations() (int, error) {
 	}
 
 	count := 0
-	gracePeriod := 15 * time.Minute
 	now := time.Now()
 
 	for _, reservation := range *expiredReservations {
 		
-		if now.After(reservation.StartTime.Add(gracePeriod)) {
-			
-			reservation.Status = "cancelled"
+		if now.After(reservation.StartTime.Add(uc.noShowGracePeriod(reservation.StartTime))) {
+			used, err := uc.ReservationRepository.HasParkingUsage(reservation.ID)
+			if err != nil {
+				log.Printf("Error al verificar uso de la reserva %d: %v", reservation.ID, err)
+				continue
+			}
+			if used {
+				continue
+			}
+
+			reservation.Status = "no_show"
 			if err := uc.UpdateReservationById(&reservation); err != nil {
 				log.Printf("Error al cancelar reserva expirada ID %d: %v", reservation.ID, err)
 				continue
@@ -211,8 +434,12 @@ func (uc *ReservationUsecase) CancelExpiredPendingReservations() (int, error) {
 				log.Printf("Error al liberar parking de reserva expirada %d: %v", reservation.ID, err)
 			}
 
+			if _, _, err := uc.NoShowUsecase.RecordNoShow(&reservation); err != nil {
+				log.Printf("Error al registrar inasistencia de la reserva %d: %v", reservation.ID, err)
+			}
+
 			count++
-			log.Printf("Reserva ID %d cancelada automáticamente por expiración", reservation.ID)
+			log.Printf("Reserva ID %d marcada como inasistencia por expiración", reservation.ID)
 		}
 	}
 
@@ -220,6 +447,22 @@ func (uc *ReservationUsecase) CancelExpiredPendingReservations() (int, error) {
 }
 
 
+// En días de exámenes el campus se congestiona y se da más margen antes de marcar inasistencia
+func (uc *ReservationUsecase) noShowGracePeriod(start time.Time) time.Duration {
+	grace := envMinutes("RESERVATION_GRACE_PERIOD_MINUTES", 15)
+	if uc.Calendar != nil && uc.Calendar.DayType(start) == calendarEntities.DayTypeExam {
+		grace = envMinutes("RESERVATION_EXAM_GRACE_MINUTES", 30)
+	}
+	return grace
+}
+
+func envMinutes(name string, defaultMinutes int) time.Duration {
+	if minutes, err := strconv.Atoi(os.Getenv(name)); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return time.Duration(defaultMinutes) * time.Minute
+}
+
 func (uc *ReservationUsecase) SearchReservationsStartingWithin(timeLimit time.Time) (*entities.Reservations, error) {
 	
 	return uc.ReservationRepository.SearchReservationsStartingWithin(timeLimit)
@@ -261,20 +504,25 @@ func (uc *ReservationUsecase) ActivateReservationParking(reservationID int) erro
 
 
 
-func (uc *ReservationUsecase) findAvailableParkingForReservation(startTime, endTime time.Time, isImmediateReservation bool) (*parkingEntities.Parking, error) {
+func (uc *ReservationUsecase) findAvailableParkingForReservation(
+	startTime, endTime time.Time,
+	isImmediateReservation bool,
+	requirements parkingEntities.SpotRequirements,
+) (*parkingEntities.Parking, error) {
 	
 	allParkings, err := uc.ParkingRepository.SearchParkings()
 	if err != nil {
 		return nil, fmt.Errorf("error al obtener parkings: %w", err)
 	}
+	candidates := parkingApplication.RankParkings(*allParkings, requirements)
 
 	log.Printf("Buscando parking disponible para período %v a %v (inmediata: %v)", startTime, endTime, isImmediateReservation)
-	log.Printf("Total de parkings a evaluar: %d", len(*allParkings))
+	log.Printf("Total de parkings a evaluar: %d de %d compatibles con %+v", len(candidates), len(*allParkings), requirements)
 
 	var unavailableReasons []string
 
 	
-	for _, parking := range *allParkings {
+	for _, parking := range candidates {
 		log.Printf("Evaluando parking ID %d", parking.ID)
 
 		available, err := uc.isParkingAvailableForReservation(parking.ID, startTime, endTime, isImmediateReservation)
@@ -324,9 +572,197 @@ func (uc *ReservationUsecase) isParkingAvailableForReservation(
 		return false, nil
 	}
 
+	closure, err := uc.findParkingClosure(parking, startTime, endTime)
+	if err != nil {
+		return false, err
+	}
+	if closure != nil {
+		log.Printf("Parking %d cerrado entre %v y %v: %s", parkingID, closure.StartsAt, closure.EndsAt, closure.Reason)
+		return false, nil
+	}
+
 	return true, nil
 }
 
+func (uc *ReservationUsecase) findParkingClosure(parking *parkingEntities.Parking, startTime, endTime time.Time) (*closureEntities.ParkingClosure, error) {
+	if uc.ClosureRepository == nil {
+		return nil, nil
+	}
+	closures, err := uc.ClosureRepository.SearchClosuresBetween(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return closures.Find(parking.ID, parking.Zone, startTime, endTime), nil
+}
+
+func (uc *ReservationUsecase) ResolveClosureConflicts(closure *closureEntities.ParkingClosure) (*closureEntities.ClosureImpact, error) {
+	impact := &closureEntities.ClosureImpact{
+		Closure:   *closure,
+		Conflicts: []closureEntities.ClosureConflict{},
+	}
+	if closure.CancelledAt != nil {
+		return impact, nil
+	}
+
+	parkings, err := uc.ParkingRepository.SearchParkings()
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener parkings: %w", err)
+	}
+
+	for _, parking := range *parkings {
+		if !closure.AppliesTo(parking.ID, parking.Zone) {
+			continue
+		}
+
+		overlaps, err := uc.ReservationRepository.SearchOverlappingReservations(parking.ID, closure.StartsAt, closure.EndsAt)
+		if err != nil {
+			return nil, fmt.Errorf("error al buscar reservas afectadas: %w", err)
+		}
+
+		for i := range *overlaps {
+			conflict := uc.resolveClosureConflict(&(*overlaps)[i], closure)
+			impact.Conflicts = append(impact.Conflicts, conflict)
+		}
+	}
+
+	return impact, nil
+}
+
+func (uc *ReservationUsecase) resolveClosureConflict(reservation *entities.Reservation, closure *closureEntities.ParkingClosure) closureEntities.ClosureConflict {
+	conflict := closureEntities.ClosureConflict{
+		ReservationID: reservation.ID,
+		CustomerID:    reservation.CustomerID,
+		StartTime:     reservation.StartTime,
+		EndTime:       reservation.EndTime,
+		FromParkingID: reservation.ParkingID,
+	}
+
+	if reservation.Status == "active" {
+		used, err := uc.ReservationRepository.HasParkingUsage(reservation.ID)
+		if err != nil {
+			log.Printf("Error al verificar uso de la reserva %d: %v", reservation.ID, err)
+		}
+		if used {
+			conflict.Outcome = closureEntities.ClosureOutcomeOccupied
+			return conflict
+		}
+	}
+
+	requirements, err := uc.requirementsForReservation(reservation)
+	if err != nil {
+		log.Printf("Error al obtener requisitos de la reserva %d: %v", reservation.ID, err)
+	}
+
+	alt, err := uc.findAvailableParkingForReservation(reservation.StartTime, reservation.EndTime, reservation.Status == "active", requirements)
+	if err == nil {
+		previousParkingID := reservation.ParkingID
+		reservation.ParkingID = alt.ID
+		if err := uc.ReservationRepository.UpdateReservationByID(reservation); err == nil {
+			if reservation.Status == "active" {
+				if err := uc.activateParkingForReservation(alt.ID); err != nil {
+					log.Printf("Error al activar parking %d para la reserva %d: %v", alt.ID, reservation.ID, err)
+				}
+				if err := uc.freeParkingFromCancelledReservation(previousParkingID, reservation.ID); err != nil {
+					log.Printf("Error al liberar parking %d de la reserva %d: %v", previousParkingID, reservation.ID, err)
+				}
+			}
+
+			conflict.ToParkingID = &alt.ID
+			conflict.Outcome = closureEntities.ClosureOutcomeRelocated
+			uc.notifyClosureConflict(reservation, closure, "reservation_relocated",
+				fmt.Sprintf("Tu reserva fue reasignada al estacionamiento %s por cierre: %s", alt.Code, closure.Reason))
+			log.Printf("Reserva ID %d reasignada del parking %d al %d por cierre %d", reservation.ID, previousParkingID, alt.ID, closure.ID)
+			return conflict
+		}
+		reservation.ParkingID = previousParkingID
+		log.Printf("Error al reasignar la reserva %d: %v", reservation.ID, err)
+	}
+
+	description := fmt.Sprintf("Cancelación de reserva %d por cierre %d", reservation.ID, closure.ID)
+	if err := uc.CancelReservationWithRefund(reservation, description); err != nil {
+		log.Printf("Error al cancelar la reserva %d por cierre: %v", reservation.ID, err)
+		return conflict
+	}
+
+	conflict.Outcome = closureEntities.ClosureOutcomeCancelled
+	uc.notifyClosureConflict(reservation, closure, "reservation_cancelled_by_closure",
+		fmt.Sprintf("Tu reserva fue cancelada porque no hay estacionamientos disponibles durante el cierre: %s", closure.Reason))
+	log.Printf("Reserva ID %d cancelada por cierre %d", reservation.ID, closure.ID)
+	return conflict
+}
+
+// El cliente no eligió cancelar, así que el cupo se devuelve completo aunque falte poco para el inicio
+func (uc *ReservationUsecase) CancelReservationWithRefund(reservation *entities.Reservation, description string) error {
+	if reservation.Status != "pending" && reservation.Status != "active" {
+		return fmt.Errorf("solo se pueden cancelar reservas pendientes o activas")
+	}
+
+	previousStatus := reservation.Status
+	reservation.Status = "cancelled"
+	if err := uc.UpdateReservationById(reservation); err != nil {
+		reservation.Status = previousStatus
+		return err
+	}
+
+	if _, err := uc.QuotaUsecase.ForceRefundReservation(reservation, description); err != nil {
+		log.Printf("Error al reembolsar cuota de la reserva %d: %v", reservation.ID, err)
+	}
+	if previousStatus == "active" {
+		if err := uc.freeParkingFromCancelledReservation(reservation.ParkingID, reservation.ID); err != nil {
+			log.Printf("Error al liberar parking %d de la reserva %d: %v", reservation.ParkingID, reservation.ID, err)
+		}
+	}
+	return nil
+}
+
+func (uc *ReservationUsecase) notifyClosureConflict(reservation *entities.Reservation, closure *closureEntities.ParkingClosure, notificationType string, message string) {
+	if uc.WebSocketService == nil {
+		return
+	}
+	uc.WebSocketService.NotifyUser(reservation.CustomerID, notificationType, map[string]interface{}{
+		"reservation": reservation,
+		"closure":     closure,
+		"message":     message,
+	})
+}
+
+// Un vehículo temporal solo puede usarse en reservas que caen dentro de su vigencia
+func (uc *ReservationUsecase) checkVehicleValidity(vehicleID int, start, end time.Time) error {
+	if vehicleID == 0 {
+		return nil
+	}
+	vehicle, err := uc.SpotAllocator.VehicleRepository.SearchVehicleByID(vehicleID)
+	if err != nil {
+		return fmt.Errorf("error al obtener vehículo: %w", err)
+	}
+	if !vehicle.CoversPeriod(start, end) {
+		return fmt.Errorf("el vehículo temporal %s no está vigente durante todo el horario de la reserva", vehicle.Plate)
+	}
+	return nil
+}
+
+// Solo las reservas compartidas pueden ocupar estacionamientos de carpool, así que se conserva esa condición al reubicarlas
+func (uc *ReservationUsecase) requirementsForReservation(reservation *entities.Reservation) (parkingEntities.SpotRequirements, error) {
+	requirements, err := uc.SpotAllocator.RequirementsFor(reservation.VehicleID, reservation.CustomerID, reservation.StartTime)
+	if err != nil {
+		return requirements, err
+	}
+	parking, err := uc.ParkingRepository.SearchParkingByID(reservation.ParkingID)
+	if err != nil {
+		return requirements, err
+	}
+	requirements.IsCarpool = parking.IsCarpool
+	return requirements, nil
+}
+
+func (uc *ReservationUsecase) parkingMeetsRequirements(parkingID int, requirements parkingEntities.SpotRequirements) (bool, error) {
+	parking, err := uc.ParkingRepository.SearchParkingByID(parkingID)
+	if err != nil {
+		return false, err
+	}
+	return parkingApplication.SpotAccepts(parking, requirements), nil
+}
+
 func (uc *ReservationUsecase) freeParkingFromCancelledReservation(parkingID, reservationID int) error {
 	
 	
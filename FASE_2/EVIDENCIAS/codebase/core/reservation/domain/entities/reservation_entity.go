@@ -14,3 +14,9 @@ type Reservation struct {
 }
 
 type Reservations []Reservation
+
+type CalendarFeedToken struct {
+	UserID    string    `json:"userId"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"createdAt"`
+}
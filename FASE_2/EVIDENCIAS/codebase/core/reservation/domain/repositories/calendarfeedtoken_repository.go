@@ -0,0 +1,10 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+
+type CalendarFeedTokenRepository interface {
+	SearchCalendarFeedTokenByToken(token string) (*entities.CalendarFeedToken, error)
+	SearchCalendarFeedTokenByUserID(userID string) (*entities.CalendarFeedToken, error)
+	SaveCalendarFeedToken(feedToken *entities.CalendarFeedToken) error
+	DeleteCalendarFeedTokenByUserID(userID string) error
+}
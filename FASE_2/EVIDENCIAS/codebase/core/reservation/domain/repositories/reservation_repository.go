@@ -16,6 +16,8 @@ type ReservationRepository interface {
 	UpdateReservationByID(reservation *entities.Reservation) error
 	DeleteReservationByID(id int) error
 	SearchReservationsByStatus(statuses []string) (*entities.Reservations, error)
+	SearchReservationsByCustomerIDAndStatus(customerID string, statuses []string) (*entities.Reservations, error)
+	HasParkingUsage(reservationID int) (bool, error)
 	SearchReservationsStartingWithin(timeLimit time.Time) (*entities.Reservations, error)
 
 	SearchPendingReservationsByParkingAndTime(parkingID int, timeLimit time.Time) (*entities.Reservations, error)
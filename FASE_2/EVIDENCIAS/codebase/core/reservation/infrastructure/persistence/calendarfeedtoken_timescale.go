@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleCalendarFeedTokenRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleCalendarFeedTokenRepository(pool *pgxpool.Pool) *TimescaleCalendarFeedTokenRepository {
+	return &TimescaleCalendarFeedTokenRepository{
+		dbPool: pool,
+	}
+}
+
+func (r *TimescaleCalendarFeedTokenRepository) SearchCalendarFeedTokenByToken(token string) (*entities.CalendarFeedToken, error) {
+	return r.searchCalendarFeedToken(`SELECT user_id, token, created_at FROM reservation_calendar_feed WHERE token = $1`, token)
+}
+
+func (r *TimescaleCalendarFeedTokenRepository) SearchCalendarFeedTokenByUserID(userID string) (*entities.CalendarFeedToken, error) {
+	return r.searchCalendarFeedToken(`SELECT user_id, token, created_at FROM reservation_calendar_feed WHERE user_id = $1`, userID)
+}
+
+func (r *TimescaleCalendarFeedTokenRepository) searchCalendarFeedToken(query string, arg string) (*entities.CalendarFeedToken, error) {
+	ctx := context.Background()
+	var t entities.CalendarFeedToken
+	err := r.dbPool.QueryRow(ctx, query, arg).Scan(&t.UserID, &t.Token, &t.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *TimescaleCalendarFeedTokenRepository) SaveCalendarFeedToken(feedToken *entities.CalendarFeedToken) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO reservation_calendar_feed (user_id, token, created_at)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = EXCLUDED.created_at;
+`
+	_, err := r.dbPool.Exec(ctx, query, feedToken.UserID, feedToken.Token, feedToken.CreatedAt)
+	return err
+}
+
+func (r *TimescaleCalendarFeedTokenRepository) DeleteCalendarFeedTokenByUserID(userID string) error {
+	ctx := context.Background()
+	_, err := r.dbPool.Exec(ctx, `DELETE FROM reservation_calendar_feed WHERE user_id = $1`, userID)
+	return err
+}
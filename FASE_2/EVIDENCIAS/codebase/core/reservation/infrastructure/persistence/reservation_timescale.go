@@ -2,12 +2,11 @@ package persistence
 
 import (
 	"context"
-	"fmt"
 	"time"
 
+	"github.com/gonzalohonorato/servercorego/config/utils"
 	"github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
 	"github.com/jackc/pgx/v5"
-
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -58,14 +57,13 @@ func (r *TimescaleReservationRepository) SearchReservations() (*entities.Reserva
 func (r *TimescaleReservationRepository) SearchReservationsByDateAndStatus(dateStr string, statuses []string) (*entities.Reservations, error) {
 	ctx := context.Background()
 
-	date, err := time.Parse("2006-01-02", dateStr)
+	date, err := utils.ParseCampusDate(dateStr)
 	if err != nil {
 		return nil, err
 	}
 
 	
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	startOfDay, endOfDay := utils.CampusDayBounds(date)
 
 	
 	query := `
@@ -132,27 +130,28 @@ func (r *TimescaleReservationRepository) UpdateReservationByID(res *entities.Res
 }
 func (r *TimescaleReservationRepository) SearchReservationByUserIDAndDates(userID string, startDate, endDate string) (*entities.Reservations, error) {
 	ctx := context.Background()
-	startTime, err := time.Parse("2006-01-02", startDate)
+	startTime, err := utils.ParseCampusDate(startDate)
 	if err != nil {
 		return nil, err
 	}
 
-	endTime, err := time.Parse("2006-01-02", endDate)
+	endTime, err := utils.ParseCampusDate(endDate)
 	if err != nil {
 		return nil, err
 	}
 
 	
-	endTime = time.Date(endTime.Year(), endTime.Month(), endTime.Day(), 23, 59, 59, 999999999, time.Local)
+	_, endTime = utils.CampusDayBounds(endTime)
 
 	query := `
 		SELECT * FROM reservation 
-		WHERE customer_id = $1 
+		WHERE (customer_id = $1 OR id IN (
+			SELECT reservation_id FROM carpool_reservation WHERE $1 = ANY(passenger_ids)
+		))
 		AND created_at >= $2 
-		AND created_at <= $3
+		AND created_at < $3
 	`
-	fmt.Println("query", startTime)
-	rows, err := r.dbPool.Query(ctx, query, userID, startTime, endDate)
+	rows, err := r.dbPool.Query(ctx, query, userID, startTime, endTime)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +215,49 @@ func (r *TimescaleReservationRepository) SearchPendingReservationsByVehicleID(ve
 }
 
 
+func (r *TimescaleReservationRepository) SearchReservationsByCustomerIDAndStatus(customerID string, statuses []string) (*entities.Reservations, error) {
+	ctx := context.Background()
+
+	query := `
+        SELECT id, customer_id, parking_id, vehicle_id, start_time, end_time, status, created_at
+        FROM reservation
+        WHERE customer_id = $1 AND status = ANY($2)
+        ORDER BY start_time
+    `
+
+	rows, err := r.dbPool.Query(ctx, query, customerID, statuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := entities.Reservations{}
+	for rows.Next() {
+		var res entities.Reservation
+		if err := rows.Scan(&res.ID, &res.CustomerID, &res.ParkingID, &res.VehicleID, &res.StartTime, &res.EndTime, &res.Status, &res.CreatedAt); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, res)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &reservations, nil
+}
+
+func (r *TimescaleReservationRepository) HasParkingUsage(reservationID int) (bool, error) {
+	ctx := context.Background()
+	query := `SELECT EXISTS (SELECT 1 FROM parking_usage WHERE reservation_id = $1)`
+
+	var exists bool
+	if err := r.dbPool.QueryRow(ctx, query, reservationID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
 func (r *TimescaleReservationRepository) SearchReservationsByStatus(statuses []string) (*entities.Reservations, error) {
 	ctx := context.Background()
 
@@ -2,14 +2,27 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	bookingRuleApplication "github.com/gonzalohonorato/servercorego/core/bookingrule/application"
+	bookingRuleRepositories "github.com/gonzalohonorato/servercorego/core/bookingrule/domain/repositories"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	closureRepositories "github.com/gonzalohonorato/servercorego/core/closure/domain/repositories"
+	noShowApplication "github.com/gonzalohonorato/servercorego/core/noshow/application"
+	noShowRepositories "github.com/gonzalohonorato/servercorego/core/noshow/domain/repositories"
 	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	quotaApplication "github.com/gonzalohonorato/servercorego/core/quota/application"
+	quotaRepositories "github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/reservation/application"
 	"github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
 	"github.com/gorilla/mux"
 )
 
@@ -20,10 +33,32 @@ type ReservationController struct {
 func NewReservationController(
 	reservationRepository repositories.ReservationRepository,
 	parkingRepository parkingRepositories.ParkingRepository, 
+	userRepository userRepositories.UserRepository,
+	quotaPolicyRepository quotaRepositories.QuotaPolicyRepository,
+	quotaMovementRepository quotaRepositories.QuotaMovementRepository,
+	bookingRuleRepository bookingRuleRepositories.BookingRuleRepository,
+	noShowRepository noShowRepositories.NoShowRepository,
+	bookingSuspensionRepository noShowRepositories.BookingSuspensionRepository,
+	wsService *infrastructure.WebSocketService,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+	accessibilityPermitRepository parkingRepositories.AccessibilityPermitRepository,
+	closureRepository closureRepositories.ClosureRepository,
+	calendarEventRepository calendarRepositories.CalendarEventRepository,
 ) *ReservationController {
 	reservationUseCase := application.NewReservationUsecase(
 		reservationRepository,
 		parkingRepository, 
+		userRepository,
+		quotaPolicyRepository,
+		quotaMovementRepository,
+		bookingRuleRepository,
+		noShowRepository,
+		bookingSuspensionRepository,
+		wsService,
+		vehicleRepository,
+		accessibilityPermitRepository,
+		closureRepository,
+		calendarEventRepository,
 	)
 
 	return &ReservationController{
@@ -116,6 +151,9 @@ func (uc *ReservationController) PostReservation(w http.ResponseWriter, r *http.
 	}
 
 	if err := uc.ReservationUsecase.CreateReservation(&newReservation); err != nil {
+		if writeReservationRuleError(w, err) {
+			return
+		}
 		http.Error(w, "Error creating reservation", http.StatusInternalServerError)
 		return
 	}
@@ -123,6 +161,80 @@ func (uc *ReservationController) PostReservation(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusCreated)
 }
 
+func (uc *ReservationController) ExtendReservation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "ID de reserva inválido", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		EndTime time.Time `json:"endTime"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.EndTime.IsZero() {
+		http.Error(w, "Payload inválido", http.StatusBadRequest)
+		return
+	}
+
+	reservation, err := uc.ReservationUsecase.ExtendReservation(id, request.EndTime)
+	if err != nil {
+		var conflictErr *application.ExtensionConflictError
+		if errors.As(err, &conflictErr) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errorCode":      "EXTENSION_CONFLICT",
+				"message":        err.Error(),
+				"currentEndTime": conflictErr.CurrentEndTime,
+				"latestEndTime":  conflictErr.LatestEndTime,
+			})
+			return
+		}
+		if writeReservationRuleError(w, err) {
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error al extender reserva: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reservation)
+}
+
+func writeReservationRuleError(w http.ResponseWriter, err error) bool {
+	var ruleErr *bookingRuleApplication.BookingRuleError
+	if errors.As(err, &ruleErr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errorCode":  "BOOKING_RULE_VIOLATION",
+			"message":    err.Error(),
+			"violations": ruleErr.Violations,
+		})
+		return true
+	}
+	if errors.Is(err, noShowApplication.ErrBookingSuspended) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{
+			"errorCode": "BOOKING_SUSPENDED",
+			"message":   err.Error(),
+		})
+		return true
+	}
+	if errors.Is(err, quotaApplication.ErrQuotaExhausted) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]string{
+			"errorCode": "QUOTA_EXHAUSTED",
+			"message":   err.Error(),
+		})
+		return true
+	}
+	return false
+}
+
 func (uc *ReservationController) PutReservation(w http.ResponseWriter, r *http.Request) {
 	var newReservation entities.Reservation
 	if err := json.NewDecoder(r.Body).Decode(&newReservation); err != nil {
@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"firebase.google.com/go/v4/auth"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/reservation/application"
+	"github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+var errUnauthenticated = errors.New("se requiere un token de Firebase válido en Authorization")
+
+type ReservationFeedController struct {
+	ReservationFeedUsecase *application.ReservationFeedUsecase
+	FirebaseAuth           *auth.Client
+}
+
+func NewReservationFeedController(
+	calendarFeedTokenRepository repositories.CalendarFeedTokenRepository,
+	reservationRepository repositories.ReservationRepository,
+	parkingRepository parkingRepositories.ParkingRepository,
+	userRepository userRepositories.UserRepository,
+) *ReservationFeedController {
+	return &ReservationFeedController{
+		ReservationFeedUsecase: application.NewReservationFeedUsecase(
+			calendarFeedTokenRepository,
+			reservationRepository,
+			parkingRepository,
+			userRepository,
+		),
+	}
+}
+
+func (uc *ReservationFeedController) SetFirebaseAuth(firebaseAuth *auth.Client) {
+	uc.FirebaseAuth = firebaseAuth
+}
+
+func feedPath(token string) string {
+	return "/calendar-feeds/" + token + ".ics"
+}
+
+// Identifica al usuario a partir del ID token de Firebase enviado como "Authorization: Bearer <token>"
+func (uc *ReservationFeedController) requesterID(r *http.Request) (string, error) {
+	idToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || idToken == "" || uc.FirebaseAuth == nil {
+		return "", errUnauthenticated
+	}
+	token, err := uc.FirebaseAuth.VerifyIDToken(r.Context(), idToken)
+	if err != nil {
+		return "", errUnauthenticated
+	}
+	return token.UID, nil
+}
+
+func writeFeedError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, application.ErrFeedForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		if err != nil {
+			fmt.Println(err)
+		}
+		http.Error(w, "Calendar feed not found", http.StatusNotFound)
+	}
+}
+
+func (uc *ReservationFeedController) GetOwnCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	requesterID, err := uc.requesterID(r)
+	if err != nil {
+		writeFeedError(w, err)
+		return
+	}
+	feedToken, err := uc.ReservationFeedUsecase.SearchFeedTokenByUserID(requesterID, requesterID)
+	if err != nil || feedToken == nil {
+		writeFeedError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"userId":    feedToken.UserID,
+		"token":     feedToken.Token,
+		"feedPath":  feedPath(feedToken.Token),
+		"createdAt": feedToken.CreatedAt,
+	})
+}
+
+// Para soporte: indica si el usuario tiene un enlace activo sin revelar el token
+func (uc *ReservationFeedController) GetCalendarFeedByUserID(w http.ResponseWriter, r *http.Request) {
+	requesterID, err := uc.requesterID(r)
+	if err != nil {
+		writeFeedError(w, err)
+		return
+	}
+	feedToken, err := uc.ReservationFeedUsecase.SearchFeedTokenByUserID(requesterID, mux.Vars(r)["userID"])
+	if err != nil || feedToken == nil {
+		writeFeedError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"userId":    feedToken.UserID,
+		"active":    true,
+		"createdAt": feedToken.CreatedAt,
+	})
+}
+
+func (uc *ReservationFeedController) PostCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	requesterID, err := uc.requesterID(r)
+	if err != nil {
+		writeFeedError(w, err)
+		return
+	}
+
+	var request struct {
+		UserID string `json:"userId"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+	}
+	if request.UserID == "" {
+		request.UserID = requesterID
+	}
+
+	feedToken, err := uc.ReservationFeedUsecase.IssueFeedToken(requesterID, request.UserID)
+	if err != nil {
+		if errors.Is(err, application.ErrFeedForbidden) {
+			writeFeedError(w, err)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error al generar enlace de calendario: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"userId":    feedToken.UserID,
+		"token":     feedToken.Token,
+		"feedPath":  feedPath(feedToken.Token),
+		"createdAt": feedToken.CreatedAt,
+	})
+}
+
+func (uc *ReservationFeedController) DeleteCalendarFeedByUserID(w http.ResponseWriter, r *http.Request) {
+	requesterID, err := uc.requesterID(r)
+	if err != nil {
+		writeFeedError(w, err)
+		return
+	}
+	if err := uc.ReservationFeedUsecase.RevokeFeedToken(requesterID, mux.Vars(r)["userID"]); err != nil {
+		if errors.Is(err, application.ErrFeedForbidden) {
+			writeFeedError(w, err)
+			return
+		}
+		fmt.Println(err)
+		http.Error(w, "Error al revocar enlace de calendario", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (uc *ReservationFeedController) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := uc.ReservationFeedUsecase.BuildUserFeed(mux.Vars(r)["token"])
+	if err != nil {
+		if errors.Is(err, application.ErrFeedTokenNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		fmt.Println(err)
+		http.Error(w, "Error al generar calendario", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write([]byte(feed))
+}
+
+func (uc *ReservationFeedController) GetReservationICS(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	requesterID, err := uc.requesterID(r)
+	if err != nil {
+		writeFeedError(w, err)
+		return
+	}
+
+	ics, err := uc.ReservationFeedUsecase.BuildReservationICS(requesterID, id)
+	if err != nil {
+		if errors.Is(err, application.ErrFeedForbidden) {
+			writeFeedError(w, err)
+			return
+		}
+		fmt.Println(err)
+		http.Error(w, "Reservation not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reserva-%d.ics"`, id))
+	w.Write([]byte(ics))
+}
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"log"
+
 	"github.com/gonzalohonorato/servercorego/config/injector"
 	"github.com/gonzalohonorato/servercorego/core/reservation/infrastructure/rest/controllers"
 	"github.com/gorilla/mux"
@@ -10,6 +12,17 @@ func ReservationRoutes(router *mux.Router, container *injector.Container) {
 	controller := controllers.NewReservationController(
 		container.ProvideReservationRepository(),
 		container.ProvideParkingRepository(), 
+		container.ProvideUserRepository(),
+		container.ProvideQuotaPolicyRepository(),
+		container.ProvideQuotaMovementRepository(),
+		container.ProvideBookingRuleRepository(),
+		container.ProvideNoShowRepository(),
+		container.ProvideBookingSuspensionRepository(),
+		container.ProvideWebSocketService(),
+		container.ProvideVehicleRepository(),
+		container.ProvideAccessibilityPermitRepository(),
+		container.ProvideClosureRepository(),
+		container.ProvideCalendarEventRepository(),
 	)
 
 	router.HandleFunc("/reservations", controller.PutReservation).Methods("PUT")
@@ -20,4 +33,25 @@ func ReservationRoutes(router *mux.Router, container *injector.Container) {
 	router.HandleFunc("/reservations/{id}", controller.GetReservationByID).Methods("GET")
 	router.HandleFunc("/reservations", controller.PostReservation).Methods("POST")
 	router.HandleFunc("/reservations/{id}/status", controller.UpdateReservationStatus).Methods("PUT")
+	router.HandleFunc("/reservations/{id}/extend", controller.ExtendReservation).Methods("PUT")
+
+	feedController := controllers.NewReservationFeedController(
+		container.ProvideCalendarFeedTokenRepository(),
+		container.ProvideReservationRepository(),
+		container.ProvideParkingRepository(),
+		container.ProvideUserRepository(),
+	)
+	firebaseAuth, err := container.GetFirebaseAuth()
+	if err != nil {
+		log.Printf("Warning: Firebase Auth not initialized, calendar feeds will reject requests: %v", err)
+	} else {
+		feedController.SetFirebaseAuth(firebaseAuth)
+	}
+
+	router.HandleFunc("/reservations/{id}/ics", feedController.GetReservationICS).Methods("GET")
+	router.HandleFunc("/calendar-feeds", feedController.PostCalendarFeed).Methods("POST")
+	router.HandleFunc("/calendar-feeds/me", feedController.GetOwnCalendarFeed).Methods("GET")
+	router.HandleFunc("/calendar-feeds/{token}.ics", feedController.GetCalendarFeed).Methods("GET")
+	router.HandleFunc("/calendar-feeds/user/{userID}", feedController.GetCalendarFeedByUserID).Methods("GET")
+	router.HandleFunc("/calendar-feeds/user/{userID}", feedController.DeleteCalendarFeedByUserID).Methods("DELETE")
 }
@@ -0,0 +1,193 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+)
+
+type HolidayCalendar interface {
+	IsHoliday(day time.Time) bool
+}
+
+type FeeResult struct {
+	Amount          float64 `json:"amount"`
+	BillableMinutes int     `json:"billableMinutes"`
+	GraceApplied    bool    `json:"graceApplied"`
+	CapApplied      bool    `json:"capApplied"`
+	HolidayApplied  bool    `json:"holidayApplied"`
+	EnergyAmount    float64 `json:"energyAmount"`
+}
+
+type timeWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+func CalculateFee(tariff *entities.Tariff, entryTime, exitTime time.Time, holidays HolidayCalendar) (*FeeResult, error) {
+	result := &FeeResult{}
+	if tariff == nil || !exitTime.After(entryTime) {
+		return result, nil
+	}
+
+	if exitTime.Sub(entryTime) <= time.Duration(tariff.GraceMinutes)*time.Minute {
+		result.GraceApplied = true
+		return result, nil
+	}
+
+	loc := utils.CampusLocation()
+	entryTime = entryTime.In(loc)
+	exitTime = exitTime.In(loc)
+
+	var billable time.Duration
+	year, month, day := entryTime.Date()
+	for offset := 0; ; offset++ {
+		dayStart := startOfDay(year, month, day+offset, loc)
+		if !dayStart.Before(exitTime) {
+			break
+		}
+		nextDay := startOfDay(year, month, day+offset+1, loc)
+		date := time.Date(year, month, day+offset, 12, 0, 0, 0, loc)
+		segment := timeWindow{start: maxTime(entryTime, dayStart), end: minTime(exitTime, nextDay)}
+
+		holiday := holidays != nil && holidays.IsHoliday(date)
+		if holiday && tariff.HolidayRatePerHour > 0 {
+			result.HolidayApplied = true
+		}
+
+		dayAmount := 0.0
+		for _, band := range tariff.Bands {
+			windows, err := bandWindows(band, date, dayStart, nextDay)
+			if err != nil {
+				return nil, err
+			}
+
+			rate := band.RatePerHour
+			if holiday && tariff.HolidayRatePerHour > 0 {
+				rate = tariff.HolidayRatePerHour
+			}
+
+			for _, window := range windows {
+				overlap := overlapDuration(segment, window)
+				if overlap <= 0 {
+					continue
+				}
+				billable += overlap
+				dayAmount += overlap.Hours() * rate
+			}
+		}
+
+		if tariff.DailyCap > 0 && dayAmount > tariff.DailyCap {
+			dayAmount = tariff.DailyCap
+			result.CapApplied = true
+		}
+
+		result.Amount += dayAmount
+	}
+
+	result.Amount = math.Round(result.Amount)
+	result.BillableMinutes = int(math.Ceil(billable.Minutes()))
+	return result, nil
+}
+
+func ValidateTariffBands(bands entities.TariffBands) error {
+	var covered [24 * 60]bool
+	for _, band := range bands {
+		start, err := parseClock(band.StartTime)
+		if err != nil {
+			return err
+		}
+		end, err := parseClock(band.EndTime)
+		if err != nil {
+			return err
+		}
+		if band.RatePerHour < 0 {
+			return fmt.Errorf("la tarifa por hora no puede ser negativa")
+		}
+
+		minute := start
+		for {
+			if covered[minute] {
+				return fmt.Errorf("la franja %s-%s se superpone con otra franja", band.StartTime, band.EndTime)
+			}
+			covered[minute] = true
+			minute = (minute + 1) % len(covered)
+			if minute == end {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func bandWindows(band entities.TariffBand, date, dayStart, nextDay time.Time) ([]timeWindow, error) {
+	start, err := parseClock(band.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(band.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	bandStart := clockOn(date, start)
+	bandEnd := clockOn(date, end)
+
+	if start == end {
+		return []timeWindow{{start: dayStart, end: nextDay}}, nil
+	}
+	if end < start {
+		return []timeWindow{
+			{start: dayStart, end: bandEnd},
+			{start: bandStart, end: nextDay},
+		}, nil
+	}
+	return []timeWindow{{start: bandStart, end: bandEnd}}, nil
+}
+
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("hora inválida %q, se espera HH:MM", value)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	noon := time.Date(year, month, day, 12, 0, 0, 0, loc)
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	for start.Day() != noon.Day() {
+		start = start.Add(time.Hour)
+	}
+	return start
+}
+
+func clockOn(day time.Time, minutes int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, day.Location())
+}
+
+func overlapDuration(a, b timeWindow) time.Duration {
+	start := maxTime(a.start, b.start)
+	end := minTime(a.end, b.end)
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
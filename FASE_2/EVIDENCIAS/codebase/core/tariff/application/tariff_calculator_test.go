@@ -0,0 +1,305 @@
+package application
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	calendarApplication "github.com/gonzalohonorato/servercorego/core/calendar/application"
+	calendarEntities "github.com/gonzalohonorato/servercorego/core/calendar/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+)
+
+type fakeCalendarEventRepository struct {
+	events calendarEntities.CalendarEvents
+}
+
+func (r *fakeCalendarEventRepository) SearchCalendarEventByID(id int) (*calendarEntities.CalendarEvent, error) {
+	return nil, nil
+}
+
+func (r *fakeCalendarEventRepository) SearchCalendarEvents() (*calendarEntities.CalendarEvents, error) {
+	return &r.events, nil
+}
+
+func (r *fakeCalendarEventRepository) SearchCalendarEventsBetween(startDate, endDate string) (*calendarEntities.CalendarEvents, error) {
+	events := calendarEntities.CalendarEvents{}
+	for _, event := range r.events {
+		if event.StartDate <= endDate && event.EndDate >= startDate {
+			events = append(events, event)
+		}
+	}
+	return &events, nil
+}
+
+func (r *fakeCalendarEventRepository) SearchCalendarEventByExternalUID(uid string) (*calendarEntities.CalendarEvent, error) {
+	return nil, nil
+}
+
+func (r *fakeCalendarEventRepository) CreateCalendarEvent(event *calendarEntities.CalendarEvent) error {
+	return nil
+}
+
+func (r *fakeCalendarEventRepository) UpdateCalendarEventByID(event *calendarEntities.CalendarEvent) error {
+	return nil
+}
+
+func (r *fakeCalendarEventRepository) DeleteCalendarEventByID(id int) error {
+	return nil
+}
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("fecha de prueba inválida %q: %v", value, err)
+	}
+	return parsed
+}
+
+func flatTariff(ratePerHour float64) *entities.Tariff {
+	return &entities.Tariff{
+		GraceMinutes: 15,
+		Bands:        entities.TariffBands{{StartTime: "00:00", EndTime: "00:00", RatePerHour: ratePerHour}},
+	}
+}
+
+func TestCalculateFee(t *testing.T) {
+	dayNight := &entities.Tariff{
+		GraceMinutes: 15,
+		Bands: entities.TariffBands{
+			{StartTime: "08:00", EndTime: "18:00", RatePerHour: 1000},
+			{StartTime: "18:00", EndTime: "08:00", RatePerHour: 500},
+		},
+	}
+	capped := flatTariff(1000)
+	capped.DailyCap = 6000
+
+	holidayRate := flatTariff(1000)
+	holidayRate.HolidayRatePerHour = 2000
+	calendar := calendarApplication.NewCalendarUsecase(&fakeCalendarEventRepository{
+		events: calendarEntities.CalendarEvents{
+			{Name: "Fiestas Patrias", EventType: calendarEntities.DayTypeHoliday, StartDate: "2024-09-18", EndDate: "2024-09-18"},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		tariff          *entities.Tariff
+		entry           string
+		exit            string
+		holidays        HolidayCalendar
+		amount          float64
+		billableMinutes int
+		grace           bool
+		capApplied      bool
+		holidayApplied  bool
+	}{
+		{
+			name:   "dentro de los minutos de gracia",
+			tariff: flatTariff(1000),
+			entry:  "2024-03-12T10:00:00-03:00",
+			exit:   "2024-03-12T10:10:00-03:00",
+			grace:  true,
+		},
+		{
+			name:            "cruza medianoche",
+			tariff:          flatTariff(1000),
+			entry:           "2024-03-12T22:00:00-03:00",
+			exit:            "2024-03-13T02:00:00-03:00",
+			amount:          4000,
+			billableMinutes: 240,
+		},
+		{
+			name:            "cruza el límite entre franjas",
+			tariff:          dayNight,
+			entry:           "2024-03-12T16:00:00-03:00",
+			exit:            "2024-03-12T20:00:00-03:00",
+			amount:          3000,
+			billableMinutes: 240,
+		},
+		{
+			name:            "franja nocturna que cruza medianoche",
+			tariff:          dayNight,
+			entry:           "2024-03-12T23:00:00-03:00",
+			exit:            "2024-03-13T09:00:00-03:00",
+			amount:          5500,
+			billableMinutes: 600,
+		},
+		{
+			name:            "inicio del horario de verano sin 00:00 local",
+			tariff:          flatTariff(1000),
+			entry:           "2024-09-07T22:00:00-04:00",
+			exit:            "2024-09-08T02:00:00-03:00",
+			amount:          3000,
+			billableMinutes: 180,
+		},
+		{
+			name:            "día del cambio a verano dura 23 horas",
+			tariff:          flatTariff(1000),
+			entry:           "2024-09-08T01:00:00-03:00",
+			exit:            "2024-09-09T00:00:00-03:00",
+			amount:          23000,
+			billableMinutes: 23 * 60,
+		},
+		{
+			name:            "fin del horario de verano con hora repetida",
+			tariff:          flatTariff(1000),
+			entry:           "2024-04-06T22:00:00-03:00",
+			exit:            "2024-04-07T01:00:00-04:00",
+			amount:          4000,
+			billableMinutes: 240,
+		},
+		{
+			name:            "día del cambio a invierno dura 25 horas",
+			tariff:          flatTariff(1000),
+			entry:           "2024-04-06T00:00:00-03:00",
+			exit:            "2024-04-07T00:00:00-04:00",
+			amount:          25000,
+			billableMinutes: 25 * 60,
+		},
+		{
+			name:            "franjas en la hora repetida",
+			tariff:          dayNight,
+			entry:           "2024-04-06T17:00:00-03:00",
+			exit:            "2024-04-06T23:30:00-04:00",
+			amount:          4250,
+			billableMinutes: 450,
+		},
+		{
+			name:            "estadía de varios días con tope diario",
+			tariff:          capped,
+			entry:           "2024-03-11T10:00:00-03:00",
+			exit:            "2024-03-13T10:00:00-03:00",
+			amount:          18000,
+			billableMinutes: 48 * 60,
+			capApplied:      true,
+		},
+		{
+			name:            "tope diario se aplica por día del campus",
+			tariff:          capped,
+			entry:           "2024-03-11T20:00:00-03:00",
+			exit:            "2024-03-12T04:00:00-03:00",
+			amount:          8000,
+			billableMinutes: 480,
+		},
+		{
+			name:            "fin de semana usa la tarifa normal",
+			tariff:          holidayRate,
+			entry:           "2024-03-16T10:00:00-03:00",
+			exit:            "2024-03-16T12:00:00-03:00",
+			holidays:        calendar,
+			amount:          2000,
+			billableMinutes: 120,
+		},
+		{
+			name:            "feriado usa la tarifa de feriado",
+			tariff:          holidayRate,
+			entry:           "2024-09-18T10:00:00-03:00",
+			exit:            "2024-09-18T12:00:00-03:00",
+			holidays:        calendar,
+			amount:          4000,
+			billableMinutes: 120,
+			holidayApplied:  true,
+		},
+		{
+			name:            "estadía que sale del feriado a un día de clases",
+			tariff:          holidayRate,
+			entry:           "2024-09-18T22:00:00-03:00",
+			exit:            "2024-09-19T02:00:00-03:00",
+			holidays:        calendar,
+			amount:          6000,
+			billableMinutes: 240,
+			holidayApplied:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CalculateFee(tt.tariff, mustTime(t, tt.entry), mustTime(t, tt.exit), tt.holidays)
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if result.Amount != tt.amount {
+				t.Errorf("monto = %v, se esperaba %v", result.Amount, tt.amount)
+			}
+			if result.BillableMinutes != tt.billableMinutes {
+				t.Errorf("minutos cobrables = %d, se esperaba %d", result.BillableMinutes, tt.billableMinutes)
+			}
+			if result.GraceApplied != tt.grace {
+				t.Errorf("gracia = %v, se esperaba %v", result.GraceApplied, tt.grace)
+			}
+			if result.CapApplied != tt.capApplied {
+				t.Errorf("tope = %v, se esperaba %v", result.CapApplied, tt.capApplied)
+			}
+			if result.HolidayApplied != tt.holidayApplied {
+				t.Errorf("feriado = %v, se esperaba %v", result.HolidayApplied, tt.holidayApplied)
+			}
+		})
+	}
+}
+
+func TestValidateTariffBands(t *testing.T) {
+	tests := []struct {
+		name    string
+		bands   entities.TariffBands
+		wantErr string
+	}{
+		{
+			name: "franjas contiguas",
+			bands: entities.TariffBands{
+				{StartTime: "08:00", EndTime: "18:00", RatePerHour: 1000},
+				{StartTime: "18:00", EndTime: "08:00", RatePerHour: 500},
+			},
+		},
+		{
+			name: "superposición simple",
+			bands: entities.TariffBands{
+				{StartTime: "08:00", EndTime: "12:00", RatePerHour: 1000},
+				{StartTime: "11:00", EndTime: "14:00", RatePerHour: 800},
+			},
+			wantErr: "se superpone",
+		},
+		{
+			name: "superposición con franja que cruza medianoche",
+			bands: entities.TariffBands{
+				{StartTime: "22:00", EndTime: "06:00", RatePerHour: 500},
+				{StartTime: "05:00", EndTime: "07:00", RatePerHour: 800},
+			},
+			wantErr: "se superpone",
+		},
+		{
+			name: "día completo junto a otra franja",
+			bands: entities.TariffBands{
+				{StartTime: "00:00", EndTime: "00:00", RatePerHour: 1000},
+				{StartTime: "10:00", EndTime: "11:00", RatePerHour: 800},
+			},
+			wantErr: "se superpone",
+		},
+		{
+			name:    "hora inválida",
+			bands:   entities.TariffBands{{StartTime: "25:00", EndTime: "08:00", RatePerHour: 1000}},
+			wantErr: "hora inválida",
+		},
+		{
+			name:    "tarifa negativa",
+			bands:   entities.TariffBands{{StartTime: "08:00", EndTime: "18:00", RatePerHour: -1}},
+			wantErr: "negativa",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTariffBands(tt.bands)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("error inesperado: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, se esperaba que contuviera %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	calendarApplication "github.com/gonzalohonorato/servercorego/core/calendar/application"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	parkingUsageEntities "github.com/gonzalohonorato/servercorego/core/parkingusage/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+)
+
+const defaultCurrency = "CLP"
+
+type TariffUsecase struct {
+	TariffRepository        repositories.TariffRepository
+	ParkingChargeRepository repositories.ParkingChargeRepository
+	HolidayCalendar         HolidayCalendar
+}
+
+func NewTariffUsecase(
+	tariffRepo repositories.TariffRepository,
+	chargeRepo repositories.ParkingChargeRepository,
+	calendarRepo calendarRepositories.CalendarEventRepository,
+) *TariffUsecase {
+	return &TariffUsecase{
+		TariffRepository:        tariffRepo,
+		ParkingChargeRepository: chargeRepo,
+		HolidayCalendar:         calendarApplication.NewCalendarUsecase(calendarRepo),
+	}
+}
+
+func (uc *TariffUsecase) SearchTariffByID(id int) (*entities.Tariff, error) {
+	return uc.TariffRepository.SearchTariffByID(id)
+}
+
+func (uc *TariffUsecase) SearchTariffs() (*entities.Tariffs, error) {
+	return uc.TariffRepository.SearchTariffs()
+}
+
+func (uc *TariffUsecase) CreateTariff(tariff *entities.Tariff) error {
+	if err := validateTariff(tariff); err != nil {
+		return err
+	}
+	return uc.TariffRepository.CreateTariff(tariff)
+}
+
+func (uc *TariffUsecase) UpdateTariffByID(tariff *entities.Tariff) error {
+	if err := validateTariff(tariff); err != nil {
+		return err
+	}
+	return uc.TariffRepository.UpdateTariffByID(tariff)
+}
+
+func (uc *TariffUsecase) DeleteTariffByID(id int) error {
+	return uc.TariffRepository.DeleteTariffByID(id)
+}
+
+func (uc *TariffUsecase) SearchParkingChargesByParkingUsageID(parkingUsageID int) (*entities.ParkingCharges, error) {
+	return uc.ParkingChargeRepository.SearchParkingChargesByParkingUsageID(parkingUsageID)
+}
+
+func (uc *TariffUsecase) SearchParkingChargesByCustomerID(customerID string) (*entities.ParkingCharges, error) {
+	return uc.ParkingChargeRepository.SearchParkingChargesByCustomerID(customerID)
+}
+
+func (uc *TariffUsecase) FindTariff(zone string, customerType string) (*entities.Tariff, error) {
+	tariffs, err := uc.TariffRepository.SearchActiveTariffs()
+	if err != nil {
+		return nil, err
+	}
+	return SelectTariff(*tariffs, zone, customerType), nil
+}
+
+func (uc *TariffUsecase) QuoteFee(zone string, customerType string, entryTime, exitTime time.Time) (*entities.Tariff, *FeeResult, error) {
+	tariff, err := uc.FindTariff(zone, customerType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tariff == nil {
+		return nil, &FeeResult{}, nil
+	}
+
+	fee, err := CalculateFee(tariff, entryTime, exitTime, uc.HolidayCalendar)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tariff, fee, nil
+}
+
+func (uc *TariffUsecase) QuoteChargingFee(zone string, startedAt, endedAt time.Time, energyKWh float64) (*entities.Tariff, *FeeResult, error) {
+	tariffs, err := uc.TariffRepository.SearchActiveTariffs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Solo compiten las tarifas de carga: una tarifa general de la zona puntúa más que la de carga global
+	// y dejaría la carga gratis
+	chargingTariffs := entities.Tariffs{}
+	for _, tariff := range *tariffs {
+		if tariff.CustomerType == entities.EVChargingCustomerType {
+			chargingTariffs = append(chargingTariffs, tariff)
+		}
+	}
+	tariff := SelectTariff(chargingTariffs, zone, entities.EVChargingCustomerType)
+	if tariff == nil {
+		return nil, &FeeResult{}, nil
+	}
+
+	fee, err := CalculateFee(tariff, startedAt, endedAt, uc.HolidayCalendar)
+	if err != nil {
+		return nil, nil, err
+	}
+	if energyKWh > 0 && tariff.EnergyRatePerKWh > 0 {
+		fee.EnergyAmount = math.Round(energyKWh * tariff.EnergyRatePerKWh)
+		fee.Amount += fee.EnergyAmount
+	}
+	return tariff, fee, nil
+}
+
+// Cobra el uso hasta su hora de salida descontando lo ya cobrado: si la salida se reintenta después de un
+// primer cálculo solo se registra la diferencia. Devuelve nil cuando no queda nada nuevo que cobrar
+func (uc *TariffUsecase) PriceParkingUsage(usage *parkingUsageEntities.ParkingUsage, zone string, customerType string, customerID *string, previous entities.ParkingCharges) (*entities.ParkingCharge, error) {
+	if usage.EntryTime == nil || usage.ExitTime == nil {
+		return nil, fmt.Errorf("el uso %d no tiene hora de ingreso y salida", usage.ID)
+	}
+
+	tariff, fee, err := uc.QuoteFee(zone, customerType, *usage.EntryTime, *usage.ExitTime)
+	if err != nil {
+		return nil, err
+	}
+	if tariff == nil {
+		return nil, nil
+	}
+
+	alreadyCharged := 0.0
+	for _, charge := range previous {
+		alreadyCharged += charge.Amount
+	}
+	amount := fee.Amount - alreadyCharged
+	description := chargeDescription(tariff, fee)
+	if len(previous) > 0 {
+		if amount <= 0 {
+			return nil, nil
+		}
+		description += fmt.Sprintf(", diferencia sobre $%.0f ya cobrados", alreadyCharged)
+	}
+
+	charge := &entities.ParkingCharge{
+		ParkingUsageID:  usage.ID,
+		TariffID:        &tariff.ID,
+		CustomerID:      customerID,
+		VisitorRut:      usage.VisitorRut,
+		Amount:          amount,
+		Currency:        tariff.Currency,
+		BillableMinutes: fee.BillableMinutes,
+		Description:     description,
+		CreatedAt:       time.Now(),
+	}
+
+	if err := uc.ParkingChargeRepository.CreateParkingCharge(charge); err != nil {
+		return nil, err
+	}
+
+	return charge, nil
+}
+
+func SelectTariff(tariffs entities.Tariffs, zone string, customerType string) *entities.Tariff {
+	var selected *entities.Tariff
+	bestScore := -1
+	for i := range tariffs {
+		tariff := &tariffs[i]
+		if !tariff.IsActive {
+			continue
+		}
+		if tariff.Zone != "" && tariff.Zone != zone {
+			continue
+		}
+		if tariff.CustomerType != "" && tariff.CustomerType != customerType {
+			continue
+		}
+
+		score := 0
+		if tariff.Zone != "" {
+			score += 2
+		}
+		if tariff.CustomerType != "" {
+			score++
+		}
+
+		if score > bestScore || (score == bestScore && tariff.Priority < selected.Priority) {
+			selected = tariff
+			bestScore = score
+		}
+	}
+	return selected
+}
+
+func validateTariff(tariff *entities.Tariff) error {
+	if tariff.Name == "" {
+		return fmt.Errorf("el nombre de la tarifa es requerido")
+	}
+	if tariff.GraceMinutes < 0 {
+		return fmt.Errorf("los minutos de gracia no pueden ser negativos")
+	}
+	if tariff.DailyCap < 0 || tariff.HolidayRatePerHour < 0 || tariff.EnergyRatePerKWh < 0 {
+		return fmt.Errorf("los montos no pueden ser negativos")
+	}
+	if tariff.Currency == "" {
+		tariff.Currency = defaultCurrency
+	}
+	return ValidateTariffBands(tariff.Bands)
+}
+
+func chargeDescription(tariff *entities.Tariff, fee *FeeResult) string {
+	description := fmt.Sprintf("Tarifa %s: %d minutos", tariff.Name, fee.BillableMinutes)
+	if fee.GraceApplied {
+		description += ", dentro del periodo de gracia"
+	}
+	if fee.HolidayApplied {
+		description += ", tarifa de feriado"
+	}
+	if fee.CapApplied {
+		description += ", tope diario aplicado"
+	}
+	return description
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+)
+
+type fakeTariffRepository struct {
+	repositories.TariffRepository
+	tariffs entities.Tariffs
+}
+
+func (r *fakeTariffRepository) SearchActiveTariffs() (*entities.Tariffs, error) {
+	return &r.tariffs, nil
+}
+
+func TestQuoteChargingFee(t *testing.T) {
+	general := entities.Tariff{ID: 1, Name: "General zona A", Zone: "A", IsActive: true, Currency: "CLP",
+		Bands: entities.TariffBands{{StartTime: "00:00", EndTime: "00:00", RatePerHour: 0}}}
+	charging := entities.Tariff{ID: 2, Name: "Carga", CustomerType: entities.EVChargingCustomerType, IsActive: true, Currency: "CLP",
+		EnergyRatePerKWh: 200, Bands: entities.TariffBands{{StartTime: "00:00", EndTime: "00:00", RatePerHour: 1000}}}
+	chargingZoneB := charging
+	chargingZoneB.ID = 3
+	chargingZoneB.Zone = "B"
+	chargingZoneB.EnergyRatePerKWh = 300
+
+	tests := []struct {
+		name       string
+		tariffs    entities.Tariffs
+		zone       string
+		wantTariff int
+		wantAmount float64
+	}{
+		{"la tarifa general de la zona no gana a la de carga global", entities.Tariffs{general, charging}, "A", 2, 2000 + 10*200},
+		{"la tarifa de carga de la zona gana a la global", entities.Tariffs{general, charging, chargingZoneB}, "B", 3, 2000 + 10*300},
+		{"sin tarifa de carga no se cobra", entities.Tariffs{general}, "A", 0, 0},
+	}
+
+	startedAt := mustTime(t, "2025-03-12T10:00:00-03:00")
+	endedAt := mustTime(t, "2025-03-12T12:00:00-03:00")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &TariffUsecase{TariffRepository: &fakeTariffRepository{tariffs: tt.tariffs}}
+			tariff, fee, err := uc.QuoteChargingFee(tt.zone, startedAt, endedAt, 10)
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+
+			gotTariff := 0
+			if tariff != nil {
+				gotTariff = tariff.ID
+			}
+			if gotTariff != tt.wantTariff {
+				t.Fatalf("tarifa = %d, se esperaba %d", gotTariff, tt.wantTariff)
+			}
+			if fee.Amount != tt.wantAmount {
+				t.Errorf("monto = %.0f, se esperaba %.0f", fee.Amount, tt.wantAmount)
+			}
+		})
+	}
+}
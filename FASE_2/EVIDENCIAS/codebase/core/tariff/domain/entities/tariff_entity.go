@@ -0,0 +1,50 @@
+package entities
+
+import "time"
+
+const (
+	VisitorCustomerType    = "visitor"
+	EVChargingCustomerType = "ev_charging"
+)
+
+type Tariff struct {
+	ID                 int         `json:"id"`
+	Name               string      `json:"name"`
+	Zone               string      `json:"zone"`
+	CustomerType       string      `json:"customerType"`
+	GraceMinutes       int         `json:"graceMinutes"`
+	DailyCap           float64     `json:"dailyCap"`
+	HolidayRatePerHour float64     `json:"holidayRatePerHour"`
+	EnergyRatePerKWh   float64     `json:"energyRatePerKwh"`
+	Currency           string      `json:"currency"`
+	Priority           int         `json:"priority"`
+	IsActive           bool        `json:"isActive"`
+	Bands              TariffBands `json:"bands"`
+}
+
+type Tariffs []Tariff
+
+type TariffBand struct {
+	ID          int     `json:"id"`
+	TariffID    int     `json:"tariffId"`
+	StartTime   string  `json:"startTime"`
+	EndTime     string  `json:"endTime"`
+	RatePerHour float64 `json:"ratePerHour"`
+}
+
+type TariffBands []TariffBand
+
+type ParkingCharge struct {
+	ID              int       `json:"id"`
+	ParkingUsageID  int       `json:"parkingUsageId"`
+	TariffID        *int      `json:"tariffId"`
+	CustomerID      *string   `json:"customerId"`
+	VisitorRut      string    `json:"visitorRut"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	BillableMinutes int       `json:"billableMinutes"`
+	Description     string    `json:"description"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+type ParkingCharges []ParkingCharge
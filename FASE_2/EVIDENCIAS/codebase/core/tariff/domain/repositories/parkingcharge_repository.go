@@ -0,0 +1,10 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+
+type ParkingChargeRepository interface {
+	SearchParkingChargeByID(id int) (*entities.ParkingCharge, error)
+	SearchParkingChargesByParkingUsageID(parkingUsageID int) (*entities.ParkingCharges, error)
+	SearchParkingChargesByCustomerID(customerID string) (*entities.ParkingCharges, error)
+	CreateParkingCharge(charge *entities.ParkingCharge) error
+}
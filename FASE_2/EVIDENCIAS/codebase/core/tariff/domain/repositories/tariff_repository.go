@@ -0,0 +1,12 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+
+type TariffRepository interface {
+	SearchTariffByID(id int) (*entities.Tariff, error)
+	SearchTariffs() (*entities.Tariffs, error)
+	SearchActiveTariffs() (*entities.Tariffs, error)
+	CreateTariff(tariff *entities.Tariff) error
+	UpdateTariffByID(tariff *entities.Tariff) error
+	DeleteTariffByID(id int) error
+}
@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleParkingChargeRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleParkingChargeRepository(pool *pgxpool.Pool) *TimescaleParkingChargeRepository {
+	return &TimescaleParkingChargeRepository{
+		dbPool: pool,
+	}
+}
+
+const parkingChargeColumns = `id, parking_usage_id, tariff_id, customer_id, visitor_rut, amount, currency,
+              billable_minutes, description, created_at`
+
+func (r *TimescaleParkingChargeRepository) SearchParkingChargeByID(id int) (*entities.ParkingCharge, error) {
+	ctx := context.Background()
+	query := `SELECT ` + parkingChargeColumns + ` FROM parking_charge WHERE id = $1`
+	var c entities.ParkingCharge
+	err := r.dbPool.QueryRow(ctx, query, id).Scan(&c.ID, &c.ParkingUsageID, &c.TariffID, &c.CustomerID, &c.VisitorRut,
+		&c.Amount, &c.Currency, &c.BillableMinutes, &c.Description, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *TimescaleParkingChargeRepository) SearchParkingChargesByParkingUsageID(parkingUsageID int) (*entities.ParkingCharges, error) {
+	query := `SELECT ` + parkingChargeColumns + ` FROM parking_charge WHERE parking_usage_id = $1 ORDER BY created_at`
+	return r.searchParkingCharges(query, parkingUsageID)
+}
+
+func (r *TimescaleParkingChargeRepository) SearchParkingChargesByCustomerID(customerID string) (*entities.ParkingCharges, error) {
+	query := `SELECT ` + parkingChargeColumns + ` FROM parking_charge WHERE customer_id = $1 ORDER BY created_at DESC`
+	return r.searchParkingCharges(query, customerID)
+}
+
+func (r *TimescaleParkingChargeRepository) searchParkingCharges(query string, args ...interface{}) (*entities.ParkingCharges, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	charges := entities.ParkingCharges{}
+	for rows.Next() {
+		var c entities.ParkingCharge
+		if err := rows.Scan(&c.ID, &c.ParkingUsageID, &c.TariffID, &c.CustomerID, &c.VisitorRut,
+			&c.Amount, &c.Currency, &c.BillableMinutes, &c.Description, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		charges = append(charges, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &charges, nil
+}
+
+func (r *TimescaleParkingChargeRepository) CreateParkingCharge(charge *entities.ParkingCharge) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO parking_charge (
+		parking_usage_id, tariff_id, customer_id, visitor_rut, amount, currency,
+		billable_minutes, description, created_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		charge.ParkingUsageID, charge.TariffID, charge.CustomerID, charge.VisitorRut, charge.Amount,
+		charge.Currency, charge.BillableMinutes, charge.Description, charge.CreatedAt,
+	).Scan(&charge.ID)
+}
@@ -0,0 +1,183 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleTariffRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleTariffRepository(pool *pgxpool.Pool) *TimescaleTariffRepository {
+	return &TimescaleTariffRepository{
+		dbPool: pool,
+	}
+}
+
+const tariffColumns = `id, name, zone, customer_type, grace_minutes, daily_cap, holiday_rate_per_hour, energy_rate_per_kwh, currency, priority, is_active`
+
+func (r *TimescaleTariffRepository) SearchTariffByID(id int) (*entities.Tariff, error) {
+	tariffs, err := r.searchTariffs(`SELECT `+tariffColumns+` FROM tariff WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(*tariffs) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	return &(*tariffs)[0], nil
+}
+
+func (r *TimescaleTariffRepository) SearchTariffs() (*entities.Tariffs, error) {
+	return r.searchTariffs(`SELECT ` + tariffColumns + ` FROM tariff ORDER BY priority, id`)
+}
+
+func (r *TimescaleTariffRepository) SearchActiveTariffs() (*entities.Tariffs, error) {
+	return r.searchTariffs(`SELECT ` + tariffColumns + ` FROM tariff WHERE is_active = TRUE ORDER BY priority, id`)
+}
+
+func (r *TimescaleTariffRepository) searchTariffs(query string, args ...interface{}) (*entities.Tariffs, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tariffs := entities.Tariffs{}
+	index := make(map[int]int)
+	for rows.Next() {
+		var t entities.Tariff
+		if err := rows.Scan(&t.ID, &t.Name, &t.Zone, &t.CustomerType, &t.GraceMinutes, &t.DailyCap,
+			&t.HolidayRatePerHour, &t.EnergyRatePerKWh, &t.Currency, &t.Priority, &t.IsActive); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		t.Bands = entities.TariffBands{}
+		index[t.ID] = len(tariffs)
+		tariffs = append(tariffs, t)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(tariffs) == 0 {
+		return &tariffs, nil
+	}
+
+	ids := make([]int, 0, len(tariffs))
+	for _, t := range tariffs {
+		ids = append(ids, t.ID)
+	}
+
+	bandRows, err := r.dbPool.Query(ctx, `
+		SELECT id, tariff_id, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), rate_per_hour
+		FROM tariff_band WHERE tariff_id = ANY($1) ORDER BY tariff_id, start_time`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer bandRows.Close()
+
+	for bandRows.Next() {
+		var b entities.TariffBand
+		if err := bandRows.Scan(&b.ID, &b.TariffID, &b.StartTime, &b.EndTime, &b.RatePerHour); err != nil {
+			return nil, err
+		}
+		if i, ok := index[b.TariffID]; ok {
+			tariffs[i].Bands = append(tariffs[i].Bands, b)
+		}
+	}
+	if err = bandRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &tariffs, nil
+}
+
+func (r *TimescaleTariffRepository) CreateTariff(tariff *entities.Tariff) error {
+	ctx := context.Background()
+	tx, err := r.dbPool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	INSERT INTO tariff (
+		name, zone, customer_type, grace_minutes, daily_cap, holiday_rate_per_hour, energy_rate_per_kwh, currency, priority, is_active
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+	) RETURNING id;
+`
+	err = tx.QueryRow(ctx, query,
+		tariff.Name, tariff.Zone, tariff.CustomerType, tariff.GraceMinutes, tariff.DailyCap,
+		tariff.HolidayRatePerHour, tariff.EnergyRatePerKWh, tariff.Currency, tariff.Priority, tariff.IsActive,
+	).Scan(&tariff.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := insertTariffBands(ctx, tx, tariff); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TimescaleTariffRepository) UpdateTariffByID(tariff *entities.Tariff) error {
+	ctx := context.Background()
+	tx, err := r.dbPool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	UPDATE tariff SET
+		name = $1, zone = $2, customer_type = $3, grace_minutes = $4, daily_cap = $5,
+		holiday_rate_per_hour = $6, energy_rate_per_kwh = $7, currency = $8, priority = $9, is_active = $10
+	WHERE id = $11;
+`
+	_, err = tx.Exec(ctx, query,
+		tariff.Name, tariff.Zone, tariff.CustomerType, tariff.GraceMinutes, tariff.DailyCap,
+		tariff.HolidayRatePerHour, tariff.EnergyRatePerKWh, tariff.Currency, tariff.Priority, tariff.IsActive, tariff.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM tariff_band WHERE tariff_id = $1`, tariff.ID); err != nil {
+		return err
+	}
+
+	if err := insertTariffBands(ctx, tx, tariff); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TimescaleTariffRepository) DeleteTariffByID(id int) error {
+	ctx := context.Background()
+	query := `DELETE FROM tariff WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, id)
+	return err
+}
+
+func insertTariffBands(ctx context.Context, tx pgx.Tx, tariff *entities.Tariff) error {
+	query := `
+	INSERT INTO tariff_band (tariff_id, start_time, end_time, rate_per_hour)
+	VALUES ($1, $2::time, $3::time, $4) RETURNING id;
+`
+	for i := range tariff.Bands {
+		band := &tariff.Bands[i]
+		band.TariffID = tariff.ID
+		if err := tx.QueryRow(ctx, query, band.TariffID, band.StartTime, band.EndTime, band.RatePerHour).Scan(&band.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
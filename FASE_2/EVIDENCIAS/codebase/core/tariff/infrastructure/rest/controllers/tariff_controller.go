@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	calendarRepositories "github.com/gonzalohonorato/servercorego/core/calendar/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/tariff/application"
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/tariff/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+type TariffController struct {
+	TariffUsecase *application.TariffUsecase
+}
+
+func NewTariffController(
+	tariffRepository repositories.TariffRepository,
+	parkingChargeRepository repositories.ParkingChargeRepository,
+	calendarEventRepository calendarRepositories.CalendarEventRepository,
+) *TariffController {
+	tariffUseCase := application.NewTariffUsecase(tariffRepository, parkingChargeRepository, calendarEventRepository)
+
+	return &TariffController{
+		TariffUsecase: tariffUseCase,
+	}
+}
+
+func (uc *TariffController) GetTariffByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	tariff, err := uc.TariffUsecase.SearchTariffByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Tariff not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tariff)
+}
+
+func (uc *TariffController) GetTariffs(w http.ResponseWriter, r *http.Request) {
+	tariffs, err := uc.TariffUsecase.SearchTariffs()
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Tariffs not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tariffs)
+}
+
+func (uc *TariffController) GetTariffQuote(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	entryTime, err := utils.ParseDateTime(query.Get("entryTime"))
+	if err != nil {
+		http.Error(w, "Invalid entryTime, expected RFC3339", http.StatusBadRequest)
+		return
+	}
+	exitTime, err := utils.ParseDateTime(query.Get("exitTime"))
+	if err != nil {
+		http.Error(w, "Invalid exitTime, expected RFC3339", http.StatusBadRequest)
+		return
+	}
+
+	tariff, fee, err := uc.TariffUsecase.QuoteFee(query.Get("zone"), query.Get("customerType"), entryTime, exitTime)
+	if err != nil {
+		http.Error(w, "Error calculating fee: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tariff": tariff,
+		"fee":    fee,
+	})
+}
+
+func (uc *TariffController) PostTariff(w http.ResponseWriter, r *http.Request) {
+	var newTariff entities.Tariff
+	if err := json.NewDecoder(r.Body).Decode(&newTariff); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.TariffUsecase.CreateTariff(&newTariff); err != nil {
+		http.Error(w, "Error creating tariff: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newTariff)
+}
+
+func (uc *TariffController) PutTariff(w http.ResponseWriter, r *http.Request) {
+	var tariff entities.Tariff
+	if err := json.NewDecoder(r.Body).Decode(&tariff); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.TariffUsecase.UpdateTariffByID(&tariff); err != nil {
+		http.Error(w, "Error update tariff: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *TariffController) DeleteTariffByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.TariffUsecase.DeleteTariffByID(idInt); err != nil {
+		http.Error(w, "Error deleting tariff", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *TariffController) GetParkingChargesByParkingUsageID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	idInt, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	charges, err := uc.TariffUsecase.SearchParkingChargesByParkingUsageID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Parking charges not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(charges)
+}
+
+func (uc *TariffController) GetParkingChargesByCustomerID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	charges, err := uc.TariffUsecase.SearchParkingChargesByCustomerID(vars["customerID"])
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Parking charges not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(charges)
+}
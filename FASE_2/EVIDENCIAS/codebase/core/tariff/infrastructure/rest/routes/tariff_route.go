@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/tariff/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func TariffRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewTariffController(
+		container.ProvideTariffRepository(),
+		container.ProvideParkingChargeRepository(),
+		container.ProvideCalendarEventRepository(),
+	)
+
+	router.HandleFunc("/tariffs", controller.PutTariff).Methods("PUT")
+	router.HandleFunc("/tariffs", controller.GetTariffs).Methods("GET")
+	router.HandleFunc("/tariffs/quote", controller.GetTariffQuote).Methods("GET")
+	router.HandleFunc("/tariffs/{id}", controller.DeleteTariffByID).Methods("DELETE")
+	router.HandleFunc("/tariffs/{id}", controller.GetTariffByID).Methods("GET")
+	router.HandleFunc("/tariffs", controller.PostTariff).Methods("POST")
+
+	router.HandleFunc("/parking-charges/usage/{id}", controller.GetParkingChargesByParkingUsageID).Methods("GET")
+	router.HandleFunc("/parking-charges/customer/{customerID}", controller.GetParkingChargesByCustomerID).Methods("GET")
+}
@@ -0,0 +1,500 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	reservationApplication "github.com/gonzalohonorato/servercorego/core/reservation/application"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+)
+
+var (
+	ErrTransferNotFound  = errors.New("solicitud de transferencia no encontrada")
+	ErrTransferForbidden = errors.New("la solicitud de transferencia no corresponde a este cliente")
+	ErrTransferClosed    = errors.New("la solicitud de transferencia ya no está pendiente")
+)
+
+type TransferUsecase struct {
+	TransferRepository repositories.TransferRepository
+	WaitlistRepository repositories.WaitlistRepository
+	UserRepository     userRepositories.UserRepository
+	VehicleRepository  vehicleRepositories.VehicleRepository
+	ReservationUsecase *reservationApplication.ReservationUsecase
+	WebSocketService   *infrastructure.WebSocketService
+}
+
+func NewTransferUsecase(
+	transferRepo repositories.TransferRepository,
+	waitlistRepo repositories.WaitlistRepository,
+	userRepo userRepositories.UserRepository,
+	vehicleRepo vehicleRepositories.VehicleRepository,
+	reservationUsecase *reservationApplication.ReservationUsecase,
+	wsService *infrastructure.WebSocketService,
+) *TransferUsecase {
+	return &TransferUsecase{
+		TransferRepository: transferRepo,
+		WaitlistRepository: waitlistRepo,
+		UserRepository:     userRepo,
+		VehicleRepository:  vehicleRepo,
+		ReservationUsecase: reservationUsecase,
+		WebSocketService:   wsService,
+	}
+}
+
+func (uc *TransferUsecase) SearchTransferByID(id int) (*entities.ReservationTransfer, error) {
+	transfer, err := uc.TransferRepository.SearchTransferByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer == nil {
+		return nil, ErrTransferNotFound
+	}
+	uc.expireIfDue(transfer, time.Now())
+	return transfer, nil
+}
+
+// Incluye las ofertas abiertas a la lista de espera que calzan con alguna inscripción del cliente
+func (uc *TransferUsecase) SearchTransfersByCustomerID(customerID string) (*entities.ReservationTransfers, error) {
+	transfers, err := uc.TransferRepository.SearchTransfersByCustomerID(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range *transfers {
+		uc.expireIfDue(&(*transfers)[i], now)
+	}
+
+	open, err := uc.TransferRepository.SearchPendingWaitlistTransfers()
+	if err != nil {
+		return nil, err
+	}
+	for i := range *open {
+		transfer := &(*open)[i]
+		if transfer.FromCustomerID == customerID || uc.expireIfDue(transfer, now) {
+			continue
+		}
+		entry, err := uc.matchingWaitlistEntry(transfer, customerID)
+		if err != nil {
+			log.Printf("Error al buscar lista de espera para la transferencia %d: %v", transfer.ID, err)
+			continue
+		}
+		if entry != nil {
+			*transfers = append(*transfers, *transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+func (uc *TransferUsecase) OfferTransfer(transfer *entities.ReservationTransfer) error {
+	now := time.Now()
+	reservation, err := uc.ownedReservation(transfer.ReservationID, transfer.FromCustomerID)
+	if err != nil {
+		return err
+	}
+	if err := uc.ReservationUsecase.CheckHandOver(reservation, now); err != nil {
+		return err
+	}
+	if err := uc.checkNoPendingTransfer(reservation.ID); err != nil {
+		return err
+	}
+
+	transfer.ExpiresAt = reservation.StartTime
+	switch transfer.Kind {
+	case entities.TransferKindTransfer:
+		transfer.CounterReservationID = nil
+		if transfer.ToWaitlist == (transfer.ToCustomerID != nil && *transfer.ToCustomerID != "") {
+			return fmt.Errorf("la transferencia debe dirigirse a un cliente o a la lista de espera")
+		}
+		if transfer.ToWaitlist {
+			transfer.ToCustomerID = nil
+		} else {
+			if *transfer.ToCustomerID == transfer.FromCustomerID {
+				return fmt.Errorf("no es posible transferir una reserva a su mismo titular")
+			}
+			if _, err := uc.UserRepository.SearchUserByID(*transfer.ToCustomerID); err != nil {
+				return fmt.Errorf("cliente %s no encontrado", *transfer.ToCustomerID)
+			}
+		}
+	case entities.TransferKindSwap:
+		if transfer.CounterReservationID == nil {
+			return fmt.Errorf("el intercambio requiere la reserva de contraparte")
+		}
+		counter, err := uc.ReservationUsecase.SearchReservationByID(*transfer.CounterReservationID)
+		if err != nil || counter == nil {
+			return fmt.Errorf("reserva con ID %d no encontrada", *transfer.CounterReservationID)
+		}
+		if err := uc.ReservationUsecase.CheckSwap(reservation, counter, now); err != nil {
+			return err
+		}
+		if err := uc.checkNoPendingTransfer(counter.ID); err != nil {
+			return err
+		}
+		transfer.ToCustomerID = &counter.CustomerID
+		transfer.ToWaitlist = false
+		if counter.StartTime.Before(transfer.ExpiresAt) {
+			transfer.ExpiresAt = counter.StartTime
+		}
+	default:
+		return fmt.Errorf("tipo de solicitud inválido: %s", transfer.Kind)
+	}
+
+	transfer.VehicleID = nil
+	transfer.WaitlistEntryID = nil
+	transfer.Status = entities.TransferStatusPending
+	transfer.CreatedAt = now
+	transfer.RespondedAt = nil
+	if err := uc.TransferRepository.CreateTransfer(transfer); err != nil {
+		return err
+	}
+
+	if transfer.ToWaitlist {
+		uc.notifyWaitlist(transfer, reservation)
+	} else {
+		uc.notify(*transfer.ToCustomerID, transfer, "offered",
+			fmt.Sprintf("El cliente %s te ofrece %s", transfer.FromCustomerID, describeTransfer(transfer, reservation)))
+	}
+	uc.notify(transfer.FromCustomerID, transfer, "offered",
+		fmt.Sprintf("Publicaste %s", describeTransfer(transfer, reservation)))
+	log.Printf("Solicitud de %s %d creada para la reserva %d", transfer.Kind, transfer.ID, reservation.ID)
+	return nil
+}
+
+// Para transferencias vehicleID reemplaza el vehículo de la reserva; en intercambios se ignora
+func (uc *TransferUsecase) AcceptTransfer(id int, customerID string, vehicleID int) (*entities.ReservationTransfer, error) {
+	transfer, err := uc.pendingTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry *entities.WaitlistEntry
+	if transfer.ToWaitlist {
+		entry, err = uc.matchingWaitlistEntry(transfer, customerID)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil || customerID == transfer.FromCustomerID {
+			return nil, ErrTransferForbidden
+		}
+	} else if !transfer.IsAddressedTo(customerID) {
+		return nil, ErrTransferForbidden
+	}
+
+	reservation, err := uc.ReservationUsecase.SearchReservationByID(transfer.ReservationID)
+	if err != nil || reservation == nil {
+		return nil, fmt.Errorf("reserva con ID %d no encontrada", transfer.ReservationID)
+	}
+	if reservation.CustomerID != transfer.FromCustomerID {
+		uc.close(transfer, entities.TransferStatusExpired)
+		return nil, fmt.Errorf("la reserva %d ya no pertenece a quien ofreció la transferencia", reservation.ID)
+	}
+	if err := uc.ReservationUsecase.CheckHandOver(reservation, time.Now()); err != nil {
+		uc.close(transfer, entities.TransferStatusExpired)
+		return nil, err
+	}
+
+	var counter *reservationEntities.Reservation
+	if transfer.Kind == entities.TransferKindSwap {
+		counter, err = uc.ReservationUsecase.SearchReservationByID(*transfer.CounterReservationID)
+		if err != nil || counter == nil {
+			return nil, fmt.Errorf("reserva con ID %d no encontrada", *transfer.CounterReservationID)
+		}
+		if counter.CustomerID != customerID {
+			uc.close(transfer, entities.TransferStatusExpired)
+			return nil, fmt.Errorf("la reserva %d ya no pertenece a este cliente", counter.ID)
+		}
+	} else {
+		if vehicleID == 0 && entry != nil {
+			vehicleID = entry.VehicleID
+		}
+		if err := uc.checkVehicleOwner(vehicleID, customerID); err != nil {
+			return nil, err
+		}
+	}
+
+	// La solicitud se toma antes de mover la reserva para que dos aceptaciones simultáneas no la apliquen dos veces
+	now := time.Now()
+	claimed, err := uc.TransferRepository.AcceptPendingTransfer(transfer.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("error al aceptar la solicitud: %w", err)
+	}
+	if !claimed {
+		return nil, ErrTransferClosed
+	}
+	transfer.Status = entities.TransferStatusAccepted
+	transfer.RespondedAt = &now
+
+	if transfer.Kind == entities.TransferKindSwap {
+		err = uc.ReservationUsecase.SwapReservationSpots(reservation, counter)
+	} else {
+		err = uc.ReservationUsecase.TransferReservation(reservation, customerID, vehicleID)
+	}
+	if err != nil {
+		uc.reopen(transfer)
+		return nil, err
+	}
+
+	if transfer.Kind != entities.TransferKindSwap {
+		transfer.ToCustomerID = &customerID
+		transfer.VehicleID = &vehicleID
+		if entry != nil {
+			transfer.WaitlistEntryID = &entry.ID
+			entry.Status = entities.WaitlistStatusFulfilled
+			if err := uc.WaitlistRepository.UpdateWaitlistEntryByID(entry); err != nil {
+				log.Printf("Error al cerrar la inscripción %d de lista de espera: %v", entry.ID, err)
+			}
+		}
+		if err := uc.TransferRepository.UpdateTransferByID(transfer); err != nil {
+			log.Printf("Error al registrar el destinatario de la solicitud %d: %v", transfer.ID, err)
+		}
+	}
+
+	message := fmt.Sprintf("Se completó %s", describeTransfer(transfer, reservation))
+	uc.notify(transfer.FromCustomerID, transfer, "accepted", message)
+	uc.notify(customerID, transfer, "accepted", message)
+	log.Printf("Solicitud de %s %d aceptada por %s", transfer.Kind, transfer.ID, customerID)
+	return transfer, nil
+}
+
+func (uc *TransferUsecase) DeclineTransfer(id int, customerID string) (*entities.ReservationTransfer, error) {
+	transfer, err := uc.pendingTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+	if !transfer.IsAddressedTo(customerID) {
+		return nil, ErrTransferForbidden
+	}
+
+	uc.close(transfer, entities.TransferStatusDeclined)
+	uc.notify(transfer.FromCustomerID, transfer, "declined",
+		fmt.Sprintf("El cliente %s rechazó tu solicitud", customerID))
+	uc.notify(customerID, transfer, "declined", "Rechazaste la solicitud")
+	return transfer, nil
+}
+
+func (uc *TransferUsecase) CancelTransfer(id int, customerID string) (*entities.ReservationTransfer, error) {
+	transfer, err := uc.pendingTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromCustomerID != customerID {
+		return nil, ErrTransferForbidden
+	}
+
+	uc.close(transfer, entities.TransferStatusCancelled)
+	uc.notify(customerID, transfer, "cancelled", "Cancelaste la solicitud")
+	if transfer.ToCustomerID != nil {
+		uc.notify(*transfer.ToCustomerID, transfer, "cancelled",
+			fmt.Sprintf("El cliente %s retiró su solicitud", customerID))
+	}
+	return transfer, nil
+}
+
+func (uc *TransferUsecase) SearchWaitlistEntriesByCustomerID(customerID string) (*entities.WaitlistEntries, error) {
+	return uc.WaitlistRepository.SearchWaitlistEntriesByCustomerID(customerID)
+}
+
+func (uc *TransferUsecase) JoinWaitlist(entry *entities.WaitlistEntry) error {
+	if entry.CustomerID == "" {
+		return fmt.Errorf("el cliente es obligatorio")
+	}
+	if !entry.EndTime.After(entry.StartTime) {
+		return fmt.Errorf("la hora de término debe ser posterior a la de inicio")
+	}
+	if !entry.EndTime.After(time.Now()) {
+		return fmt.Errorf("la ventana de espera ya terminó")
+	}
+	if err := uc.checkVehicleOwner(entry.VehicleID, entry.CustomerID); err != nil {
+		return err
+	}
+
+	entry.Status = entities.WaitlistStatusWaiting
+	entry.CreatedAt = time.Now()
+	return uc.WaitlistRepository.CreateWaitlistEntry(entry)
+}
+
+func (uc *TransferUsecase) CancelWaitlistEntry(id int, customerID string) (*entities.WaitlistEntry, error) {
+	entry, err := uc.WaitlistRepository.SearchWaitlistEntryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("inscripción %d no encontrada", id)
+	}
+	if entry.CustomerID != customerID {
+		return nil, ErrTransferForbidden
+	}
+	if entry.Status != entities.WaitlistStatusWaiting {
+		return nil, fmt.Errorf("la inscripción %d ya no está en espera", id)
+	}
+
+	entry.Status = entities.WaitlistStatusCancelled
+	if err := uc.WaitlistRepository.UpdateWaitlistEntryByID(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (uc *TransferUsecase) pendingTransfer(id int) (*entities.ReservationTransfer, error) {
+	transfer, err := uc.SearchTransferByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != entities.TransferStatusPending {
+		return nil, ErrTransferClosed
+	}
+	return transfer, nil
+}
+
+func (uc *TransferUsecase) ownedReservation(reservationID int, customerID string) (*reservationEntities.Reservation, error) {
+	reservation, err := uc.ReservationUsecase.SearchReservationByID(reservationID)
+	if err != nil || reservation == nil {
+		return nil, fmt.Errorf("reserva con ID %d no encontrada", reservationID)
+	}
+	if reservation.CustomerID != customerID {
+		return nil, ErrTransferForbidden
+	}
+	return reservation, nil
+}
+
+func (uc *TransferUsecase) checkNoPendingTransfer(reservationID int) error {
+	pending, err := uc.TransferRepository.SearchPendingTransfersByReservationID(reservationID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i := range *pending {
+		if !uc.expireIfDue(&(*pending)[i], now) {
+			return fmt.Errorf("la reserva %d ya tiene una solicitud pendiente (%d)", reservationID, (*pending)[i].ID)
+		}
+	}
+	return nil
+}
+
+func (uc *TransferUsecase) checkVehicleOwner(vehicleID int, customerID string) error {
+	if vehicleID == 0 {
+		return fmt.Errorf("debe indicar el vehículo con el que usará la reserva")
+	}
+	vehicle, err := uc.VehicleRepository.SearchVehicleByID(vehicleID)
+	if err != nil || vehicle == nil {
+		return fmt.Errorf("vehículo con ID %d no encontrado", vehicleID)
+	}
+	if vehicle.CustomerID != customerID {
+		return fmt.Errorf("el vehículo %s no pertenece al cliente %s", vehicle.Plate, customerID)
+	}
+	return nil
+}
+
+func (uc *TransferUsecase) matchingWaitlistEntry(transfer *entities.ReservationTransfer, customerID string) (*entities.WaitlistEntry, error) {
+	reservation, err := uc.ReservationUsecase.SearchReservationByID(transfer.ReservationID)
+	if err != nil || reservation == nil {
+		return nil, err
+	}
+	parking, err := uc.ReservationUsecase.ParkingRepository.SearchParkingByID(reservation.ParkingID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.WaitlistRepository.SearchWaitlistEntriesByCustomerID(customerID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *entries {
+		if (*entries)[i].Covers(reservation.StartTime, reservation.EndTime, parking.Zone) {
+			return &(*entries)[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Marca como vencidas las solicitudes cuya reserva ya comenzó; devuelve true si la solicitud no sigue pendiente
+func (uc *TransferUsecase) expireIfDue(transfer *entities.ReservationTransfer, now time.Time) bool {
+	if transfer.Status != entities.TransferStatusPending {
+		return true
+	}
+	if now.Before(transfer.ExpiresAt) {
+		return false
+	}
+	uc.close(transfer, entities.TransferStatusExpired)
+	return true
+}
+
+func (uc *TransferUsecase) close(transfer *entities.ReservationTransfer, status string) {
+	now := time.Now()
+	transfer.Status = status
+	transfer.RespondedAt = &now
+	if err := uc.TransferRepository.UpdateTransferByID(transfer); err != nil {
+		log.Printf("Error al actualizar la solicitud %d a %s: %v", transfer.ID, status, err)
+	}
+}
+
+func (uc *TransferUsecase) reopen(transfer *entities.ReservationTransfer) {
+	transfer.Status = entities.TransferStatusPending
+	transfer.RespondedAt = nil
+	if err := uc.TransferRepository.UpdateTransferByID(transfer); err != nil {
+		log.Printf("Error al reabrir la solicitud %d: %v", transfer.ID, err)
+	}
+}
+
+func (uc *TransferUsecase) notifyWaitlist(transfer *entities.ReservationTransfer, reservation *reservationEntities.Reservation) {
+	if uc.WebSocketService == nil {
+		return
+	}
+	parking, err := uc.ReservationUsecase.ParkingRepository.SearchParkingByID(reservation.ParkingID)
+	if err != nil {
+		log.Printf("Error al obtener parking %d: %v", reservation.ParkingID, err)
+		return
+	}
+	entries, err := uc.WaitlistRepository.SearchWaitingEntriesCovering(reservation.StartTime, reservation.EndTime)
+	if err != nil {
+		log.Printf("Error al buscar lista de espera para la transferencia %d: %v", transfer.ID, err)
+		return
+	}
+
+	seen := map[string]bool{transfer.FromCustomerID: true}
+	customerIDs := []string{}
+	for i := range *entries {
+		entry := &(*entries)[i]
+		if seen[entry.CustomerID] || !entry.Covers(reservation.StartTime, reservation.EndTime, parking.Zone) {
+			continue
+		}
+		seen[entry.CustomerID] = true
+		customerIDs = append(customerIDs, entry.CustomerID)
+	}
+	if len(customerIDs) == 0 {
+		log.Printf("Transferencia %d publicada sin clientes en lista de espera compatibles", transfer.ID)
+		return
+	}
+
+	uc.WebSocketService.NotifyMultipleUsers(customerIDs, "reservation_transfer_offered", map[string]interface{}{
+		"transfer": transfer,
+		"message":  fmt.Sprintf("Hay una reserva disponible desde la lista de espera: %s", describeTransfer(transfer, reservation)),
+	})
+}
+
+func (uc *TransferUsecase) notify(customerID string, transfer *entities.ReservationTransfer, event string, message string) {
+	if uc.WebSocketService == nil {
+		return
+	}
+	uc.WebSocketService.NotifyUser(customerID, fmt.Sprintf("reservation_%s_%s", transfer.Kind, event), map[string]interface{}{
+		"transfer": transfer,
+		"message":  message,
+	})
+}
+
+func describeTransfer(transfer *entities.ReservationTransfer, reservation *reservationEntities.Reservation) string {
+	period := utils.InCampus(reservation.StartTime).Format("2006-01-02 15:04")
+	if transfer.Kind == entities.TransferKindSwap {
+		return fmt.Sprintf("el intercambio de estacionamiento de la reserva del %s", period)
+	}
+	return fmt.Sprintf("la transferencia de la reserva del %s", period)
+}
@@ -0,0 +1,418 @@
+package application
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	bookingRuleEntities "github.com/gonzalohonorato/servercorego/core/bookingrule/domain/entities"
+	bookingRuleRepositories "github.com/gonzalohonorato/servercorego/core/bookingrule/domain/repositories"
+	noShowEntities "github.com/gonzalohonorato/servercorego/core/noshow/domain/entities"
+	noShowRepositories "github.com/gonzalohonorato/servercorego/core/noshow/domain/repositories"
+	parkingEntities "github.com/gonzalohonorato/servercorego/core/parking/domain/entities"
+	parkingRepositories "github.com/gonzalohonorato/servercorego/core/parking/domain/repositories"
+	quotaEntities "github.com/gonzalohonorato/servercorego/core/quota/domain/entities"
+	quotaRepositories "github.com/gonzalohonorato/servercorego/core/quota/domain/repositories"
+	reservationApplication "github.com/gonzalohonorato/servercorego/core/reservation/application"
+	reservationEntities "github.com/gonzalohonorato/servercorego/core/reservation/domain/entities"
+	reservationRepositories "github.com/gonzalohonorato/servercorego/core/reservation/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/repositories"
+	userEntities "github.com/gonzalohonorato/servercorego/core/user/domain/entities"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleEntities "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+)
+
+type fakeTransferRepository struct {
+	repositories.TransferRepository
+	mu        sync.Mutex
+	transfers map[int]entities.ReservationTransfer
+}
+
+func (r *fakeTransferRepository) SearchTransferByID(id int) (*entities.ReservationTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	transfer, ok := r.transfers[id]
+	if !ok {
+		return nil, nil
+	}
+	return &transfer, nil
+}
+
+func (r *fakeTransferRepository) UpdateTransferByID(transfer *entities.ReservationTransfer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.transfers[transfer.ID] = *transfer
+	return nil
+}
+
+func (r *fakeTransferRepository) AcceptPendingTransfer(id int, respondedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	transfer := r.transfers[id]
+	if transfer.Status != entities.TransferStatusPending {
+		return false, nil
+	}
+	transfer.Status = entities.TransferStatusAccepted
+	transfer.RespondedAt = &respondedAt
+	r.transfers[id] = transfer
+	return true, nil
+}
+
+func (r *fakeTransferRepository) status(id int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.transfers[id].Status
+}
+
+type fakeReservationRepository struct {
+	reservationRepositories.ReservationRepository
+	mu           sync.Mutex
+	reservations map[int]reservationEntities.Reservation
+	updates      int
+}
+
+func (r *fakeReservationRepository) SearchReservationByID(id int) (*reservationEntities.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reservation, ok := r.reservations[id]
+	if !ok {
+		return nil, nil
+	}
+	return &reservation, nil
+}
+
+func (r *fakeReservationRepository) UpdateReservationByID(reservation *reservationEntities.Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reservations[reservation.ID] = *reservation
+	r.updates++
+	return nil
+}
+
+func (r *fakeReservationRepository) HasParkingUsage(reservationID int) (bool, error) {
+	return false, nil
+}
+
+type fakeParkingRepository struct {
+	parkingRepositories.ParkingRepository
+}
+
+func (r *fakeParkingRepository) SearchParkingByID(id int) (*parkingEntities.Parking, error) {
+	return &parkingEntities.Parking{ID: id, Code: "A-01", Zone: "A", SpotType: parkingEntities.SpotTypeCar}, nil
+}
+
+type fakePermitRepository struct {
+	parkingRepositories.AccessibilityPermitRepository
+}
+
+func (r *fakePermitRepository) SearchActiveAccessibilityPermit(customerID string, at time.Time) (*parkingEntities.AccessibilityPermit, error) {
+	return nil, nil
+}
+
+type fakeVehicleRepository struct {
+	vehicleRepositories.VehicleRepository
+}
+
+var vehicleOwners = map[int]string{1: "ana", 2: "bruno", 3: "carla"}
+
+func (r *fakeVehicleRepository) SearchVehicleByID(id int) (*vehicleEntities.Vehicle, error) {
+	return &vehicleEntities.Vehicle{ID: id, Plate: "BCDF10", CustomerID: vehicleOwners[id], VehicleType: parkingEntities.SpotTypeCar}, nil
+}
+
+type fakeUserRepository struct {
+	userRepositories.UserRepository
+}
+
+func (r *fakeUserRepository) SearchUserByID(id string) (*userEntities.User, error) {
+	return &userEntities.User{ID: id}, nil
+}
+
+type fakeBookingRuleRepository struct {
+	bookingRuleRepositories.BookingRuleRepository
+}
+
+func (r *fakeBookingRuleRepository) SearchActiveBookingRules() (*bookingRuleEntities.BookingRules, error) {
+	return &bookingRuleEntities.BookingRules{}, nil
+}
+
+type fakeSuspensionRepository struct {
+	noShowRepositories.BookingSuspensionRepository
+}
+
+func (r *fakeSuspensionRepository) SearchActiveBookingSuspension(customerID string, at time.Time) (*noShowEntities.BookingSuspension, error) {
+	return nil, nil
+}
+
+type fakeQuotaMovementRepository struct {
+	quotaRepositories.QuotaMovementRepository
+	err error
+}
+
+func (r *fakeQuotaMovementRepository) SearchQuotaMovementsByReservationID(reservationID int) (*quotaEntities.QuotaMovements, error) {
+	return &quotaEntities.QuotaMovements{}, r.err
+}
+
+type transferFixture struct {
+	usecase      *TransferUsecase
+	transfers    *fakeTransferRepository
+	reservations *fakeReservationRepository
+	movements    *fakeQuotaMovementRepository
+}
+
+func newTransferFixture() *transferFixture {
+	start := time.Now().Add(24 * time.Hour)
+	to := "bruno"
+	transfers := &fakeTransferRepository{transfers: map[int]entities.ReservationTransfer{
+		1: {
+			ID:             1,
+			Kind:           entities.TransferKindTransfer,
+			ReservationID:  10,
+			FromCustomerID: "ana",
+			ToCustomerID:   &to,
+			Status:         entities.TransferStatusPending,
+			ExpiresAt:      start,
+		},
+	}}
+	reservations := &fakeReservationRepository{reservations: map[int]reservationEntities.Reservation{
+		10: {ID: 10, CustomerID: "ana", VehicleID: 1, ParkingID: 1, StartTime: start, EndTime: start.Add(2 * time.Hour), Status: "pending"},
+	}}
+	movements := &fakeQuotaMovementRepository{}
+	vehicles := &fakeVehicleRepository{}
+	users := &fakeUserRepository{}
+
+	reservationUsecase := reservationApplication.NewReservationUsecase(
+		reservations,
+		&fakeParkingRepository{},
+		users,
+		nil,
+		movements,
+		&fakeBookingRuleRepository{},
+		nil,
+		&fakeSuspensionRepository{},
+		nil,
+		vehicles,
+		&fakePermitRepository{},
+		nil,
+		nil,
+	)
+
+	return &transferFixture{
+		usecase:      NewTransferUsecase(transfers, nil, users, vehicles, reservationUsecase, nil),
+		transfers:    transfers,
+		reservations: reservations,
+		movements:    movements,
+	}
+}
+
+func TestAcceptTransferMovesReservation(t *testing.T) {
+	fixture := newTransferFixture()
+
+	transfer, err := fixture.usecase.AcceptTransfer(1, "bruno", 2)
+	if err != nil {
+		t.Fatalf("error inesperado al aceptar: %v", err)
+	}
+	if transfer.Status != entities.TransferStatusAccepted || fixture.transfers.status(1) != entities.TransferStatusAccepted {
+		t.Fatalf("la solicitud debería quedar aceptada, obtuvo %s", fixture.transfers.status(1))
+	}
+	if owner := fixture.reservations.reservations[10].CustomerID; owner != "bruno" {
+		t.Fatalf("la reserva debería pertenecer a bruno, pertenece a %s", owner)
+	}
+	if _, err := fixture.usecase.AcceptTransfer(1, "bruno", 2); !errors.Is(err, ErrTransferClosed) {
+		t.Fatalf("una solicitud aceptada no debería aceptarse otra vez, obtuvo %v", err)
+	}
+}
+
+func TestAcceptTransferConcurrentAppliesOnce(t *testing.T) {
+	fixture := newTransferFixture()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted, closed := 0, 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := fixture.usecase.AcceptTransfer(1, "bruno", 2)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				accepted++
+			case errors.Is(err, ErrTransferClosed):
+				closed++
+			default:
+				t.Errorf("error inesperado: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if accepted != 1 || closed != 7 {
+		t.Fatalf("solo una aceptación debería aplicarse: %d aceptadas, %d cerradas", accepted, closed)
+	}
+	if fixture.reservations.updates != 1 {
+		t.Fatalf("la reserva debería moverse una sola vez, se actualizó %d veces", fixture.reservations.updates)
+	}
+}
+
+func TestAcceptTransferRejections(t *testing.T) {
+	tests := []struct {
+		name       string
+		prepare    func(*transferFixture)
+		customerID string
+		vehicleID  int
+		wantErr    error
+		wantStatus string
+		wantOwner  string
+	}{
+		{
+			name:       "cliente que no es el destinatario",
+			customerID: "carla",
+			vehicleID:  3,
+			wantErr:    ErrTransferForbidden,
+			wantStatus: entities.TransferStatusPending,
+			wantOwner:  "ana",
+		},
+		{
+			name: "solicitud ya rechazada",
+			prepare: func(f *transferFixture) {
+				transfer := f.transfers.transfers[1]
+				transfer.Status = entities.TransferStatusDeclined
+				f.transfers.transfers[1] = transfer
+			},
+			customerID: "bruno",
+			vehicleID:  2,
+			wantErr:    ErrTransferClosed,
+			wantStatus: entities.TransferStatusDeclined,
+			wantOwner:  "ana",
+		},
+		{
+			name: "reserva que ya cambió de titular",
+			prepare: func(f *transferFixture) {
+				reservation := f.reservations.reservations[10]
+				reservation.CustomerID = "carla"
+				f.reservations.reservations[10] = reservation
+			},
+			customerID: "bruno",
+			vehicleID:  2,
+			wantStatus: entities.TransferStatusExpired,
+			wantOwner:  "carla",
+		},
+		{
+			name:       "vehículo de otro cliente",
+			customerID: "bruno",
+			vehicleID:  3,
+			wantStatus: entities.TransferStatusPending,
+			wantOwner:  "ana",
+		},
+		{
+			name: "fallo al traspasar la cuota",
+			prepare: func(f *transferFixture) {
+				f.movements.err = errors.New("sin conexión")
+			},
+			customerID: "bruno",
+			vehicleID:  2,
+			wantStatus: entities.TransferStatusPending,
+			wantOwner:  "ana",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newTransferFixture()
+			if tt.prepare != nil {
+				tt.prepare(fixture)
+			}
+
+			_, err := fixture.usecase.AcceptTransfer(1, tt.customerID, tt.vehicleID)
+			if err == nil {
+				t.Fatal("se esperaba un error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("se esperaba %v, obtuvo %v", tt.wantErr, err)
+			}
+			if status := fixture.transfers.status(1); status != tt.wantStatus {
+				t.Fatalf("la solicitud debería quedar %s, quedó %s", tt.wantStatus, status)
+			}
+			if owner := fixture.reservations.reservations[10].CustomerID; owner != tt.wantOwner {
+				t.Fatalf("la reserva debería pertenecer a %s, pertenece a %s", tt.wantOwner, owner)
+			}
+		})
+	}
+}
+
+func TestDeclineAndCancelTransfer(t *testing.T) {
+	tests := []struct {
+		name       string
+		act        func(*TransferUsecase) error
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			name: "el destinatario rechaza",
+			act: func(uc *TransferUsecase) error {
+				_, err := uc.DeclineTransfer(1, "bruno")
+				return err
+			},
+			wantStatus: entities.TransferStatusDeclined,
+		},
+		{
+			name: "un tercero no puede rechazar",
+			act: func(uc *TransferUsecase) error {
+				_, err := uc.DeclineTransfer(1, "carla")
+				return err
+			},
+			wantErr:    ErrTransferForbidden,
+			wantStatus: entities.TransferStatusPending,
+		},
+		{
+			name: "quien ofrece cancela",
+			act: func(uc *TransferUsecase) error {
+				_, err := uc.CancelTransfer(1, "ana")
+				return err
+			},
+			wantStatus: entities.TransferStatusCancelled,
+		},
+		{
+			name: "el destinatario no puede cancelar",
+			act: func(uc *TransferUsecase) error {
+				_, err := uc.CancelTransfer(1, "bruno")
+				return err
+			},
+			wantErr:    ErrTransferForbidden,
+			wantStatus: entities.TransferStatusPending,
+		},
+		{
+			name: "no se acepta tras cancelar",
+			act: func(uc *TransferUsecase) error {
+				if _, err := uc.CancelTransfer(1, "ana"); err != nil {
+					return err
+				}
+				_, err := uc.AcceptTransfer(1, "bruno", 2)
+				return err
+			},
+			wantErr:    ErrTransferClosed,
+			wantStatus: entities.TransferStatusCancelled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newTransferFixture()
+
+			err := tt.act(fixture.usecase)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("se esperaba %v, obtuvo %v", tt.wantErr, err)
+			}
+			if status := fixture.transfers.status(1); status != tt.wantStatus {
+				t.Fatalf("la solicitud debería quedar %s, quedó %s", tt.wantStatus, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package entities
+
+import "time"
+
+const (
+	TransferKindTransfer = "transfer"
+	TransferKindSwap     = "swap"
+
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+	TransferStatusExpired   = "expired"
+
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusFulfilled = "fulfilled"
+	WaitlistStatusCancelled = "cancelled"
+)
+
+type ReservationTransfer struct {
+	ID                   int        `json:"id"`
+	Kind                 string     `json:"kind"`
+	ReservationID        int        `json:"reservationId"`
+	CounterReservationID *int       `json:"counterReservationId"`
+	FromCustomerID       string     `json:"fromCustomerId"`
+	ToCustomerID         *string    `json:"toCustomerId"`
+	ToWaitlist           bool       `json:"toWaitlist"`
+	VehicleID            *int       `json:"vehicleId"`
+	WaitlistEntryID      *int       `json:"waitlistEntryId"`
+	Message              string     `json:"message"`
+	Status               string     `json:"status"`
+	ExpiresAt            time.Time  `json:"expiresAt"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	RespondedAt          *time.Time `json:"respondedAt"`
+}
+
+type ReservationTransfers []ReservationTransfer
+
+func (t *ReservationTransfer) IsAddressedTo(customerID string) bool {
+	return t.ToCustomerID != nil && *t.ToCustomerID == customerID
+}
+
+type WaitlistEntry struct {
+	ID         int       `json:"id"`
+	CustomerID string    `json:"customerId"`
+	VehicleID  int       `json:"vehicleId"`
+	Zone       string    `json:"zone"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type WaitlistEntries []WaitlistEntry
+
+// La entrada acepta una reserva cuyo horario cae dentro de su ventana y, si indica zona, en esa zona
+func (e *WaitlistEntry) Covers(start, end time.Time, zone string) bool {
+	if e.Status != WaitlistStatusWaiting {
+		return false
+	}
+	if e.Zone != "" && e.Zone != zone {
+		return false
+	}
+	return !e.StartTime.After(start) && !e.EndTime.Before(end)
+}
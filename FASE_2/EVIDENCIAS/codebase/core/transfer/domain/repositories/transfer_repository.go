@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/entities"
+)
+
+type TransferRepository interface {
+	SearchTransferByID(id int) (*entities.ReservationTransfer, error)
+	SearchTransfersByCustomerID(customerID string) (*entities.ReservationTransfers, error)
+	SearchPendingTransfersByReservationID(reservationID int) (*entities.ReservationTransfers, error)
+	SearchPendingWaitlistTransfers() (*entities.ReservationTransfers, error)
+	CreateTransfer(transfer *entities.ReservationTransfer) error
+	UpdateTransferByID(transfer *entities.ReservationTransfer) error
+	AcceptPendingTransfer(id int, respondedAt time.Time) (bool, error)
+}
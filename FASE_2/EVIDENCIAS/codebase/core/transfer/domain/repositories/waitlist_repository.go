@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/entities"
+)
+
+type WaitlistRepository interface {
+	SearchWaitlistEntryByID(id int) (*entities.WaitlistEntry, error)
+	SearchWaitlistEntriesByCustomerID(customerID string) (*entities.WaitlistEntries, error)
+	SearchWaitingEntriesCovering(start, end time.Time) (*entities.WaitlistEntries, error)
+	CreateWaitlistEntry(entry *entities.WaitlistEntry) error
+	UpdateWaitlistEntryByID(entry *entities.WaitlistEntry) error
+}
@@ -0,0 +1,125 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleTransferRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleTransferRepository(pool *pgxpool.Pool) *TimescaleTransferRepository {
+	return &TimescaleTransferRepository{
+		dbPool: pool,
+	}
+}
+
+const transferColumns = `id, kind, reservation_id, counter_reservation_id, from_customer_id, to_customer_id, to_waitlist,
+	vehicle_id, waitlist_entry_id, message, status, expires_at, created_at, responded_at`
+
+func scanTransfer(row pgx.Row, t *entities.ReservationTransfer) error {
+	return row.Scan(&t.ID, &t.Kind, &t.ReservationID, &t.CounterReservationID, &t.FromCustomerID, &t.ToCustomerID,
+		&t.ToWaitlist, &t.VehicleID, &t.WaitlistEntryID, &t.Message, &t.Status, &t.ExpiresAt, &t.CreatedAt, &t.RespondedAt)
+}
+
+func (r *TimescaleTransferRepository) SearchTransferByID(id int) (*entities.ReservationTransfer, error) {
+	ctx := context.Background()
+	query := `SELECT ` + transferColumns + ` FROM reservation_transfer WHERE id = $1`
+	var t entities.ReservationTransfer
+	if err := scanTransfer(r.dbPool.QueryRow(ctx, query, id), &t); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *TimescaleTransferRepository) SearchTransfersByCustomerID(customerID string) (*entities.ReservationTransfers, error) {
+	query := `SELECT ` + transferColumns + ` FROM reservation_transfer
+	WHERE from_customer_id = $1 OR to_customer_id = $1
+	ORDER BY created_at DESC`
+	return r.searchTransfers(query, customerID)
+}
+
+func (r *TimescaleTransferRepository) SearchPendingTransfersByReservationID(reservationID int) (*entities.ReservationTransfers, error) {
+	query := `SELECT ` + transferColumns + ` FROM reservation_transfer
+	WHERE status = 'pending' AND (reservation_id = $1 OR counter_reservation_id = $1)
+	ORDER BY created_at`
+	return r.searchTransfers(query, reservationID)
+}
+
+func (r *TimescaleTransferRepository) SearchPendingWaitlistTransfers() (*entities.ReservationTransfers, error) {
+	query := `SELECT ` + transferColumns + ` FROM reservation_transfer
+	WHERE status = 'pending' AND to_waitlist
+	ORDER BY created_at`
+	return r.searchTransfers(query)
+}
+
+func (r *TimescaleTransferRepository) searchTransfers(query string, args ...interface{}) (*entities.ReservationTransfers, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := entities.ReservationTransfers{}
+	for rows.Next() {
+		var t entities.ReservationTransfer
+		if err := scanTransfer(rows, &t); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &transfers, nil
+}
+
+func (r *TimescaleTransferRepository) CreateTransfer(transfer *entities.ReservationTransfer) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO reservation_transfer (
+		kind, reservation_id, counter_reservation_id, from_customer_id, to_customer_id, to_waitlist,
+		vehicle_id, waitlist_entry_id, message, status, expires_at, created_at, responded_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		transfer.Kind, transfer.ReservationID, transfer.CounterReservationID, transfer.FromCustomerID,
+		transfer.ToCustomerID, transfer.ToWaitlist, transfer.VehicleID, transfer.WaitlistEntryID,
+		transfer.Message, transfer.Status, transfer.ExpiresAt, transfer.CreatedAt, transfer.RespondedAt,
+	).Scan(&transfer.ID)
+}
+
+func (r *TimescaleTransferRepository) UpdateTransferByID(transfer *entities.ReservationTransfer) error {
+	ctx := context.Background()
+	query := `UPDATE reservation_transfer SET
+		to_customer_id = $2, vehicle_id = $3, waitlist_entry_id = $4, status = $5, responded_at = $6
+	WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query,
+		transfer.ID, transfer.ToCustomerID, transfer.VehicleID, transfer.WaitlistEntryID,
+		transfer.Status, transfer.RespondedAt,
+	)
+	return err
+}
+
+// Solo una aceptación puede pasar la solicitud de pendiente a aceptada; devuelve false si otra la tomó antes
+func (r *TimescaleTransferRepository) AcceptPendingTransfer(id int, respondedAt time.Time) (bool, error) {
+	ctx := context.Background()
+	query := `UPDATE reservation_transfer SET status = $2, responded_at = $3 WHERE id = $1 AND status = $4`
+	tag, err := r.dbPool.Exec(ctx, query, id, entities.TransferStatusAccepted, respondedAt, entities.TransferStatusPending)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleWaitlistRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleWaitlistRepository(pool *pgxpool.Pool) *TimescaleWaitlistRepository {
+	return &TimescaleWaitlistRepository{
+		dbPool: pool,
+	}
+}
+
+const waitlistColumns = `id, customer_id, vehicle_id, zone, start_time, end_time, status, created_at`
+
+func (r *TimescaleWaitlistRepository) SearchWaitlistEntryByID(id int) (*entities.WaitlistEntry, error) {
+	ctx := context.Background()
+	query := `SELECT ` + waitlistColumns + ` FROM reservation_waitlist WHERE id = $1`
+	var e entities.WaitlistEntry
+	err := r.dbPool.QueryRow(ctx, query, id).Scan(&e.ID, &e.CustomerID, &e.VehicleID, &e.Zone,
+		&e.StartTime, &e.EndTime, &e.Status, &e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *TimescaleWaitlistRepository) SearchWaitlistEntriesByCustomerID(customerID string) (*entities.WaitlistEntries, error) {
+	query := `SELECT ` + waitlistColumns + ` FROM reservation_waitlist
+	WHERE customer_id = $1
+	ORDER BY start_time DESC`
+	return r.searchEntries(query, customerID)
+}
+
+func (r *TimescaleWaitlistRepository) SearchWaitingEntriesCovering(start, end time.Time) (*entities.WaitlistEntries, error) {
+	query := `SELECT ` + waitlistColumns + ` FROM reservation_waitlist
+	WHERE status = 'waiting' AND start_time <= $1 AND end_time >= $2
+	ORDER BY created_at`
+	return r.searchEntries(query, start, end)
+}
+
+func (r *TimescaleWaitlistRepository) searchEntries(query string, args ...interface{}) (*entities.WaitlistEntries, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := entities.WaitlistEntries{}
+	for rows.Next() {
+		var e entities.WaitlistEntry
+		if err := rows.Scan(&e.ID, &e.CustomerID, &e.VehicleID, &e.Zone,
+			&e.StartTime, &e.EndTime, &e.Status, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &entries, nil
+}
+
+func (r *TimescaleWaitlistRepository) CreateWaitlistEntry(entry *entities.WaitlistEntry) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO reservation_waitlist (
+		customer_id, vehicle_id, zone, start_time, end_time, status, created_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7
+	) RETURNING id;
+`
+	return r.dbPool.QueryRow(ctx, query,
+		entry.CustomerID, entry.VehicleID, entry.Zone, entry.StartTime, entry.EndTime, entry.Status, entry.CreatedAt,
+	).Scan(&entry.ID)
+}
+
+func (r *TimescaleWaitlistRepository) UpdateWaitlistEntryByID(entry *entities.WaitlistEntry) error {
+	ctx := context.Background()
+	query := `UPDATE reservation_waitlist SET status = $2 WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, entry.ID, entry.Status)
+	return err
+}
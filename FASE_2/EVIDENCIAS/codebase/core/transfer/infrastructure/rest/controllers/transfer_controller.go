@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	bookingRuleApplication "github.com/gonzalohonorato/servercorego/core/bookingrule/application"
+	noShowApplication "github.com/gonzalohonorato/servercorego/core/noshow/application"
+	quotaApplication "github.com/gonzalohonorato/servercorego/core/quota/application"
+	reservationApplication "github.com/gonzalohonorato/servercorego/core/reservation/application"
+	"github.com/gonzalohonorato/servercorego/core/transfer/application"
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/transfer/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
+	"github.com/gorilla/mux"
+)
+
+type TransferController struct {
+	TransferUsecase *application.TransferUsecase
+}
+
+func NewTransferController(
+	transferRepository repositories.TransferRepository,
+	waitlistRepository repositories.WaitlistRepository,
+	userRepository userRepositories.UserRepository,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+	reservationUsecase *reservationApplication.ReservationUsecase,
+	wsService *infrastructure.WebSocketService,
+) *TransferController {
+	transferUseCase := application.NewTransferUsecase(
+		transferRepository,
+		waitlistRepository,
+		userRepository,
+		vehicleRepository,
+		reservationUsecase,
+		wsService,
+	)
+
+	return &TransferController{
+		TransferUsecase: transferUseCase,
+	}
+}
+
+func (uc *TransferController) GetTransferByID(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	transfer, err := uc.TransferUsecase.SearchTransferByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Transfer not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transfer)
+}
+
+func (uc *TransferController) GetTransfers(w http.ResponseWriter, r *http.Request) {
+	customerID := r.URL.Query().Get("customerId")
+	if customerID == "" {
+		http.Error(w, "customerId query parameter is required", http.StatusBadRequest)
+		return
+	}
+	transfers, err := uc.TransferUsecase.SearchTransfersByCustomerID(customerID)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Transfers not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transfers)
+}
+
+func (uc *TransferController) PostTransfer(w http.ResponseWriter, r *http.Request) {
+	var transfer entities.ReservationTransfer
+	if err := json.NewDecoder(r.Body).Decode(&transfer); err != nil || transfer.FromCustomerID == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if transfer.Kind == "" {
+		transfer.Kind = entities.TransferKindTransfer
+	}
+
+	if err := uc.TransferUsecase.OfferTransfer(&transfer); err != nil {
+		writeTransferError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+func (uc *TransferController) PostTransferAccept(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		CustomerID string `json:"customerId"`
+		VehicleID  int    `json:"vehicleId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.CustomerID == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := uc.TransferUsecase.AcceptTransfer(idInt, request.CustomerID, request.VehicleID)
+	if err != nil {
+		writeTransferError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transfer)
+}
+
+func (uc *TransferController) PostTransferDecline(w http.ResponseWriter, r *http.Request) {
+	uc.respondTransfer(w, r, uc.TransferUsecase.DeclineTransfer)
+}
+
+func (uc *TransferController) PostTransferCancel(w http.ResponseWriter, r *http.Request) {
+	uc.respondTransfer(w, r, uc.TransferUsecase.CancelTransfer)
+}
+
+func (uc *TransferController) respondTransfer(w http.ResponseWriter, r *http.Request, respond func(int, string) (*entities.ReservationTransfer, error)) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		CustomerID string `json:"customerId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.CustomerID == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := respond(idInt, request.CustomerID)
+	if err != nil {
+		writeTransferError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transfer)
+}
+
+func (uc *TransferController) GetWaitlistEntries(w http.ResponseWriter, r *http.Request) {
+	customerID := r.URL.Query().Get("customerId")
+	if customerID == "" {
+		http.Error(w, "customerId query parameter is required", http.StatusBadRequest)
+		return
+	}
+	entries, err := uc.TransferUsecase.SearchWaitlistEntriesByCustomerID(customerID)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Waitlist entries not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (uc *TransferController) PostWaitlistEntry(w http.ResponseWriter, r *http.Request) {
+	var entry entities.WaitlistEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.TransferUsecase.JoinWaitlist(&entry); err != nil {
+		http.Error(w, "Error joining waitlist: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (uc *TransferController) PostWaitlistEntryCancel(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		CustomerID string `json:"customerId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.CustomerID == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := uc.TransferUsecase.CancelWaitlistEntry(idInt, request.CustomerID)
+	if err != nil {
+		writeTransferError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
+func writeTransferError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	errorCode := "TRANSFER_INVALID"
+
+	var ruleErr *bookingRuleApplication.BookingRuleError
+	switch {
+	case errors.Is(err, application.ErrTransferNotFound):
+		status, errorCode = http.StatusNotFound, "TRANSFER_NOT_FOUND"
+	case errors.Is(err, application.ErrTransferForbidden):
+		status, errorCode = http.StatusForbidden, "TRANSFER_FORBIDDEN"
+	case errors.Is(err, application.ErrTransferClosed):
+		status, errorCode = http.StatusConflict, "TRANSFER_CLOSED"
+	case errors.As(err, &ruleErr):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errorCode":  "BOOKING_RULE_VIOLATION",
+			"message":    err.Error(),
+			"violations": ruleErr.Violations,
+		})
+		return
+	case errors.Is(err, noShowApplication.ErrBookingSuspended):
+		status, errorCode = http.StatusForbidden, "BOOKING_SUSPENDED"
+	case errors.Is(err, quotaApplication.ErrQuotaExhausted):
+		status, errorCode = http.StatusConflict, "QUOTA_EXHAUSTED"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"errorCode": errorCode,
+		"message":   err.Error(),
+	})
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/transfer/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func TransferRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewTransferController(
+		container.ProvideTransferRepository(),
+		container.ProvideWaitlistRepository(),
+		container.ProvideUserRepository(),
+		container.ProvideVehicleRepository(),
+		container.ProvideReservationUsecase(),
+		container.ProvideWebSocketService(),
+	)
+
+	router.HandleFunc("/reservation-transfers", controller.GetTransfers).Methods("GET")
+	router.HandleFunc("/reservation-transfers", controller.PostTransfer).Methods("POST")
+	router.HandleFunc("/reservation-transfers/{id}", controller.GetTransferByID).Methods("GET")
+	router.HandleFunc("/reservation-transfers/{id}/accept", controller.PostTransferAccept).Methods("POST")
+	router.HandleFunc("/reservation-transfers/{id}/decline", controller.PostTransferDecline).Methods("POST")
+	router.HandleFunc("/reservation-transfers/{id}/cancel", controller.PostTransferCancel).Methods("POST")
+
+	router.HandleFunc("/reservation-waitlist", controller.GetWaitlistEntries).Methods("GET")
+	router.HandleFunc("/reservation-waitlist", controller.PostWaitlistEntry).Methods("POST")
+	router.HandleFunc("/reservation-waitlist/{id}/cancel", controller.PostWaitlistEntryCancel).Methods("POST")
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"fmt"
+	"log"
+)
+
+type compensation struct {
+	name string
+	undo func() error
+}
+
+// Registra los pasos completados del alta para deshacerlos en orden inverso si un paso posterior falla
+type onboardingSaga struct {
+	email         string
+	compensations []compensation
+}
+
+func newOnboardingSaga(email string) *onboardingSaga {
+	return &onboardingSaga{email: email}
+}
+
+func (s *onboardingSaga) completed(name string, undo func() error) {
+	s.compensations = append(s.compensations, compensation{name: name, undo: undo})
+}
+
+func (s *onboardingSaga) abort(step string, cause error) error {
+	for i := len(s.compensations) - 1; i >= 0; i-- {
+		c := s.compensations[i]
+		if err := c.undo(); err != nil {
+			log.Printf("Error al revertir paso '%s' del alta de %s: %v", c.name, s.email, err)
+			continue
+		}
+		log.Printf("Paso '%s' del alta de %s revertido", c.name, s.email)
+	}
+	s.compensations = nil
+	return fmt.Errorf("%s: %w", step, cause)
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"firebase.google.com/go/v4/auth"
+	mailEntities "github.com/gonzalohonorato/servercorego/core/mail/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/user/domain/entities"
+)
+
+var ErrUserNotFound = errors.New("usuario no encontrado")
+
+func (uc *UserUsecase) ResendInvitation(userID string) error {
+	user, err := uc.UserRepository.SearchUserByID(userID)
+	if err != nil || user == nil {
+		return ErrUserNotFound
+	}
+	return uc.sendInvitation(user)
+}
+
+// El enlace de Firebase permite definir la contraseña; se envía por correo y nunca se registra en el log
+func (uc *UserUsecase) sendInvitation(user *entities.User) error {
+	if uc.FirebaseAuth == nil {
+		return fmt.Errorf("Firebase Auth not initialized")
+	}
+	if uc.Mailer == nil {
+		return fmt.Errorf("Mailer not initialized")
+	}
+
+	ctx := context.Background()
+	var link string
+	var err error
+	if continueURL := os.Getenv("INVITE_CONTINUE_URL"); continueURL != "" {
+		link, err = uc.FirebaseAuth.PasswordResetLinkWithSettings(ctx, user.Email, &auth.ActionCodeSettings{URL: continueURL})
+	} else {
+		link, err = uc.FirebaseAuth.PasswordResetLink(ctx, user.Email)
+	}
+	if err != nil {
+		return fmt.Errorf("error al generar enlace de invitación: %w", err)
+	}
+
+	message := &mailEntities.Message{
+		To:      user.Email,
+		Subject: "Invitación a Duoc Parkings",
+		TextBody: fmt.Sprintf("Hola %s,\n\n"+
+			"Se creó tu cuenta en Duoc Parkings. Para activarla define tu contraseña en el siguiente enlace:\n\n"+
+			"%s\n\n"+
+			"El enlace es personal y caduca; si expira solicita una nueva invitación a la administración.\n",
+			user.Name, link),
+	}
+	if err := uc.Mailer.Send(message); err != nil {
+		return err
+	}
+
+	log.Printf("Invitación enviada al usuario %s", user.ID)
+	return nil
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	"github.com/gonzalohonorato/servercorego/core/user/domain/entities"
+)
+
+var ErrRutAlreadyRegistered = errors.New("RUT ya registrado")
+
+// Devuelve el RUT canónico y rechaza el que ya pertenece a otro usuario; el RUT vacío se mantiene opcional
+func (uc *UserUsecase) normalizeUserRut(raw string, userID string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	rut, err := utils.ParseRut(raw)
+	if err != nil {
+		return "", err
+	}
+	existing, err := uc.UserRepository.SearchUserByRut(rut.String())
+	if err != nil {
+		return "", fmt.Errorf("error al buscar RUT: %w", err)
+	}
+	if existing != nil && existing.ID != userID {
+		return "", fmt.Errorf("%w: %s", ErrRutAlreadyRegistered, rut)
+	}
+	return rut.String(), nil
+}
+
+func (uc *UserUsecase) SearchUserByRut(raw string) (*entities.User, error) {
+	rut, err := utils.ParseRut(raw)
+	if err != nil {
+		return nil, err
+	}
+	return uc.UserRepository.SearchUserByRut(rut.String())
+}
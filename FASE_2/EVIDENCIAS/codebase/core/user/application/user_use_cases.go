@@ -2,77 +2,100 @@ package application
 
 import (
 	"context"
-	"crypto/rand"
+	"errors"
 	"fmt"
 	"log"
-	"math/big"
 	"time"
 
 	"firebase.google.com/go/v4/auth"
+	mailGateways "github.com/gonzalohonorato/servercorego/core/mail/domain/gateways"
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/user/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleEntities "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
 )
 
 type UserUsecase struct {
-	UserRepository repositories.UserRepository
-	FirebaseAuth   *auth.Client
+	UserRepository     repositories.UserRepository
+	VehicleRepository  vehicleRepositories.VehicleRepository
+	PlateFormatUsecase *plateApplication.PlateFormatUsecase
+	FirebaseAuth       *auth.Client
+	Mailer             mailGateways.Mailer
 }
 
+var ErrPlateAlreadyRegistered = errors.New("la patente ya está registrada")
+
 func NewUserUsecase(userRepo repositories.UserRepository) *UserUsecase {
 	return &UserUsecase{
 		UserRepository: userRepo,
 	}
 }
 
+func (uc *UserUsecase) SetMailer(mailer mailGateways.Mailer) {
+	uc.Mailer = mailer
+}
+
+func (uc *UserUsecase) SetVehicleRepositories(vehicleRepo vehicleRepositories.VehicleRepository, plateFormatRepo plateRepositories.PlateFormatRepository) {
+	uc.VehicleRepository = vehicleRepo
+	uc.PlateFormatUsecase = plateApplication.NewPlateFormatUsecase(plateFormatRepo)
+}
+
 func (uc *UserUsecase) SetFirebaseAuth(firebaseAuth *auth.Client) {
 	uc.FirebaseAuth = firebaseAuth
 }
 
 
+// Las cuentas se crean sin contraseña: el usuario la define desde el enlace de invitación
 type CreateCustomerRequest struct {
-	Name                   string       `json:"name"`
-	Email                  string       `json:"email"`
-	Rut                    string       `json:"rut"`
-	CustomerType           *string      `json:"customerType"`
-	Password               string       `json:"password"`
-	GenerateRandomPassword bool         `json:"generateRandomPassword"`
-	SendEmailPassword      bool         `json:"sendEmailPassword"`
-	Vehicle                *VehicleData `json:"vehicle,omitempty"`
+	Name         string       `json:"name"`
+	Email        string       `json:"email"`
+	Rut          string       `json:"rut"`
+	CustomerType *string      `json:"customerType"`
+	Vehicle      *VehicleData `json:"vehicle,omitempty"`
 }
 
 type VehicleData struct {
-	Plate string `json:"plate"`
-	Brand string `json:"brand"`
-	Model string `json:"model"`
+	Plate       string `json:"plate"`
+	Brand       string `json:"brand"`
+	Model       string `json:"model"`
+	VehicleType string `json:"vehicleType,omitempty"`
+	IsElectric  bool   `json:"isElectric,omitempty"`
 }
 
-func (uc *UserUsecase) CreateCustomerWithFirebase(req CreateCustomerRequest) (*entities.User, interface{}, error) {
+// El alta crea la cuenta de Firebase, el usuario/cliente y el vehículo; si un paso falla se revierten los anteriores
+func (uc *UserUsecase) CreateCustomerWithFirebase(req CreateCustomerRequest) (*entities.User, *vehicleEntities.Vehicle, error) {
 	ctx := context.Background()
 
 	
-	finalPassword := req.Password
-	if req.GenerateRandomPassword {
-		generatedPassword, err := generateRandomPassword()
-		if err != nil {
-			return nil, nil, fmt.Errorf("error generating password: %w", err)
-		}
-		finalPassword = generatedPassword
-	}
-
-	
 	if uc.FirebaseAuth == nil {
 		return nil, nil, fmt.Errorf("Firebase Auth not initialized")
 	}
 
+	rut, err := uc.normalizeUserRut(req.Rut, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vehicle, err := uc.prepareOnboardingVehicle(req.Vehicle)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	saga := newOnboardingSaga(req.Email)
+
 	firebaseUser := &auth.UserToCreate{}
 	firebaseUser.Email(req.Email)
-	firebaseUser.Password(finalPassword)
 	firebaseUser.DisplayName(req.Name)
 
 	createdFirebaseUser, err := uc.FirebaseAuth.CreateUser(ctx, firebaseUser)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating Firebase user: %w", err)
 	}
+	saga.completed("firebase", func() error {
+		return uc.FirebaseAuth.DeleteUser(ctx, createdFirebaseUser.UID)
+	})
 
 	log.Printf("Firebase user created with UID: %s", createdFirebaseUser.UID)
 
@@ -81,7 +104,7 @@ func (uc *UserUsecase) CreateCustomerWithFirebase(req CreateCustomerRequest) (*e
 		ID:           createdFirebaseUser.UID,
 		Name:         req.Name,
 		Email:        req.Email,
-		Rut:          req.Rut,
+		Rut:          rut,
 		Uid:          createdFirebaseUser.UID,
 		Type:         "customer",
 		CustomerType: req.CustomerType,
@@ -89,75 +112,97 @@ func (uc *UserUsecase) CreateCustomerWithFirebase(req CreateCustomerRequest) (*e
 	}
 
 	
-	err = uc.UserRepository.CreateUser(user)
-	if err != nil {
-		
-		uc.FirebaseAuth.DeleteUser(ctx, createdFirebaseUser.UID)
-		return nil, nil, fmt.Errorf("error creating user in database: %w", err)
+	if err := uc.UserRepository.CreateUser(user); err != nil {
+		return nil, nil, saga.abort("error creating user in database", err)
 	}
-
-	
-	var createdVehicle interface{}
-	if req.Vehicle != nil && req.Vehicle.Plate != "" {
-		log.Printf("Vehicle creation would be implemented here for user %s", user.ID)
-
-		createdVehicle = map[string]interface{}{
-			"plate":      req.Vehicle.Plate,
-			"brand":      req.Vehicle.Brand,
-			"model":      req.Vehicle.Model,
-			"customerId": user.ID,
+	saga.completed("user", func() error {
+		return uc.UserRepository.DeleteUserByID(user.ID)
+	})
+
+	if vehicle != nil {
+		vehicle.CustomerID = user.ID
+		if err := uc.VehicleRepository.CreateVehicle(vehicle); err != nil {
+			return nil, nil, saga.abort("error creating vehicle", err)
 		}
+		saga.completed("vehicle", func() error {
+			return uc.VehicleRepository.DeleteVehicleByID(vehicle.ID)
+		})
+		log.Printf("Vehicle %s created with ID %d for user %s", vehicle.Plate, vehicle.ID, user.ID)
 	}
 
 	
-	if req.SendEmailPassword {
-		err = uc.sendPasswordEmail(req.Email, req.Name, finalPassword)
-		if err != nil {
-			log.Printf("Warning: User created but email sending failed: %v", err)
-		}
+	if err := uc.sendInvitation(user); err != nil {
+		log.Printf("Warning: User created but invitation sending failed: %v", err)
 	}
 
-	return user, createdVehicle, nil
+	return user, vehicle, nil
+}
+
+// Valida la patente antes de crear la cuenta para no tener que revertir por datos inválidos
+func (uc *UserUsecase) prepareOnboardingVehicle(data *VehicleData) (*vehicleEntities.Vehicle, error) {
+	if data == nil || data.Plate == "" {
+		return nil, nil
+	}
+	if uc.VehicleRepository == nil || uc.PlateFormatUsecase == nil {
+		return nil, fmt.Errorf("Vehicle repository not initialized")
+	}
+
+	plate, format, err := uc.PlateFormatUsecase.ValidatePlate(data.Plate)
+	if err != nil {
+		return nil, err
+	}
+	if existing, err := uc.VehicleRepository.SearchVehicleByPlate(plate); err == nil && existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPlateAlreadyRegistered, plate)
+	}
+
+	vehicle := &vehicleEntities.Vehicle{
+		Plate:              plate,
+		Brand:              data.Brand,
+		Model:              data.Model,
+		VehicleType:        data.VehicleType,
+		IsElectric:         data.IsElectric,
+		VerificationStatus: vehicleEntities.VerificationStatusPending,
+	}
+	if vehicle.VehicleType == "" {
+		vehicle.VehicleType = format.VehicleType
+	}
+	return vehicle, nil
 }
 
 
 type CreateEmployeeRequest struct {
-	Name                   string  `json:"name"`
-	Email                  string  `json:"email"`
-	Rut                    string  `json:"rut"`
-	EmployeeRole           *string `json:"employeeRole"`
-	Password               string  `json:"password"`
-	GenerateRandomPassword bool    `json:"generateRandomPassword"`
-	SendEmailPassword      bool    `json:"sendEmailPassword"`
+	Name         string  `json:"name"`
+	Email        string  `json:"email"`
+	Rut          string  `json:"rut"`
+	EmployeeRole *string `json:"employeeRole"`
 }
 
 func (uc *UserUsecase) CreateEmployeeWithFirebase(req CreateEmployeeRequest) (*entities.User, error) {
 	ctx := context.Background()
 
 	
-	finalPassword := req.Password
-	if req.GenerateRandomPassword {
-		generatedPassword, err := generateRandomPassword()
-		if err != nil {
-			return nil, fmt.Errorf("error generating password: %w", err)
-		}
-		finalPassword = generatedPassword
-	}
-
-	
 	if uc.FirebaseAuth == nil {
 		return nil, fmt.Errorf("Firebase Auth not initialized")
 	}
 
+	rut, err := uc.normalizeUserRut(req.Rut, "")
+	if err != nil {
+		return nil, err
+	}
+
+	saga := newOnboardingSaga(req.Email)
+
 	firebaseUser := &auth.UserToCreate{}
 	firebaseUser.Email(req.Email)
-	firebaseUser.Password(finalPassword)
 	firebaseUser.DisplayName(req.Name)
 
 	createdFirebaseUser, err := uc.FirebaseAuth.CreateUser(ctx, firebaseUser)
 	if err != nil {
 		return nil, fmt.Errorf("error creating Firebase user: %w", err)
 	}
+	saga.completed("firebase", func() error {
+		return uc.FirebaseAuth.DeleteUser(ctx, createdFirebaseUser.UID)
+	})
 
 	log.Printf("Firebase user created with UID: %s", createdFirebaseUser.UID)
 
@@ -167,7 +212,7 @@ func (uc *UserUsecase) CreateEmployeeWithFirebase(req CreateEmployeeRequest) (*e
 		Uid:          createdFirebaseUser.UID, 
 		Name:         req.Name,
 		Email:        req.Email,
-		Rut:          req.Rut,
+		Rut:          rut,
 		Type:         "employee",
 		EmployeeRole: req.EmployeeRole,
 		CreatedAt:    time.Now(),
@@ -175,21 +220,15 @@ func (uc *UserUsecase) CreateEmployeeWithFirebase(req CreateEmployeeRequest) (*e
 	}
 
 	
-	err = uc.UserRepository.CreateUser(user)
-	if err != nil {
-		
-		uc.FirebaseAuth.DeleteUser(ctx, createdFirebaseUser.UID)
-		return nil, fmt.Errorf("error creating user in database: %w", err)
+	if err := uc.UserRepository.CreateUser(user); err != nil {
+		return nil, saga.abort("error creating user in database", err)
 	}
 
 	log.Printf("Employee created in PostgreSQL with ID: %s (Firebase UID)", user.ID)
 
 	
-	if req.SendEmailPassword {
-		err = uc.sendPasswordEmail(req.Email, req.Name, finalPassword)
-		if err != nil {
-			log.Printf("Warning: Employee created but email sending failed: %v", err)
-		}
+	if err := uc.sendInvitation(user); err != nil {
+		log.Printf("Warning: Employee created but invitation sending failed: %v", err)
 	}
 
 	return user, nil
@@ -201,10 +240,20 @@ func (uc *UserUsecase) GetUserByID(id string) (*entities.User, error) {
 }
 
 func (uc *UserUsecase) CreateUser(user *entities.User) error {
+	rut, err := uc.normalizeUserRut(user.Rut, user.ID)
+	if err != nil {
+		return err
+	}
+	user.Rut = rut
 	return uc.UserRepository.CreateUser(user)
 }
 
 func (uc *UserUsecase) UpdateUser(user *entities.User) error {
+	rut, err := uc.normalizeUserRut(user.Rut, user.ID)
+	if err != nil {
+		return err
+	}
+	user.Rut = rut
 	return uc.UserRepository.UpdateUser(user)
 }
 
@@ -215,24 +264,3 @@ func (uc *UserUsecase) SearchUsers() (*entities.Users, error) {
 func (uc *UserUsecase) SearchUsersByType(userType string) (*entities.Users, error) {
 	return uc.UserRepository.SearchUsersByType(userType)
 }
-
-func generateRandomPassword() (string, error) {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*"
-	const length = 12
-
-	password := make([]byte, length)
-	for i := range password {
-		randomIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
-		if err != nil {
-			return "", err
-		}
-		password[i] = charset[randomIndex.Int64()]
-	}
-
-	return string(password), nil
-}
-
-func (uc *UserUsecase) sendPasswordEmail(email, name, password string) error {
-	log.Printf("Would send password email to %s for user %s with password %s", email, name, password)
-	return nil
-}
@@ -4,8 +4,10 @@ import "github.com/gonzalohonorato/servercorego/core/user/domain/entities"
 
 type UserRepository interface {
 	SearchUserByID(id string) (*entities.User, error)
+	SearchUserByRut(rut string) (*entities.User, error)
 	CreateUser(user *entities.User) error
 	UpdateUser(user *entities.User) error
 	SearchUsers() (*entities.Users, error)
 	SearchUsersByType(userType string) (*entities.Users, error)
+	DeleteUserByID(id string) error
 }
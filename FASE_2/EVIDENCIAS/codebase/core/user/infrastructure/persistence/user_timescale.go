@@ -2,8 +2,10 @@ package persistence
 
 import (
 	"context"
+	"errors"
 
 	"github.com/gonzalohonorato/servercorego/core/user/domain/entities"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -31,6 +33,23 @@ func (r *TimescaleUserRepository) SearchUserByID(id string) (*entities.User, err
 	return &u, nil
 }
 
+func (r *TimescaleUserRepository) SearchUserByRut(rut string) (*entities.User, error) {
+	ctx := context.Background()
+	query := `SELECT * FROM user_details WHERE rut = $1`
+	row := r.dbPool.QueryRow(ctx, query, rut)
+
+	var u entities.User
+	err := row.Scan(&u.ID, &u.Name, &u.Email, &u.Rut, &u.Uid, &u.Type, &u.CreatedAt,
+		&u.CustomerType, &u.EmployeeRole)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
 func (r *TimescaleUserRepository) SearchUsers() (*entities.Users, error) {
 	ctx := context.Background()
 	query := `SELECT * FROM user_details`
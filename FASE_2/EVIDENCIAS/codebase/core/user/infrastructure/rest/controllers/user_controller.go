@@ -2,13 +2,20 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
 	"firebase.google.com/go/v4/auth" 
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	mailGateways "github.com/gonzalohonorato/servercorego/core/mail/domain/gateways"
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/user/application"
 	"github.com/gonzalohonorato/servercorego/core/user/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	vehicleEntities "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
 	"github.com/gorilla/mux"
 )
 
@@ -16,8 +23,15 @@ type UserController struct {
 	UserUsecase *application.UserUsecase
 }
 
-func NewUserController(userRepository repositories.UserRepository) *UserController {
+func NewUserController(
+	userRepository repositories.UserRepository,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+	plateFormatRepository plateRepositories.PlateFormatRepository,
+	mailer mailGateways.Mailer,
+) *UserController {
 	userUseCase := application.NewUserUsecase(userRepository)
+	userUseCase.SetVehicleRepositories(vehicleRepository, plateFormatRepository)
+	userUseCase.SetMailer(mailer)
 	return &UserController{
 		UserUsecase: userUseCase,
 	}
@@ -30,11 +44,11 @@ func (uc *UserController) SetFirebaseAuth(firebaseAuth *auth.Client) {
 
 
 type CreateCustomerResponse struct {
-	Success   bool           `json:"success"`
-	Message   string         `json:"message"`
-	User      *entities.User `json:"user,omitempty"`
-	Vehicle   interface{}    `json:"vehicle,omitempty"`
-	ErrorCode string         `json:"errorCode,omitempty"`
+	Success   bool                     `json:"success"`
+	Message   string                   `json:"message"`
+	User      *entities.User           `json:"user,omitempty"`
+	Vehicle   *vehicleEntities.Vehicle `json:"vehicle,omitempty"`
+	ErrorCode string                   `json:"errorCode,omitempty"`
 }
 
 func (uc *UserController) CreateCustomer(w http.ResponseWriter, r *http.Request) {
@@ -55,12 +69,21 @@ func (uc *UserController) CreateCustomer(w http.ResponseWriter, r *http.Request)
 
 	if err != nil {
 		log.Printf("Error creating customer: %v", err)
+		status, errorCode := http.StatusInternalServerError, "CREATION_ERROR"
+		switch {
+		case errors.Is(err, plateApplication.ErrInvalidPlateFormat):
+			status, errorCode = http.StatusBadRequest, "INVALID_PLATE_FORMAT"
+		case errors.Is(err, application.ErrPlateAlreadyRegistered):
+			status, errorCode = http.StatusConflict, "PLATE_ALREADY_REGISTERED"
+		default:
+			status, errorCode = rutErrorStatus(err, status, errorCode)
+		}
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(status)
 		json.NewEncoder(w).Encode(CreateCustomerResponse{
 			Success:   false,
 			Message:   "Error al crear cliente: " + err.Error(),
-			ErrorCode: "CREATION_ERROR",
+			ErrorCode: errorCode,
 		})
 		return
 	}
@@ -101,12 +124,13 @@ func (uc *UserController) CreateEmployee(w http.ResponseWriter, r *http.Request)
 
 	if err != nil {
 		log.Printf("Error creating employee: %v", err)
+		status, errorCode := rutErrorStatus(err, http.StatusInternalServerError, "CREATION_ERROR")
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(status)
 		json.NewEncoder(w).Encode(CreateEmployeeResponse{
 			Success:   false,
 			Message:   "Error al crear empleado: " + err.Error(),
-			ErrorCode: "CREATION_ERROR",
+			ErrorCode: errorCode,
 		})
 		return
 	}
@@ -121,6 +145,21 @@ func (uc *UserController) CreateEmployee(w http.ResponseWriter, r *http.Request)
 }
 
 
+func (uc *UserController) PostUserInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	if err := uc.UserUsecase.ResendInvitation(userID); err != nil {
+		if errors.Is(err, application.ErrUserNotFound) {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("Error sending invitation to user %s: %v", userID, err)
+		http.Error(w, "Error al enviar invitación", http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
 func (uc *UserController) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["id"]
@@ -142,6 +181,10 @@ func (uc *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := uc.UserUsecase.CreateUser(&newUser); err != nil {
+		if status, _ := rutErrorStatus(err, 0, ""); status != 0 {
+			http.Error(w, err.Error(), status)
+			return
+		}
 		http.Error(w, "Error creating user", http.StatusInternalServerError)
 		return
 	}
@@ -157,6 +200,10 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := uc.UserUsecase.UpdateUser(&user); err != nil {
+		if status, _ := rutErrorStatus(err, 0, ""); status != 0 {
+			http.Error(w, err.Error(), status)
+			return
+		}
 		http.Error(w, "Error update user", http.StatusInternalServerError)
 		return
 	}
@@ -176,6 +223,11 @@ func (uc *UserController) SearchUsers(w http.ResponseWriter, r *http.Request) {
 }
 
 func (uc *UserController) SearchUsersByType(w http.ResponseWriter, r *http.Request) {
+	if rut := r.URL.Query().Get("rut"); rut != "" {
+		uc.searchUsersByRut(w, rut)
+		return
+	}
+
 	userType := r.URL.Query().Get("type")
 	if userType == "" {
 		userType = "customer"
@@ -190,3 +242,32 @@ func (uc *UserController) SearchUsersByType(w http.ResponseWriter, r *http.Reque
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(users)
 }
+
+func (uc *UserController) searchUsersByRut(w http.ResponseWriter, rut string) {
+	user, err := uc.UserUsecase.SearchUserByRut(rut)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidRut) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Error searching users by rut", http.StatusInternalServerError)
+		return
+	}
+
+	users := entities.Users{}
+	if user != nil {
+		users = append(users, *user)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(users)
+}
+
+func rutErrorStatus(err error, status int, errorCode string) (int, string) {
+	switch {
+	case errors.Is(err, utils.ErrInvalidRut):
+		return http.StatusBadRequest, "INVALID_RUT"
+	case errors.Is(err, application.ErrRutAlreadyRegistered):
+		return http.StatusConflict, "RUT_ALREADY_REGISTERED"
+	}
+	return status, errorCode
+}
@@ -10,7 +10,7 @@ import (
 
 func UserRoutes(router *mux.Router, container *injector.Container) {
 	
-	controller := controllers.NewUserController(container.ProvideUserRepository())
+	controller := controllers.NewUserController(container.ProvideUserRepository(), container.ProvideVehicleRepository(), container.ProvidePlateFormatRepository(), container.ProvideMailer())
 
 	
 	firebaseAuth, err := container.GetFirebaseAuth()
@@ -25,6 +25,7 @@ func UserRoutes(router *mux.Router, container *injector.Container) {
 	router.HandleFunc("/users/{id}", controller.GetUserByID).Methods("GET")
 	router.HandleFunc("/users", controller.CreateUser).Methods("POST")
 	router.HandleFunc("/users/{id}", controller.UpdateUser).Methods("PUT")
+	router.HandleFunc("/users/{id}/invitation", controller.PostUserInvitation).Methods("POST")
 
 	
 	router.HandleFunc("/customers", controller.CreateCustomer).Methods("POST")
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/entities"
+)
+
+var reportHeader = []string{
+	"linea", "accion", "estado", "correo", "rut", "nombre", "tipo_cliente",
+	"patente", "usuario_id", "vehiculo_id", "cambios", "errores",
+}
+
+// Escribe el resultado fila por fila para que el operador pueda corregir el archivo y reintentar
+func WriteReportCSV(w io.Writer, userImport *entities.UserImport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(reportHeader); err != nil {
+		return err
+	}
+	for _, row := range userImport.Rows {
+		record := []string{
+			strconv.Itoa(row.Line),
+			string(row.Action),
+			string(row.Status),
+			row.Email,
+			row.Rut,
+			row.Name,
+			row.CustomerType,
+			row.Plate,
+			row.UserID,
+			optionalID(row.VehicleID),
+			strings.Join(row.Changes, "; "),
+			strings.Join(row.Errors, "; "),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func optionalID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
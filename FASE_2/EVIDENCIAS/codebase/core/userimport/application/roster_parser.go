@@ -0,0 +1,150 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/entities"
+)
+
+const MaxRosterRows = 5000
+
+var rosterHeaderAliases = map[string]string{
+	"name":               "name",
+	"nombre":             "name",
+	"nombre completo":    "name",
+	"email":              "email",
+	"e mail":             "email",
+	"correo":             "email",
+	"correo electronico": "email",
+	"rut":                "rut",
+	"customer type":      "customerType",
+	"customertype":       "customerType",
+	"tipo":               "customerType",
+	"tipo cliente":       "customerType",
+	"tipo de cliente":    "customerType",
+	"plate":              "plate",
+	"patente":            "plate",
+}
+
+var requiredRosterColumns = []string{"name", "email", "rut", "customerType"}
+
+// Acepta CSV (coma, punto y coma o tabulador) y XLSX; la primera fila debe traer los encabezados
+func ParseRoster(fileName string, content []byte) ([]entities.RosterRow, error) {
+	var records [][]string
+	var lines []int
+	var err error
+	if isXLSX(fileName, content) {
+		records, lines, err = readXLSX(content)
+	} else {
+		records, lines, err = readCSV(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("el archivo no tiene filas")
+	}
+
+	columns := map[string]int{}
+	for i, header := range records[0] {
+		if key, ok := rosterHeaderAliases[normalizeHeader(header)]; ok {
+			if _, dup := columns[key]; !dup {
+				columns[key] = i
+			}
+		}
+	}
+	for _, required := range requiredRosterColumns {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("falta la columna %s en el encabezado", required)
+		}
+	}
+
+	rows := []entities.RosterRow{}
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(rows) == MaxRosterRows {
+			return nil, fmt.Errorf("el archivo supera el máximo de %d filas", MaxRosterRows)
+		}
+		cell := func(key string) string {
+			index, ok := columns[key]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		rows = append(rows, entities.RosterRow{
+			Line:         lines[i+1],
+			Name:         cell("name"),
+			Email:        cell("email"),
+			Rut:          cell("rut"),
+			CustomerType: cell("customerType"),
+			Plate:        cell("plate"),
+		})
+	}
+	return rows, nil
+}
+
+func isXLSX(fileName string, content []byte) bool {
+	return strings.HasSuffix(strings.ToLower(fileName), ".xlsx") || bytes.HasPrefix(content, []byte("PK\x03\x04"))
+}
+
+func readCSV(content []byte) ([][]string, []int, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = detectDelimiter(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	records := [][]string{}
+	lines := []int{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("CSV inválido: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	return records, lines, nil
+}
+
+func detectDelimiter(content []byte) rune {
+	header := content
+	if i := bytes.IndexByte(content, '\n'); i >= 0 {
+		header = content[:i]
+	}
+	best, bestCount := ',', bytes.Count(header, []byte(","))
+	for _, candidate := range []rune{';', '\t'} {
+		if count := bytes.Count(header, []byte(string(candidate))); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best
+}
+
+func normalizeHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")))
+	header = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "_", " ", "-", " ").Replace(header)
+	return strings.Join(strings.Fields(header), " ")
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/entities"
+)
+
+func TestXLSXColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    int
+		wantErr bool
+	}{
+		{"A1", 0, false},
+		{"z9", 25, false},
+		{"AA10", 26, false},
+		{"AB12", 27, false},
+		{"XFD1048576", 16383, false},
+		{"XFE1", 0, true},
+		{"ZZZ1", 0, true},
+		{"ZZZZZZZ1", 0, true},
+		{"12", 0, true},
+		{"A", 0, true},
+		{"A1B", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := xlsxColumnIndex(tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("xlsxColumnIndex(%q) error = %v, se esperaba error: %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("xlsxColumnIndex(%q) = %d, se esperaba %d", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestParseRosterCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []entities.RosterRow
+		wantErr string
+	}{
+		{
+			name:    "coma",
+			content: "name,email,rut,customerType,plate\nAna Pérez,ana@uni.cl,12.345.678-5,student,BCDF10\n",
+			want: []entities.RosterRow{
+				{Line: 2, Name: "Ana Pérez", Email: "ana@uni.cl", Rut: "12.345.678-5", CustomerType: "student", Plate: "BCDF10"},
+			},
+		},
+		{
+			name:    "punto y coma con BOM, encabezados en español y filas vacías",
+			content: "\xef\xbb\xbfNombre;Correo Electrónico;RUT;Tipo de cliente\n;;;\nLuis Soto;luis@uni.cl;9.876.543-3;staff\n",
+			want: []entities.RosterRow{
+				{Line: 3, Name: "Luis Soto", Email: "luis@uni.cl", Rut: "9.876.543-3", CustomerType: "staff"},
+			},
+		},
+		{
+			name:    "tabulador",
+			content: "nombre\tcorreo\trut\ttipo\tpatente\nEva\teva@uni.cl\t11.111.111-1\tstudent\t \n",
+			want: []entities.RosterRow{
+				{Line: 2, Name: "Eva", Email: "eva@uni.cl", Rut: "11.111.111-1", CustomerType: "student"},
+			},
+		},
+		{
+			name:    "falta una columna obligatoria",
+			content: "name,email,customerType\nAna,ana@uni.cl,student\n",
+			wantErr: "falta la columna rut",
+		},
+		{
+			name:    "archivo vacío",
+			content: "",
+			wantErr: "no tiene filas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseRoster("nomina.csv", []byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("se esperaba error %q, obtuvo %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Fatalf("ParseRoster = %+v, se esperaba %+v", rows, tt.want)
+			}
+		})
+	}
+}
+
+func buildXLSX(t *testing.T, sheetData string) []byte {
+	t.Helper()
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Nómina" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>nombre</t></si><si><t>correo</t></si><si><r><t>Ana </t></r><r><t>Pérez</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			sheetData + `</sheetData></worksheet>`,
+	}
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range parts {
+		writer, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("error al crear %s: %v", name, err)
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			t.Fatalf("error al escribir %s: %v", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("error al cerrar XLSX de prueba: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestParseRosterXLSX(t *testing.T) {
+	header := `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c>` +
+		`<c r="C1" t="inlineStr"><is><t>rut</t></is></c><c r="E1" t="inlineStr"><is><t>tipo</t></is></c></row>`
+
+	tests := []struct {
+		name    string
+		rows    string
+		want    []entities.RosterRow
+		wantErr string
+	}{
+		{
+			name: "textos compartidos, en línea y celdas salteadas",
+			rows: header + `<row r="4"><c r="A4" t="s"><v>2</v></c><c r="B4" t="inlineStr"><is><t>ana@uni.cl</t></is></c>` +
+				`<c r="C4"><v>123456785</v></c><c r="E4" t="inlineStr"><is><t>student</t></is></c></row>`,
+			want: []entities.RosterRow{
+				{Line: 4, Name: "Ana Pérez", Email: "ana@uni.cl", Rut: "123456785", CustomerType: "student"},
+			},
+		},
+		{
+			name:    "referencia sin letras",
+			rows:    header + `<row r="2"><c r="12"><v>x</v></c></row>`,
+			wantErr: "referencia de celda inválida",
+		},
+		{
+			name:    "referencia con columna enorme",
+			rows:    header + `<row r="2"><c r="ZZZZZZZ2"><v>x</v></c></row>`,
+			wantErr: "referencia de celda inválida",
+		},
+		{
+			name:    "referencia después de XFD",
+			rows:    header + `<row r="2"><c r="XFE2"><v>x</v></c></row>`,
+			wantErr: "fuera de rango",
+		},
+		{
+			name:    "índice de texto compartido inexistente",
+			rows:    header + `<row r="2"><c r="A2" t="s"><v>9</v></c></row>`,
+			wantErr: "referencia de texto inválida",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ParseRoster("nomina.xlsx", buildXLSX(t, tt.rows))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("se esperaba error %q, obtuvo %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Fatalf("ParseRoster = %+v, se esperaba %+v", rows, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,423 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/gonzalohonorato/servercorego/config/utils"
+	mailGateways "github.com/gonzalohonorato/servercorego/core/mail/domain/gateways"
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	userApplication "github.com/gonzalohonorato/servercorego/core/user/application"
+	userEntities "github.com/gonzalohonorato/servercorego/core/user/domain/entities"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/repositories"
+	vehicleEntities "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+)
+
+var (
+	ErrUserImportNotFound  = errors.New("importación no encontrada")
+	ErrInvalidRoster       = errors.New("archivo de nómina inválido")
+	ErrImporterNotEmployee = errors.New("solo un funcionario puede importar usuarios")
+)
+
+type UserImportUsecase struct {
+	UserImportRepository repositories.UserImportRepository
+	UserRepository       userRepositories.UserRepository
+	VehicleRepository    vehicleRepositories.VehicleRepository
+	PlateFormatUsecase   *plateApplication.PlateFormatUsecase
+	UserUsecase          *userApplication.UserUsecase
+	BatchSize            int
+	BatchPause           time.Duration
+}
+
+func NewUserImportUsecase(
+	userImportRepo repositories.UserImportRepository,
+	userRepo userRepositories.UserRepository,
+	vehicleRepo vehicleRepositories.VehicleRepository,
+	plateFormatRepo plateRepositories.PlateFormatRepository,
+	mailer mailGateways.Mailer,
+	firebaseAuth *auth.Client,
+) *UserImportUsecase {
+	userUsecase := userApplication.NewUserUsecase(userRepo)
+	userUsecase.SetVehicleRepositories(vehicleRepo, plateFormatRepo)
+	userUsecase.SetMailer(mailer)
+	if firebaseAuth != nil {
+		userUsecase.SetFirebaseAuth(firebaseAuth)
+	}
+
+	return &UserImportUsecase{
+		UserImportRepository: userImportRepo,
+		UserRepository:       userRepo,
+		VehicleRepository:    vehicleRepo,
+		PlateFormatUsecase:   plateApplication.NewPlateFormatUsecase(plateFormatRepo),
+		UserUsecase:          userUsecase,
+		BatchSize:            envPositiveInt("USER_IMPORT_BATCH_SIZE", 50),
+		BatchPause:           time.Duration(envPositiveInt("USER_IMPORT_BATCH_PAUSE_MS", 1000)) * time.Millisecond,
+	}
+}
+
+func envPositiveInt(name string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// Fila planificada junto con el usuario existente y el vehículo que habría que agregar
+type plannedRow struct {
+	result   *entities.ImportRowResult
+	existing *userEntities.User
+	vehicle  *vehicleEntities.Vehicle
+}
+
+func (uc *UserImportUsecase) SearchUserImportByID(id int) (*entities.UserImport, error) {
+	userImport, err := uc.UserImportRepository.SearchUserImportByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if userImport == nil {
+		return nil, ErrUserImportNotFound
+	}
+	return userImport, nil
+}
+
+func (uc *UserImportUsecase) SearchUserImports() (*entities.UserImports, error) {
+	return uc.UserImportRepository.SearchUserImports()
+}
+
+// Prepara la importación y calcula el diff; en modo simulación no escribe usuarios ni vehículos
+func (uc *UserImportUsecase) StartImport(fileName string, content []byte, dryRun bool, createdBy string) (*entities.UserImport, []plannedRow, error) {
+	if createdBy != "" {
+		importer, err := uc.UserRepository.SearchUserByID(createdBy)
+		if err != nil || importer == nil || importer.Type != "employee" {
+			return nil, nil, ErrImporterNotEmployee
+		}
+	}
+
+	rows, err := ParseRoster(fileName, content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidRoster, err)
+	}
+
+	plan, err := uc.plan(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userImport := &entities.UserImport{
+		FileName:  fileName,
+		DryRun:    dryRun,
+		Status:    entities.ImportStatusRunning,
+		CreatedBy: createdBy,
+		StartedAt: time.Now(),
+		Rows:      make([]entities.ImportRowResult, len(plan)),
+	}
+	for i, row := range plan {
+		userImport.Rows[i] = *row.result
+		plan[i].result = &userImport.Rows[i]
+	}
+	summarize(userImport)
+
+	if dryRun {
+		finish(userImport)
+	}
+	if err := uc.UserImportRepository.CreateUserImport(userImport); err != nil {
+		return nil, nil, fmt.Errorf("error al registrar importación: %w", err)
+	}
+	return userImport, plan, nil
+}
+
+func (uc *UserImportUsecase) ImportRoster(fileName string, content []byte, dryRun bool, createdBy string) (*entities.UserImport, error) {
+	userImport, plan, err := uc.StartImport(fileName, content, dryRun, createdBy)
+	if err != nil || dryRun {
+		return userImport, err
+	}
+	return userImport, uc.ApplyImport(userImport, plan)
+}
+
+// Aplica las filas válidas en lotes para no saturar Firebase; los fallos quedan registrados por fila
+func (uc *UserImportUsecase) ApplyImport(userImport *entities.UserImport, plan []plannedRow) error {
+	pending := []plannedRow{}
+	for _, row := range plan {
+		if row.result.Action == entities.RowActionCreate || row.result.Action == entities.RowActionUpdate {
+			pending = append(pending, row)
+		}
+	}
+
+	for start := 0; start < len(pending); start += uc.BatchSize {
+		end := start + uc.BatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		if start > 0 && uc.BatchPause > 0 {
+			time.Sleep(uc.BatchPause)
+		}
+
+		for _, row := range pending[start:end] {
+			var err error
+			if row.result.Action == entities.RowActionCreate {
+				err = uc.createRow(row)
+			} else {
+				err = uc.updateRow(row)
+			}
+			if err != nil {
+				row.result.Status = entities.RowStatusFailed
+				row.result.Errors = append(row.result.Errors, err.Error())
+				continue
+			}
+			row.result.Status = entities.RowStatusApplied
+		}
+		log.Printf("Importación %d: lote %d-%d de %d procesado", userImport.ID, start+1, end, len(pending))
+	}
+
+	summarize(userImport)
+	finish(userImport)
+	if err := uc.UserImportRepository.UpdateUserImportByID(userImport); err != nil {
+		return fmt.Errorf("error al guardar resultado de la importación: %w", err)
+	}
+	log.Printf("Importación %d terminada: %d creados, %d actualizados, %d con error", userImport.ID,
+		userImport.Summary.Created, userImport.Summary.Updated, userImport.Summary.Failed)
+	return nil
+}
+
+// Aplica la importación en segundo plano; si falla o entra en pánico la deja marcada como fallida
+// en vez de dejarla en curso para siempre
+func (uc *UserImportUsecase) RunImport(userImport *entities.UserImport, plan []plannedRow) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			uc.failImport(userImport, fmt.Errorf("pánico: %v", recovered))
+		}
+	}()
+	if err := uc.ApplyImport(userImport, plan); err != nil {
+		uc.failImport(userImport, err)
+	}
+}
+
+func (uc *UserImportUsecase) failImport(userImport *entities.UserImport, cause error) {
+	log.Printf("Error en importación %d: %v", userImport.ID, cause)
+	now := time.Now()
+	userImport.Status = entities.ImportStatusFailed
+	userImport.FinishedAt = &now
+	if err := uc.UserImportRepository.UpdateUserImportByID(userImport); err != nil {
+		log.Printf("Error al marcar como fallida la importación %d: %v", userImport.ID, err)
+	}
+}
+
+func (uc *UserImportUsecase) createRow(row plannedRow) error {
+	request := userApplication.CreateCustomerRequest{
+		Name:         row.result.Name,
+		Email:        row.result.Email,
+		Rut:          row.result.Rut,
+		CustomerType: &row.result.CustomerType,
+	}
+	if row.vehicle != nil {
+		request.Vehicle = &userApplication.VehicleData{
+			Plate:       row.vehicle.Plate,
+			VehicleType: row.vehicle.VehicleType,
+		}
+	}
+
+	user, vehicle, err := uc.UserUsecase.CreateCustomerWithFirebase(request)
+	if err != nil {
+		return err
+	}
+	row.result.UserID = user.ID
+	if vehicle != nil {
+		row.result.VehicleID = vehicle.ID
+	}
+	return nil
+}
+
+func (uc *UserImportUsecase) updateRow(row plannedRow) error {
+	user := *row.existing
+	user.Name = row.result.Name
+	user.Rut = row.result.Rut
+	user.CustomerType = &row.result.CustomerType
+	if err := uc.UserRepository.UpdateUser(&user); err != nil {
+		return fmt.Errorf("error al actualizar usuario: %w", err)
+	}
+
+	if row.vehicle != nil {
+		vehicle := *row.vehicle
+		vehicle.CustomerID = user.ID
+		if err := uc.VehicleRepository.CreateVehicle(&vehicle); err != nil {
+			return fmt.Errorf("usuario actualizado pero no se pudo registrar la patente %s: %w", vehicle.Plate, err)
+		}
+		row.result.VehicleID = vehicle.ID
+	}
+	return nil
+}
+
+func (uc *UserImportUsecase) plan(rows []entities.RosterRow) ([]plannedRow, error) {
+	users, err := uc.UserRepository.SearchUsers()
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener usuarios: %w", err)
+	}
+	vehicles, err := uc.VehicleRepository.SearchVehicles()
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener vehículos: %w", err)
+	}
+
+	usersByEmail := map[string]*userEntities.User{}
+	usersByRut := map[string]*userEntities.User{}
+	for i := range *users {
+		u := &(*users)[i]
+		usersByEmail[strings.ToLower(u.Email)] = u
+		if rut, err := utils.ParseRut(u.Rut); err == nil {
+			usersByRut[rut.String()] = u
+		}
+	}
+	vehiclesByPlate := map[string]*vehicleEntities.Vehicle{}
+	for i := range *vehicles {
+		v := &(*vehicles)[i]
+		vehiclesByPlate[uc.PlateFormatUsecase.NormalizePlate(v.Plate)] = v
+	}
+
+	seenEmails := map[string]int{}
+	seenRuts := map[string]int{}
+	seenPlates := map[string]int{}
+
+	plan := make([]plannedRow, 0, len(rows))
+	for _, row := range rows {
+		result := &entities.ImportRowResult{RosterRow: row, Status: entities.RowStatusPlanned}
+		planned := plannedRow{result: result}
+		invalid := func(format string, args ...interface{}) {
+			result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+		}
+
+		if row.Name == "" {
+			invalid("nombre requerido")
+		}
+		if address, err := mail.ParseAddress(row.Email); err != nil || address.Address != row.Email {
+			invalid("correo inválido: %q", row.Email)
+		} else {
+			result.Email = strings.ToLower(address.Address)
+		}
+		if rut, err := utils.ParseRut(row.Rut); err != nil {
+			invalid("%v", err)
+		} else {
+			result.Rut = rut.String()
+		}
+		result.CustomerType = strings.ToLower(row.CustomerType)
+		if result.CustomerType == "" {
+			invalid("tipo de cliente requerido")
+		}
+		if row.Plate != "" {
+			plate, format, err := uc.PlateFormatUsecase.ValidatePlate(row.Plate)
+			if err != nil {
+				invalid("patente inválida: %s", row.Plate)
+			} else {
+				result.Plate = plate
+				planned.vehicle = &vehicleEntities.Vehicle{Plate: plate, VehicleType: format.VehicleType}
+			}
+		}
+
+		if line, dup := seenEmails[result.Email]; dup && result.Email != "" {
+			invalid("correo repetido en la línea %d", line)
+		}
+		if line, dup := seenRuts[result.Rut]; dup && result.Rut != "" {
+			invalid("RUT repetido en la línea %d", line)
+		}
+		if line, dup := seenPlates[result.Plate]; dup && result.Plate != "" {
+			invalid("patente repetida en la línea %d", line)
+		}
+		seenEmails[result.Email] = row.Line
+		seenRuts[result.Rut] = row.Line
+		if result.Plate != "" {
+			seenPlates[result.Plate] = row.Line
+		}
+
+		if len(result.Errors) == 0 {
+			planned.existing = usersByEmail[result.Email]
+			if byRut := usersByRut[result.Rut]; byRut != nil && (planned.existing == nil || byRut.ID != planned.existing.ID) {
+				invalid("el RUT ya está registrado con el correo %s", byRut.Email)
+			}
+			if planned.existing != nil && planned.existing.Type != "customer" {
+				invalid("el correo pertenece a un usuario de tipo %s", planned.existing.Type)
+			}
+			if planned.vehicle != nil {
+				if owned := vehiclesByPlate[result.Plate]; owned != nil {
+					if planned.existing == nil || owned.CustomerID != planned.existing.ID {
+						invalid("la patente ya está registrada a otro usuario")
+					}
+					planned.vehicle = nil
+					result.VehicleID = owned.ID
+				}
+			}
+		}
+
+		switch {
+		case len(result.Errors) > 0:
+			result.Action = entities.RowActionInvalid
+			result.Status = entities.RowStatusSkipped
+		case planned.existing == nil:
+			result.Action = entities.RowActionCreate
+		default:
+			result.UserID = planned.existing.ID
+			result.Changes = diffUser(planned.existing, result, planned.vehicle)
+			result.Action = entities.RowActionUpdate
+			if len(result.Changes) == 0 {
+				result.Action = entities.RowActionUnchanged
+				result.Status = entities.RowStatusSkipped
+			}
+		}
+		plan = append(plan, planned)
+	}
+	return plan, nil
+}
+
+func diffUser(existing *userEntities.User, result *entities.ImportRowResult, vehicle *vehicleEntities.Vehicle) []string {
+	changes := []string{}
+	if existing.Name != result.Name {
+		changes = append(changes, fmt.Sprintf("nombre: %q -> %q", existing.Name, result.Name))
+	}
+	if existing.Rut != result.Rut {
+		changes = append(changes, fmt.Sprintf("rut: %q -> %q", existing.Rut, result.Rut))
+	}
+	current := ""
+	if existing.CustomerType != nil {
+		current = *existing.CustomerType
+	}
+	if current != result.CustomerType {
+		changes = append(changes, fmt.Sprintf("tipo: %q -> %q", current, result.CustomerType))
+	}
+	if vehicle != nil {
+		changes = append(changes, fmt.Sprintf("patente: agregar %s", vehicle.Plate))
+	}
+	return changes
+}
+
+func summarize(userImport *entities.UserImport) {
+	summary := entities.ImportSummary{Total: len(userImport.Rows)}
+	for _, row := range userImport.Rows {
+		switch {
+		case row.Status == entities.RowStatusFailed:
+			summary.Failed++
+		case row.Action == entities.RowActionCreate:
+			summary.Created++
+		case row.Action == entities.RowActionUpdate:
+			summary.Updated++
+		case row.Action == entities.RowActionUnchanged:
+			summary.Unchanged++
+		case row.Action == entities.RowActionInvalid:
+			summary.Invalid++
+		}
+	}
+	userImport.Summary = summary
+}
+
+func finish(userImport *entities.UserImport) {
+	now := time.Now()
+	userImport.Status = entities.ImportStatusCompleted
+	userImport.FinishedAt = &now
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/repositories"
+)
+
+type fakeUserImportRepository struct {
+	repositories.UserImportRepository
+	updates []entities.UserImport
+}
+
+func (r *fakeUserImportRepository) UpdateUserImportByID(userImport *entities.UserImport) error {
+	r.updates = append(r.updates, *userImport)
+	return nil
+}
+
+func TestRunImportMarksPanicAsFailed(t *testing.T) {
+	repo := &fakeUserImportRepository{}
+	uc := &UserImportUsecase{UserImportRepository: repo, BatchSize: 10}
+	userImport := &entities.UserImport{ID: 7, Status: entities.ImportStatusRunning}
+
+	// Una fila sin resultado hace que la aplicación entre en pánico
+	uc.RunImport(userImport, []plannedRow{{}})
+
+	if userImport.Status != entities.ImportStatusFailed || userImport.FinishedAt == nil {
+		t.Fatalf("la importación debería quedar fallida y terminada, obtuvo %+v", userImport)
+	}
+	if len(repo.updates) != 1 || repo.updates[0].Status != entities.ImportStatusFailed {
+		t.Fatalf("se esperaba guardar la importación como fallida, actualizaciones: %+v", repo.updates)
+	}
+}
@@ -0,0 +1,199 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxXLSXPartSize = 50 << 20
+	// Última columna que admite Excel (XFD)
+	maxXLSXColumns = 16384
+)
+
+var xlsxCellRef = regexp.MustCompile(`^([A-Z]{1,3})[0-9]+$`)
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// Lee la primera hoja del libro con la librería estándar; solo interesan los valores, no los estilos
+func readXLSX(content []byte) ([][]string, []int, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("XLSX inválido: %w", err)
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(archive, "xl/workbook.xml", &workbook); err != nil {
+		return nil, nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, nil, fmt.Errorf("XLSX sin hojas")
+	}
+
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(archive, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, nil, err
+	}
+	sheetPath := ""
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RelID {
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPath = strings.TrimPrefix(rel.Target, "/")
+			} else {
+				sheetPath = path.Join("xl", rel.Target)
+			}
+		}
+	}
+	if sheetPath == "" {
+		return nil, nil, fmt.Errorf("XLSX sin referencia a la primera hoja")
+	}
+
+	var shared xlsxSharedStrings
+	if findXLSXPart(archive, "xl/sharedStrings.xml") != nil {
+		if err := decodeXLSXPart(archive, "xl/sharedStrings.xml", &shared); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var sheet xlsxSheet
+	if err := decodeXLSXPart(archive, sheetPath, &sheet); err != nil {
+		return nil, nil, err
+	}
+
+	records := [][]string{}
+	lines := []int{}
+	for i, row := range sheet.Rows {
+		record := []string{}
+		for j, cell := range row.Cells {
+			column := j
+			if cell.Ref != "" {
+				column, err = xlsxColumnIndex(cell.Ref)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			if column >= maxXLSXColumns {
+				return nil, nil, fmt.Errorf("XLSX con demasiadas columnas en la fila %d", row.Number)
+			}
+			for len(record) <= column {
+				record = append(record, "")
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(shared.Items) {
+					return nil, nil, fmt.Errorf("XLSX con referencia de texto inválida en %s", cell.Ref)
+				}
+				value = shared.Items[index].String()
+			case "inlineStr":
+				value = cell.Inline.String()
+			}
+			record[column] = value
+		}
+
+		line := row.Number
+		if line == 0 {
+			line = i + 1
+		}
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	return records, lines, nil
+}
+
+func findXLSXPart(archive *zip.Reader, name string) *zip.File {
+	for _, file := range archive.File {
+		if file.Name == name {
+			return file
+		}
+	}
+	return nil
+}
+
+func decodeXLSXPart(archive *zip.Reader, name string, target interface{}) error {
+	file := findXLSXPart(archive, name)
+	if file == nil {
+		return fmt.Errorf("XLSX sin %s", name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("XLSX inválido: %w", err)
+	}
+	defer reader.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(reader, maxXLSXPartSize)).Decode(target); err != nil {
+		return fmt.Errorf("XLSX inválido en %s: %w", name, err)
+	}
+	return nil
+}
+
+// Convierte la referencia de celda (por ejemplo "AB12") al índice de columna desde cero
+func xlsxColumnIndex(ref string) (int, error) {
+	match := xlsxCellRef.FindStringSubmatch(strings.ToUpper(ref))
+	if match == nil {
+		return 0, fmt.Errorf("XLSX con referencia de celda inválida: %q", ref)
+	}
+
+	index := 0
+	for _, r := range match[1] {
+		index = index*26 + int(r-'A'+1)
+	}
+	if index > maxXLSXColumns {
+		return 0, fmt.Errorf("XLSX con referencia de celda fuera de rango: %q", ref)
+	}
+	return index - 1, nil
+}
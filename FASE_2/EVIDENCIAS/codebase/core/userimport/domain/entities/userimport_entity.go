@@ -0,0 +1,65 @@
+package entities
+
+import "time"
+
+const (
+	RowActionCreate    = "create"
+	RowActionUpdate    = "update"
+	RowActionUnchanged = "unchanged"
+	RowActionInvalid   = "invalid"
+)
+
+const (
+	RowStatusPlanned = "planned"
+	RowStatusApplied = "applied"
+	RowStatusSkipped = "skipped"
+	RowStatusFailed  = "failed"
+)
+
+const (
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+type RosterRow struct {
+	Line         int    `json:"line"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Rut          string `json:"rut"`
+	CustomerType string `json:"customerType"`
+	Plate        string `json:"plate,omitempty"`
+}
+
+type ImportRowResult struct {
+	RosterRow
+	Action    string   `json:"action"`
+	Status    string   `json:"status"`
+	Changes   []string `json:"changes,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+	UserID    string   `json:"userId,omitempty"`
+	VehicleID int      `json:"vehicleId,omitempty"`
+}
+
+type ImportSummary struct {
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Invalid   int `json:"invalid"`
+	Failed    int `json:"failed"`
+}
+
+type UserImport struct {
+	ID         int               `json:"id"`
+	FileName   string            `json:"fileName"`
+	DryRun     bool              `json:"dryRun"`
+	Status     string            `json:"status"`
+	CreatedBy  string            `json:"createdBy"`
+	Summary    ImportSummary     `json:"summary"`
+	Rows       []ImportRowResult `json:"rows,omitempty"`
+	StartedAt  time.Time         `json:"startedAt"`
+	FinishedAt *time.Time        `json:"finishedAt,omitempty"`
+}
+
+type UserImports []UserImport
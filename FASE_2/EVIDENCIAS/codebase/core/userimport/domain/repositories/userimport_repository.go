@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/userimport/domain/entities"
+
+type UserImportRepository interface {
+	SearchUserImportByID(id int) (*entities.UserImport, error)
+	SearchUserImports() (*entities.UserImports, error)
+	CreateUserImport(userImport *entities.UserImport) error
+	UpdateUserImportByID(userImport *entities.UserImport) error
+	FailRunningUserImports() (int64, error)
+}
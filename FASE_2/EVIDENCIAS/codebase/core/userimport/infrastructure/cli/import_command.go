@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/userimport/application"
+)
+
+// Uso: servercorego import-users [-apply] [-report salida.csv] [-by uid] [-batch n] nomina.csv
+func RunImportCommand(args []string) int {
+	flags := flag.NewFlagSet("import-users", flag.ContinueOnError)
+	apply := flags.Bool("apply", false, "crear y actualizar cuentas; sin esta opción solo se simula")
+	reportPath := flags.String("report", "", "ruta del reporte CSV por fila (- para la salida estándar)")
+	createdBy := flags.String("by", "", "id del funcionario que ejecuta la importación")
+	batchSize := flags.Int("batch", 0, "cantidad de filas por lote")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Uso: import-users [-apply] [-report archivo.csv] [-by uid] [-batch n] nomina.csv|nomina.xlsx")
+		return 2
+	}
+
+	path := flags.Arg(0)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error al leer %s: %v\n", path, err)
+		return 1
+	}
+
+	container := injector.NewContainer(context.Background())
+	defer container.CloseTimescaleDB()
+
+	firebaseAuth, err := container.GetFirebaseAuth()
+	if err != nil && *apply {
+		fmt.Fprintf(os.Stderr, "Error al inicializar Firebase Auth: %v\n", err)
+		return 1
+	}
+
+	usecase := application.NewUserImportUsecase(
+		container.ProvideUserImportRepository(),
+		container.ProvideUserRepository(),
+		container.ProvideVehicleRepository(),
+		container.ProvidePlateFormatRepository(),
+		container.ProvideMailer(),
+		firebaseAuth,
+	)
+	if *batchSize > 0 {
+		usecase.BatchSize = *batchSize
+	}
+
+	userImport, err := usecase.ImportRoster(filepath.Base(path), content, !*apply, *createdBy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error en la importación: %v\n", err)
+		if userImport == nil {
+			return 1
+		}
+	}
+
+	if *reportPath != "" {
+		var out io.Writer = os.Stdout
+		if *reportPath != "-" {
+			file, err := os.Create(*reportPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error al crear reporte: %v\n", err)
+				return 1
+			}
+			defer file.Close()
+			out = file
+		}
+		if err := application.WriteReportCSV(out, userImport); err != nil {
+			fmt.Fprintf(os.Stderr, "Error al escribir reporte: %v\n", err)
+			return 1
+		}
+	}
+
+	mode := "simulación"
+	if *apply {
+		mode = "aplicada"
+	}
+	summary := userImport.Summary
+	fmt.Fprintf(os.Stderr, "Importación %d (%s): %d filas, %d nuevos, %d actualizados, %d sin cambios, %d inválidos, %d con error\n",
+		userImport.ID, mode, summary.Total, summary.Created, summary.Updated, summary.Unchanged, summary.Invalid, summary.Failed)
+
+	if err != nil || summary.Invalid > 0 || summary.Failed > 0 {
+		return 1
+	}
+	return 0
+}
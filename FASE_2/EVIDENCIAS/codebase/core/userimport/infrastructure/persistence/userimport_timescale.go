@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleUserImportRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleUserImportRepository(pool *pgxpool.Pool) *TimescaleUserImportRepository {
+	return &TimescaleUserImportRepository{
+		dbPool: pool,
+	}
+}
+
+const userImportColumns = `id, file_name, dry_run, status, created_by, total, created, updated, unchanged, invalid, failed,
+	started_at, finished_at`
+
+func scanUserImport(row pgx.Row, i *entities.UserImport) error {
+	return row.Scan(&i.ID, &i.FileName, &i.DryRun, &i.Status, &i.CreatedBy, &i.Summary.Total, &i.Summary.Created,
+		&i.Summary.Updated, &i.Summary.Unchanged, &i.Summary.Invalid, &i.Summary.Failed, &i.StartedAt, &i.FinishedAt)
+}
+
+func (r *TimescaleUserImportRepository) SearchUserImportByID(id int) (*entities.UserImport, error) {
+	ctx := context.Background()
+	query := `SELECT ` + userImportColumns + `, rows FROM user_import WHERE id = $1`
+	var i entities.UserImport
+	var rows []byte
+	err := r.dbPool.QueryRow(ctx, query, id).Scan(&i.ID, &i.FileName, &i.DryRun, &i.Status, &i.CreatedBy, &i.Summary.Total,
+		&i.Summary.Created, &i.Summary.Updated, &i.Summary.Unchanged, &i.Summary.Invalid, &i.Summary.Failed, &i.StartedAt,
+		&i.FinishedAt, &rows)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(rows, &i.Rows); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func (r *TimescaleUserImportRepository) SearchUserImports() (*entities.UserImports, error) {
+	ctx := context.Background()
+	query := `SELECT ` + userImportColumns + ` FROM user_import ORDER BY started_at DESC`
+	rows, err := r.dbPool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	imports := entities.UserImports{}
+	for rows.Next() {
+		var i entities.UserImport
+		if err := scanUserImport(rows, &i); err != nil {
+			return nil, err
+		}
+		imports = append(imports, i)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &imports, nil
+}
+
+func (r *TimescaleUserImportRepository) CreateUserImport(i *entities.UserImport) error {
+	ctx := context.Background()
+	rows, err := json.Marshal(i.Rows)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO user_import (file_name, dry_run, status, created_by, total, created, updated, unchanged, invalid, failed,
+		started_at, finished_at, rows)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
+	return r.dbPool.QueryRow(ctx, query, i.FileName, i.DryRun, i.Status, i.CreatedBy, i.Summary.Total, i.Summary.Created,
+		i.Summary.Updated, i.Summary.Unchanged, i.Summary.Invalid, i.Summary.Failed, i.StartedAt, i.FinishedAt, rows).Scan(&i.ID)
+}
+
+func (r *TimescaleUserImportRepository) UpdateUserImportByID(i *entities.UserImport) error {
+	ctx := context.Background()
+	rows, err := json.Marshal(i.Rows)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE user_import SET status = $2, total = $3, created = $4, updated = $5, unchanged = $6, invalid = $7, failed = $8,
+		finished_at = $9, rows = $10 WHERE id = $1`
+	_, err = r.dbPool.Exec(ctx, query, i.ID, i.Status, i.Summary.Total, i.Summary.Created, i.Summary.Updated,
+		i.Summary.Unchanged, i.Summary.Invalid, i.Summary.Failed, i.FinishedAt, rows)
+	return err
+}
+
+func (r *TimescaleUserImportRepository) FailRunningUserImports() (int64, error) {
+	ctx := context.Background()
+	query := `UPDATE user_import SET status = $1, finished_at = NOW() WHERE status = $2`
+	tag, err := r.dbPool.Exec(ctx, query, entities.ImportStatusFailed, entities.ImportStatusRunning)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
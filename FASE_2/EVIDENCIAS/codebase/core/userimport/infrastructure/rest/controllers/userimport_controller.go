@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"firebase.google.com/go/v4/auth"
+	mailGateways "github.com/gonzalohonorato/servercorego/core/mail/domain/gateways"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/userimport/application"
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/userimport/domain/repositories"
+	vehicleRepositories "github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+const maxRosterUploadSize = 10 << 20
+
+var errUnauthenticated = errors.New("se requiere un token de Firebase válido en Authorization")
+
+type UserImportController struct {
+	UserImportUsecase *application.UserImportUsecase
+	FirebaseAuth      *auth.Client
+}
+
+func NewUserImportController(
+	userImportRepository repositories.UserImportRepository,
+	userRepository userRepositories.UserRepository,
+	vehicleRepository vehicleRepositories.VehicleRepository,
+	plateFormatRepository plateRepositories.PlateFormatRepository,
+	mailer mailGateways.Mailer,
+	firebaseAuth *auth.Client,
+) *UserImportController {
+	return &UserImportController{
+		UserImportUsecase: application.NewUserImportUsecase(
+			userImportRepository, userRepository, vehicleRepository, plateFormatRepository, mailer, firebaseAuth,
+		),
+		FirebaseAuth: firebaseAuth,
+	}
+}
+
+// Identifica al usuario a partir del ID token de Firebase enviado como "Authorization: Bearer <token>"
+func (uc *UserImportController) requesterID(r *http.Request) (string, error) {
+	idToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || idToken == "" || uc.FirebaseAuth == nil {
+		return "", errUnauthenticated
+	}
+	token, err := uc.FirebaseAuth.VerifyIDToken(r.Context(), idToken)
+	if err != nil {
+		return "", errUnauthenticated
+	}
+	return token.UID, nil
+}
+
+func (uc *UserImportController) GetUserImports(w http.ResponseWriter, r *http.Request) {
+	userImports, err := uc.UserImportUsecase.SearchUserImports()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(userImports)
+}
+
+func (uc *UserImportController) GetUserImportByID(w http.ResponseWriter, r *http.Request) {
+	userImport, ok := uc.findUserImport(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(userImport)
+}
+
+func (uc *UserImportController) GetUserImportReport(w http.ResponseWriter, r *http.Request) {
+	userImport, ok := uc.findUserImport(w, r)
+	if !ok {
+		return
+	}
+	writeReport(w, userImport)
+}
+
+// Por defecto solo simula; con dryRun=false las cuentas se crean en segundo plano y se consulta el avance por id
+func (uc *UserImportController) PostUserImport(w http.ResponseWriter, r *http.Request) {
+	dryRun := true
+	if value := r.URL.Query().Get("dryRun"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "Parámetro dryRun inválido", http.StatusBadRequest)
+			return
+		}
+		dryRun = parsed
+	}
+
+	createdBy, err := uc.requesterID(r)
+	if err != nil {
+		writeUserImportError(w, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRosterUploadSize)
+	if err := r.ParseMultipartForm(maxRosterUploadSize); err != nil {
+		http.Error(w, "Formulario inválido o archivo demasiado grande", http.StatusBadRequest)
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Archivo requerido en el campo file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Error al leer archivo", http.StatusBadRequest)
+		return
+	}
+
+	userImport, plan, err := uc.UserImportUsecase.StartImport(header.Filename, content, dryRun, createdBy)
+	if err != nil {
+		writeUserImportError(w, err)
+		return
+	}
+
+	if !dryRun {
+		go uc.UserImportUsecase.RunImport(userImport, plan)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      userImport.ID,
+			"status":  userImport.Status,
+			"summary": userImport.Summary,
+		})
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" {
+		writeReport(w, userImport)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(userImport)
+}
+
+func (uc *UserImportController) findUserImport(w http.ResponseWriter, r *http.Request) (*entities.UserImport, bool) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return nil, false
+	}
+	userImport, err := uc.UserImportUsecase.SearchUserImportByID(idInt)
+	if err != nil {
+		writeUserImportError(w, err)
+		return nil, false
+	}
+	return userImport, true
+}
+
+func writeReport(w http.ResponseWriter, userImport *entities.UserImport) {
+	fileName := fmt.Sprintf("importacion-%d.csv", userImport.ID)
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	if err := application.WriteReportCSV(w, userImport); err != nil {
+		log.Printf("Error al escribir reporte de importación %d: %v", userImport.ID, err)
+	}
+}
+
+func writeUserImportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, application.ErrUserImportNotFound):
+		http.Error(w, "User import not found", http.StatusNotFound)
+	case errors.Is(err, application.ErrInvalidRoster):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, application.ErrImporterNotEmployee):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
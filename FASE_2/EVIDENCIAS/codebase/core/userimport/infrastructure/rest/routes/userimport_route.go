@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"log"
+
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/userimport/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func UserImportRoutes(router *mux.Router, container *injector.Container) {
+	firebaseAuth, err := container.GetFirebaseAuth()
+	if err != nil {
+		log.Printf("Warning: Firebase Auth not initialized: %v", err)
+	}
+
+	controller := controllers.NewUserImportController(
+		container.ProvideUserImportRepository(),
+		container.ProvideUserRepository(),
+		container.ProvideVehicleRepository(),
+		container.ProvidePlateFormatRepository(),
+		container.ProvideMailer(),
+		firebaseAuth,
+	)
+	router.HandleFunc("/user-imports", controller.GetUserImports).Methods("GET")
+	router.HandleFunc("/user-imports", controller.PostUserImport).Methods("POST")
+	router.HandleFunc("/user-imports/{id}", controller.GetUserImportByID).Methods("GET")
+	router.HandleFunc("/user-imports/{id}/report", controller.GetUserImportReport).Methods("GET")
+}
@@ -0,0 +1,123 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+)
+
+var (
+	ErrTemporaryVehicleLimit  = errors.New("se alcanzó el máximo de vehículos temporales vigentes")
+	ErrInvalidVehicleValidity = errors.New("vigencia de vehículo temporal inválida")
+)
+
+// Vehículos temporales vigentes o futuros que un cliente puede tener a la vez
+func TemporaryVehicleLimit() int {
+	if limit, err := strconv.Atoi(os.Getenv("TEMPORARY_VEHICLE_LIMIT")); err == nil && limit > 0 {
+		return limit
+	}
+	return 1
+}
+
+func TemporaryVehicleMaxDuration() time.Duration {
+	if days, err := strconv.Atoi(os.Getenv("TEMPORARY_VEHICLE_MAX_DAYS")); err == nil && days > 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return 30 * 24 * time.Hour
+}
+
+func (uc *VehicleUsecase) validateValidity(vehicle *entities.Vehicle, now time.Time) error {
+	if !vehicle.IsTemporary {
+		vehicle.ValidFrom = nil
+		vehicle.ValidUntil = nil
+		return nil
+	}
+
+	if vehicle.ValidUntil == nil {
+		return fmt.Errorf("%w: se requiere fecha de término", ErrInvalidVehicleValidity)
+	}
+	if vehicle.ValidFrom == nil {
+		vehicle.ValidFrom = &now
+	}
+	if !vehicle.ValidUntil.After(*vehicle.ValidFrom) {
+		return fmt.Errorf("%w: debe terminar después de su inicio", ErrInvalidVehicleValidity)
+	}
+	if !vehicle.ValidUntil.After(now) {
+		return fmt.Errorf("%w: la fecha de término ya pasó", ErrInvalidVehicleValidity)
+	}
+	if vehicle.ValidUntil.Sub(*vehicle.ValidFrom) > TemporaryVehicleMaxDuration() {
+		return fmt.Errorf("%w: no puede superar %d días", ErrInvalidVehicleValidity, int(TemporaryVehicleMaxDuration().Hours()/24))
+	}
+
+	vehicles, err := uc.VehicleRepository.SearchVehiclesByCustomerID(vehicle.CustomerID)
+	if err != nil {
+		return fmt.Errorf("error al obtener vehículos del cliente: %w", err)
+	}
+	count := 0
+	for _, v := range *vehicles {
+		if v.ID != vehicle.ID && v.IsTemporary && !v.IsExpired(now) {
+			count++
+		}
+	}
+	if count >= TemporaryVehicleLimit() {
+		return fmt.Errorf("%w (%d)", ErrTemporaryVehicleLimit, TemporaryVehicleLimit())
+	}
+	return nil
+}
+
+// Un vehículo temporal vencido libera su patente. Si el mismo cliente la vuelve a inscribir se reutiliza
+// el registro; si es otro cliente el registro vencido se archiva con otra patente y se crea uno nuevo,
+// para que no herede documentos, historial de usos ni vínculos de carpool del dueño anterior
+func (uc *VehicleUsecase) reuseExpiredPlate(vehicle *entities.Vehicle, now time.Time) (bool, error) {
+	existing, err := uc.VehicleRepository.SearchVehicleByPlate(uc.PlateFormatUsecase.NormalizePlate(vehicle.Plate))
+	if err != nil || existing == nil || !existing.IsExpired(now) {
+		return false, nil
+	}
+
+	if existing.CustomerID != vehicle.CustomerID {
+		return false, uc.archiveExpiredPlate(existing)
+	}
+
+	vehicle.ID = existing.ID
+	vehicle.CreatedAt = now
+	vehicle.VerificationStatus = existing.VerificationStatus
+	vehicle.VerifiedBy = existing.VerifiedBy
+	vehicle.VerifiedAt = existing.VerifiedAt
+	vehicle.RejectionReason = existing.RejectionReason
+
+	if err := uc.VehicleRepository.UpdateVehicleByID(vehicle); err != nil {
+		return true, err
+	}
+	if err := uc.VehicleRepository.UpdateVehicleVerification(vehicle); err != nil {
+		return true, err
+	}
+	log.Printf("Patente %s del vehículo temporal vencido %d reutilizada por %s", vehicle.Plate, vehicle.ID, vehicle.CustomerID)
+	return true, nil
+}
+
+const archivedPlatePrefix = "VENCIDA-"
+
+func (uc *VehicleUsecase) archiveExpiredPlate(existing *entities.Vehicle) error {
+	plate := existing.Plate
+	existing.Plate = fmt.Sprintf("%s%d-%s", archivedPlatePrefix, existing.ID, plate)
+	if err := uc.VehicleRepository.UpdateVehicleByID(existing); err != nil {
+		return fmt.Errorf("error al liberar la patente %s del vehículo vencido %d: %w", plate, existing.ID, err)
+	}
+	log.Printf("Patente %s liberada: el vehículo temporal vencido %d de %s quedó archivado como %s", plate, existing.ID, existing.CustomerID, existing.Plate)
+	return nil
+}
+
+func activeVehicles(vehicles *entities.Vehicles, now time.Time) *entities.Vehicles {
+	active := entities.Vehicles{}
+	for _, v := range *vehicles {
+		if !v.IsExpired(now) {
+			active = append(active, v)
+		}
+	}
+	return &active
+}
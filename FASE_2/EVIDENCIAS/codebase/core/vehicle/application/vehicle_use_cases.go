@@ -1,16 +1,41 @@
 package application
 
 import (
+	"time"
+
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
 )
 
 type VehicleUsecase struct {
-	VehicleRepository repositories.VehicleRepository
+	VehicleRepository         repositories.VehicleRepository
+	VehicleDocumentRepository repositories.VehicleDocumentRepository
+	DocumentStorage           repositories.DocumentStorage
+	UserRepository            userRepositories.UserRepository
+	PlateFormatUsecase        *plateApplication.PlateFormatUsecase
+	WebSocketService          *infrastructure.WebSocketService
 }
 
-func NewVehicleUsecase(vehicleRepo repositories.VehicleRepository) *VehicleUsecase {
-	return &VehicleUsecase{VehicleRepository: vehicleRepo}
+func NewVehicleUsecase(
+	vehicleRepo repositories.VehicleRepository,
+	plateFormatRepo plateRepositories.PlateFormatRepository,
+	documentRepo repositories.VehicleDocumentRepository,
+	documentStorage repositories.DocumentStorage,
+	userRepo userRepositories.UserRepository,
+	wsService *infrastructure.WebSocketService,
+) *VehicleUsecase {
+	return &VehicleUsecase{
+		VehicleRepository:         vehicleRepo,
+		VehicleDocumentRepository: documentRepo,
+		DocumentStorage:           documentStorage,
+		UserRepository:            userRepo,
+		PlateFormatUsecase:        plateApplication.NewPlateFormatUsecase(plateFormatRepo),
+		WebSocketService:          wsService,
+	}
 }
 
 func (uc *VehicleUsecase) SearchVehicleByID(id int) (*entities.Vehicle, error) {
@@ -20,21 +45,74 @@ func (uc *VehicleUsecase) SearchVehicleByID(id int) (*entities.Vehicle, error) {
 func (uc *VehicleUsecase) SearchVehicles() (*entities.Vehicles, error) {
 	return uc.VehicleRepository.SearchVehicles()
 }
-func (uc *VehicleUsecase) SearchVehiclesByCustomerID(customerID string) (*entities.Vehicles, error) {
-	return uc.VehicleRepository.SearchVehiclesByCustomerID(customerID)
+// Los vehículos temporales vencidos solo se incluyen si se piden explícitamente
+func (uc *VehicleUsecase) SearchVehiclesByCustomerID(customerID string, includeExpired bool) (*entities.Vehicles, error) {
+	vehicles, err := uc.VehicleRepository.SearchVehiclesByCustomerID(customerID)
+	if err != nil || includeExpired {
+		return vehicles, err
+	}
+	return activeVehicles(vehicles, time.Now()), nil
 }
 func (uc *VehicleUsecase) CreateVehicle(vehicle *entities.Vehicle) error {
+	if err := uc.applyPlateFormat(vehicle); err != nil {
+		return err
+	}
+	now := time.Now()
+	vehicle.ID = 0
+	if err := uc.validateValidity(vehicle, now); err != nil {
+		return err
+	}
+	resetVerification(vehicle)
+
+	if reused, err := uc.reuseExpiredPlate(vehicle, now); reused || err != nil {
+		return err
+	}
 	return uc.VehicleRepository.CreateVehicle(vehicle)
 }
 
 func (uc *VehicleUsecase) UpdateVehicleById(vehicle *entities.Vehicle) error {
+	if err := uc.applyPlateFormat(vehicle); err != nil {
+		return err
+	}
+	if err := uc.validateValidity(vehicle, time.Now()); err != nil {
+		return err
+	}
+
+	current, err := uc.VehicleRepository.SearchVehicleByID(vehicle.ID)
+	if err != nil {
+		return err
+	}
+	if err := uc.VehicleRepository.UpdateVehicleByID(vehicle); err != nil {
+		return err
+	}
 
-	return uc.VehicleRepository.UpdateVehicleByID(vehicle)
+	vehicle.VerificationStatus = current.VerificationStatus
+	vehicle.VerifiedBy = current.VerifiedBy
+	vehicle.VerifiedAt = current.VerifiedAt
+	vehicle.RejectionReason = current.RejectionReason
+	if current.Plate != vehicle.Plate && current.VerificationStatus != entities.VerificationStatusPending {
+		resetVerification(vehicle)
+		return uc.VehicleRepository.UpdateVehicleVerification(vehicle)
+	}
+	return nil
 }
 
 func (uc *VehicleUsecase) DeleteVehicleByID(id int) error {
 	return uc.VehicleRepository.DeleteVehicleByID(id)
 }
 func (uc *VehicleUsecase) SearchVehicleByPlate(plate string) (*entities.Vehicle, error) {
-	return uc.VehicleRepository.SearchVehicleByPlate(plate)
+	return uc.VehicleRepository.SearchVehicleByPlate(uc.PlateFormatUsecase.NormalizePlate(plate))
+}
+
+func (uc *VehicleUsecase) applyPlateFormat(vehicle *entities.Vehicle) error {
+	plate, format, err := uc.PlateFormatUsecase.ValidatePlate(vehicle.Plate)
+	if err != nil {
+		return err
+	}
+
+	vehicle.Plate = plate
+	if vehicle.VehicleType == "" {
+		vehicle.VehicleType = format.VehicleType
+	}
+	return nil
 }
@@ -0,0 +1,209 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+)
+
+var (
+	ErrVehicleNotFound         = errors.New("vehículo no encontrado")
+	ErrVehicleDocumentNotFound = errors.New("documento no encontrado")
+	ErrReviewerNotEmployee     = errors.New("solo un funcionario puede revisar vehículos")
+	ErrInvalidDocument         = errors.New("documento inválido")
+)
+
+var allowedDocumentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+func resetVerification(vehicle *entities.Vehicle) {
+	vehicle.VerificationStatus = entities.VerificationStatusPending
+	vehicle.VerifiedBy = nil
+	vehicle.VerifiedAt = nil
+	vehicle.RejectionReason = ""
+}
+
+func (uc *VehicleUsecase) SearchVehiclesByVerificationStatus(status string) (*entities.Vehicles, error) {
+	switch status {
+	case "":
+		status = entities.VerificationStatusPending
+	case entities.VerificationStatusPending, entities.VerificationStatusVerified, entities.VerificationStatusRejected:
+	default:
+		return nil, fmt.Errorf("estado de verificación inválido: %s", status)
+	}
+	return uc.VehicleRepository.SearchVehiclesByVerificationStatus(status)
+}
+
+func (uc *VehicleUsecase) SearchVehicleDocuments(vehicleID int) (*entities.VehicleDocuments, error) {
+	if _, err := uc.findVehicle(vehicleID); err != nil {
+		return nil, err
+	}
+	return uc.VehicleDocumentRepository.SearchVehicleDocumentsByVehicleID(vehicleID)
+}
+
+// Guarda el documento en disco y, si el vehículo había sido rechazado, lo devuelve a revisión
+func (uc *VehicleUsecase) UploadVehicleDocument(document *entities.VehicleDocument, content io.Reader) error {
+	vehicle, err := uc.findVehicle(document.VehicleID)
+	if err != nil {
+		return err
+	}
+
+	extension, ok := allowedDocumentTypes[document.ContentType]
+	if !ok {
+		return fmt.Errorf("%w: tipo de archivo %q no permitido", ErrInvalidDocument, document.ContentType)
+	}
+	if document.DocumentType == "" {
+		document.DocumentType = entities.DocumentTypeRegistration
+	}
+	if document.UploadedBy == "" {
+		document.UploadedBy = vehicle.CustomerID
+	}
+	document.FileName = path.Base(strings.ReplaceAll(document.FileName, "\\", "/"))
+	document.UploadedAt = time.Now()
+
+	name := fmt.Sprintf("%d/%s-%d%s", vehicle.ID, document.DocumentType, document.UploadedAt.UnixNano(), extension)
+	storagePath, size, err := uc.DocumentStorage.Save(name, content)
+	if err != nil {
+		return err
+	}
+	if size == 0 {
+		uc.DocumentStorage.Delete(storagePath)
+		return fmt.Errorf("%w: el archivo está vacío", ErrInvalidDocument)
+	}
+	document.StoragePath = storagePath
+	document.Size = size
+
+	if err := uc.VehicleDocumentRepository.CreateVehicleDocument(document); err != nil {
+		uc.DocumentStorage.Delete(storagePath)
+		return fmt.Errorf("error al registrar documento: %w", err)
+	}
+
+	if vehicle.VerificationStatus == entities.VerificationStatusRejected {
+		resetVerification(vehicle)
+		if err := uc.VehicleRepository.UpdateVehicleVerification(vehicle); err != nil {
+			return fmt.Errorf("error al reabrir verificación: %w", err)
+		}
+		log.Printf("Vehículo %s vuelve a revisión tras subir documento %d", vehicle.Plate, document.ID)
+	}
+	return nil
+}
+
+func (uc *VehicleUsecase) OpenVehicleDocument(documentID int) (*entities.VehicleDocument, io.ReadCloser, error) {
+	document, err := uc.VehicleDocumentRepository.SearchVehicleDocumentByID(documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if document == nil {
+		return nil, nil, ErrVehicleDocumentNotFound
+	}
+
+	content, err := uc.DocumentStorage.Open(document.StoragePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al abrir documento %d: %w", document.ID, err)
+	}
+	return document, content, nil
+}
+
+func (uc *VehicleUsecase) DeleteVehicleDocument(documentID int) error {
+	document, err := uc.VehicleDocumentRepository.SearchVehicleDocumentByID(documentID)
+	if err != nil {
+		return err
+	}
+	if document == nil {
+		return ErrVehicleDocumentNotFound
+	}
+
+	if err := uc.VehicleDocumentRepository.DeleteVehicleDocumentByID(document.ID); err != nil {
+		return err
+	}
+	if err := uc.DocumentStorage.Delete(document.StoragePath); err != nil {
+		log.Printf("Error al eliminar archivo del documento %d: %v", document.ID, err)
+	}
+	return nil
+}
+
+func (uc *VehicleUsecase) VerifyVehicle(vehicleID int, reviewerID string) (*entities.Vehicle, error) {
+	vehicle, err := uc.startReview(vehicleID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := uc.VehicleDocumentRepository.SearchVehicleDocumentsByVehicleID(vehicle.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(*documents) == 0 {
+		return nil, fmt.Errorf("el vehículo %s no tiene documentos de respaldo", vehicle.Plate)
+	}
+
+	now := time.Now()
+	vehicle.VerificationStatus = entities.VerificationStatusVerified
+	vehicle.VerifiedBy = &reviewerID
+	vehicle.VerifiedAt = &now
+	vehicle.RejectionReason = ""
+	if err := uc.VehicleRepository.UpdateVehicleVerification(vehicle); err != nil {
+		return nil, fmt.Errorf("error al verificar vehículo: %w", err)
+	}
+
+	log.Printf("Vehículo %s verificado por %s", vehicle.Plate, reviewerID)
+	uc.notifyVerification(vehicle)
+	return vehicle, nil
+}
+
+func (uc *VehicleUsecase) RejectVehicle(vehicleID int, reviewerID string, reason string) (*entities.Vehicle, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("debe indicar el motivo del rechazo")
+	}
+
+	vehicle, err := uc.startReview(vehicleID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	vehicle.VerificationStatus = entities.VerificationStatusRejected
+	vehicle.VerifiedBy = &reviewerID
+	vehicle.VerifiedAt = &now
+	vehicle.RejectionReason = reason
+	if err := uc.VehicleRepository.UpdateVehicleVerification(vehicle); err != nil {
+		return nil, fmt.Errorf("error al rechazar vehículo: %w", err)
+	}
+
+	log.Printf("Vehículo %s rechazado por %s: %s", vehicle.Plate, reviewerID, reason)
+	uc.notifyVerification(vehicle)
+	return vehicle, nil
+}
+
+func (uc *VehicleUsecase) startReview(vehicleID int, reviewerID string) (*entities.Vehicle, error) {
+	reviewer, err := uc.UserRepository.SearchUserByID(reviewerID)
+	if err != nil || reviewer == nil || reviewer.Type != "employee" {
+		return nil, ErrReviewerNotEmployee
+	}
+	return uc.findVehicle(vehicleID)
+}
+
+func (uc *VehicleUsecase) findVehicle(vehicleID int) (*entities.Vehicle, error) {
+	vehicle, err := uc.VehicleRepository.SearchVehicleByID(vehicleID)
+	if err != nil || vehicle == nil {
+		return nil, ErrVehicleNotFound
+	}
+	return vehicle, nil
+}
+
+func (uc *VehicleUsecase) notifyVerification(vehicle *entities.Vehicle) {
+	if uc.WebSocketService == nil || vehicle.CustomerID == "" {
+		return
+	}
+	uc.WebSocketService.NotifyUser(vehicle.CustomerID, "vehicle_"+vehicle.VerificationStatus, vehicle)
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+const (
+	DocumentTypeRegistration = "registration_certificate"
+)
+
+type VehicleDocument struct {
+	ID           int       `json:"id"`
+	VehicleID    int       `json:"vehicleId"`
+	DocumentType string    `json:"documentType"`
+	FileName     string    `json:"fileName"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	StoragePath  string    `json:"-"`
+	UploadedBy   string    `json:"uploadedBy"`
+	UploadedAt   time.Time `json:"uploadedAt"`
+}
+
+type VehicleDocuments []VehicleDocument
@@ -2,14 +2,51 @@ package entities
 
 import "time"
 
+const (
+	VerificationStatusPending  = "pending"
+	VerificationStatusVerified = "verified"
+	VerificationStatusRejected = "rejected"
+)
+
 type Vehicle struct {
-	ID          int       `json:"id"`
-	Plate       string    `json:"plate"`
-	Brand       string    `json:"brand"`
-	Model       string    `json:"model"`
-	VehicleType string    `json:"vehicleType"`
-	CustomerID  string    `json:"customerId"` 
-	CreatedAt   time.Time `json:"createdAt"`
+	ID                 int        `json:"id"`
+	Plate              string     `json:"plate"`
+	Brand              string     `json:"brand"`
+	Model              string     `json:"model"`
+	VehicleType        string     `json:"vehicleType"`
+	IsElectric         bool       `json:"isElectric"`
+	CustomerID         string     `json:"customerId"` 
+	CreatedAt          time.Time  `json:"createdAt"`
+	VerificationStatus string     `json:"verificationStatus"`
+	VerifiedBy         *string    `json:"verifiedBy,omitempty"`
+	VerifiedAt         *time.Time `json:"verifiedAt,omitempty"`
+	RejectionReason    string     `json:"rejectionReason,omitempty"`
+	IsTemporary        bool       `json:"isTemporary"`
+	ValidFrom          *time.Time `json:"validFrom,omitempty"`
+	ValidUntil         *time.Time `json:"validUntil,omitempty"`
+}
+
+func (v *Vehicle) IsVerified() bool {
+	return v.VerificationStatus == VerificationStatusVerified
+}
+
+// Los vehículos permanentes siempre son válidos; los temporales solo dentro de su ventana
+func (v *Vehicle) IsValidAt(t time.Time) bool {
+	return v.CoversPeriod(t, t) && !v.IsExpired(t)
+}
+
+func (v *Vehicle) CoversPeriod(start, end time.Time) bool {
+	if !v.IsTemporary {
+		return true
+	}
+	if v.ValidFrom != nil && start.Before(*v.ValidFrom) {
+		return false
+	}
+	return v.ValidUntil == nil || !end.After(*v.ValidUntil)
+}
+
+func (v *Vehicle) IsExpired(t time.Time) bool {
+	return v.IsTemporary && v.ValidUntil != nil && !t.Before(*v.ValidUntil)
 }
 
 type Vehicles []Vehicle
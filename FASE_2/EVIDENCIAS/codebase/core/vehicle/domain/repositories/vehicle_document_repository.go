@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"io"
+
+	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+)
+
+type VehicleDocumentRepository interface {
+	SearchVehicleDocumentByID(id int) (*entities.VehicleDocument, error)
+	SearchVehicleDocumentsByVehicleID(vehicleID int) (*entities.VehicleDocuments, error)
+	CreateVehicleDocument(document *entities.VehicleDocument) error
+	DeleteVehicleDocumentByID(id int) error
+}
+
+type DocumentStorage interface {
+	Save(name string, content io.Reader) (string, int64, error)
+	Open(path string) (io.ReadCloser, error)
+	Delete(path string) error
+}
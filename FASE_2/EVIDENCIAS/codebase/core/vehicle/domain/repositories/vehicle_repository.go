@@ -5,9 +5,13 @@ import "github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
 type VehicleRepository interface {
 	SearchVehicleByID(id int) (*entities.Vehicle, error)
 	SearchVehiclesByCustomerID(customerID string) (*entities.Vehicles, error)
+	SearchCarpoolVehiclesByMemberID(customerID string) (*entities.Vehicles, error)
 	SearchVehicles() (*entities.Vehicles, error)
+	SearchVehiclesByVerificationStatus(status string) (*entities.Vehicles, error)
 	SearchVehicleByPlate(plate string) (*entities.Vehicle, error)
+	SearchVehiclesByPlateLength(minLength, maxLength int) (*entities.Vehicles, error)
 	CreateVehicle(vehicle *entities.Vehicle) error
 	UpdateVehicleByID(vehicle *entities.Vehicle) error
+	UpdateVehicleVerification(vehicle *entities.Vehicle) error
 	DeleteVehicleByID(id int) error
 }
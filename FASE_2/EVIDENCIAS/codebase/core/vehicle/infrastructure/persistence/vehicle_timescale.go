@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,13 +20,21 @@ func NewTimescaleVehicleRepository(pool *pgxpool.Pool) *TimescaleVehicleReposito
 	}
 }
 
+const vehicleColumns = `id, plate, brand, model, vehicle_type, is_electric, customer_id, created_at,
+	verification_status, verified_by, verified_at, rejection_reason, is_temporary, valid_from, valid_until`
+
+func scanVehicle(row pgx.Row, v *entities.Vehicle) error {
+	return row.Scan(&v.ID, &v.Plate, &v.Brand, &v.Model, &v.VehicleType, &v.IsElectric, &v.CustomerID, &v.CreatedAt,
+		&v.VerificationStatus, &v.VerifiedBy, &v.VerifiedAt, &v.RejectionReason, &v.IsTemporary, &v.ValidFrom, &v.ValidUntil)
+}
+
 func (r *TimescaleVehicleRepository) SearchVehicleByID(id int) (*entities.Vehicle, error) {
 	ctx := context.Background()
 	fmt.Println("Searching vehicle by ID:", id)
-	query := `SELECT id, plate, brand, model, vehicle_type, customer_id, created_at FROM vehicle WHERE id = $1`
+	query := `SELECT ` + vehicleColumns + ` FROM vehicle WHERE id = $1`
 	row := r.dbPool.QueryRow(ctx, query, id)
 	var v entities.Vehicle
-	err := row.Scan(&v.ID, &v.Plate, &v.Brand, &v.Model, &v.VehicleType, &v.CustomerID, &v.CreatedAt)
+	err := scanVehicle(row, &v)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +42,7 @@ func (r *TimescaleVehicleRepository) SearchVehicleByID(id int) (*entities.Vehicl
 }
 func (r *TimescaleVehicleRepository) SearchVehiclesByCustomerID(customerID string) (*entities.Vehicles, error) {
 	ctx := context.Background()
-	query := `SELECT id, plate, brand, model, vehicle_type, customer_id, created_at FROM vehicle WHERE customer_id = $1`
+	query := `SELECT ` + vehicleColumns + ` FROM vehicle WHERE customer_id = $1`
 	rows, err := r.dbPool.Query(ctx, query, customerID)
 	if err != nil {
 		return nil, err
@@ -42,7 +51,34 @@ func (r *TimescaleVehicleRepository) SearchVehiclesByCustomerID(customerID strin
 	var vehicles entities.Vehicles
 	for rows.Next() {
 		var v entities.Vehicle
-		if err := rows.Scan(&v.ID, &v.Plate, &v.Brand, &v.Model, &v.VehicleType, &v.CustomerID, &v.CreatedAt); err != nil {
+		if err := scanVehicle(rows, &v); err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &vehicles, nil
+}
+
+func (r *TimescaleVehicleRepository) SearchCarpoolVehiclesByMemberID(customerID string) (*entities.Vehicles, error) {
+	ctx := context.Background()
+	query := `SELECT DISTINCT v.id, v.plate, v.brand, v.model, v.vehicle_type, v.is_electric, v.customer_id, v.created_at,
+		v.verification_status, v.verified_by, v.verified_at, v.rejection_reason, v.is_temporary, v.valid_from, v.valid_until
+	FROM vehicle v
+	JOIN carpool c ON c.vehicle_id = v.id
+	JOIN carpool_member m ON m.carpool_id = c.id
+	WHERE m.customer_id = $1 AND v.customer_id <> $1`
+	rows, err := r.dbPool.Query(ctx, query, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	vehicles := entities.Vehicles{}
+	for rows.Next() {
+		var v entities.Vehicle
+		if err := scanVehicle(rows, &v); err != nil {
 			return nil, err
 		}
 		vehicles = append(vehicles, v)
@@ -55,19 +91,67 @@ func (r *TimescaleVehicleRepository) SearchVehiclesByCustomerID(customerID strin
 
 func (r *TimescaleVehicleRepository) SearchVehicleByPlate(plate string) (*entities.Vehicle, error) {
 	ctx := context.Background()
-	query := `SELECT id, plate, brand, model, vehicle_type, customer_id, created_at FROM vehicle WHERE plate = $1`
+	query := `SELECT ` + vehicleColumns + ` FROM vehicle WHERE UPPER(REGEXP_REPLACE(plate, '[^A-Za-z0-9]', '', 'g')) = $1`
 	row := r.dbPool.QueryRow(ctx, query, plate)
 	var v entities.Vehicle
-	err := row.Scan(&v.ID, &v.Plate, &v.Brand, &v.Model, &v.VehicleType, &v.CustomerID, &v.CreatedAt)
+	err := scanVehicle(row, &v)
 	if err != nil {
 		return nil, err
 	}
 	return &v, nil
 }
 
+// Largo de la patente limpia, igual que plate/application.CleanPlate; está indexado para acotar los candidatos del OCR
+const vehiclePlateLength = `LENGTH(REGEXP_REPLACE(plate, '[^A-Za-z0-9]', '', 'g'))`
+
+func (r *TimescaleVehicleRepository) SearchVehiclesByPlateLength(minLength, maxLength int) (*entities.Vehicles, error) {
+	ctx := context.Background()
+	query := `SELECT ` + vehicleColumns + ` FROM vehicle WHERE ` + vehiclePlateLength + ` BETWEEN $1 AND $2`
+	rows, err := r.dbPool.Query(ctx, query, minLength, maxLength)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vehicles := entities.Vehicles{}
+	for rows.Next() {
+		var v entities.Vehicle
+		if err := scanVehicle(rows, &v); err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &vehicles, nil
+}
+
+func (r *TimescaleVehicleRepository) SearchVehiclesByVerificationStatus(status string) (*entities.Vehicles, error) {
+	ctx := context.Background()
+	query := `SELECT ` + vehicleColumns + ` FROM vehicle WHERE verification_status = $1 ORDER BY created_at`
+	rows, err := r.dbPool.Query(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	vehicles := entities.Vehicles{}
+	for rows.Next() {
+		var v entities.Vehicle
+		if err := scanVehicle(rows, &v); err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &vehicles, nil
+}
+
 func (r *TimescaleVehicleRepository) SearchVehicles() (*entities.Vehicles, error) {
 	ctx := context.Background()
-	query := `SELECT id, plate, brand, model, vehicle_type, customer_id, created_at FROM vehicle`
+	query := `SELECT ` + vehicleColumns + ` FROM vehicle`
 	rows, err := r.dbPool.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -77,7 +161,7 @@ func (r *TimescaleVehicleRepository) SearchVehicles() (*entities.Vehicles, error
 	var vehicles entities.Vehicles
 	for rows.Next() {
 		var v entities.Vehicle
-		if err := rows.Scan(&v.ID, &v.Plate, &v.Brand, &v.Model, &v.VehicleType, &v.CustomerID, &v.CreatedAt); err != nil {
+		if err := scanVehicle(rows, &v); err != nil {
 			return nil, err
 		}
 		vehicles = append(vehicles, v)
@@ -92,25 +176,39 @@ func (r *TimescaleVehicleRepository) CreateVehicle(vehicle *entities.Vehicle) er
 	ctx := context.Background()
 	query := `
 	INSERT INTO vehicle (
-		plate, brand, model, vehicle_type, customer_id, created_at
+		plate, brand, model, vehicle_type, is_electric, customer_id, created_at, verification_status,
+		is_temporary, valid_from, valid_until
 	) VALUES (
-		$1, $2, $3, $4, $5, $6
-	);
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+	) RETURNING id;
 `
 	
 	if vehicle.CreatedAt.IsZero() {
 		vehicle.CreatedAt = time.Now()
 	}
+	if vehicle.VerificationStatus == "" {
+		vehicle.VerificationStatus = entities.VerificationStatusPending
+	}
 
-	_, err := r.dbPool.Exec(ctx, query, vehicle.Plate, vehicle.Brand, vehicle.Model, vehicle.VehicleType, vehicle.CustomerID, vehicle.CreatedAt)
-	return err
+	return r.dbPool.QueryRow(ctx, query, vehicle.Plate, vehicle.Brand, vehicle.Model, vehicle.VehicleType, vehicle.IsElectric,
+		vehicle.CustomerID, vehicle.CreatedAt, vehicle.VerificationStatus, vehicle.IsTemporary, vehicle.ValidFrom, vehicle.ValidUntil).Scan(&vehicle.ID)
 }
 
 func (r *TimescaleVehicleRepository) UpdateVehicleByID(v *entities.Vehicle) error {
 	ctx := context.Background()
-	query := `UPDATE vehicle SET plate = $2, brand = $3, model = $4, vehicle_type = $5, customer_id = $6, created_at = $7 WHERE id = $1`
+	query := `UPDATE vehicle SET plate = $2, brand = $3, model = $4, vehicle_type = $5, is_electric = $6, customer_id = $7, created_at = $8,
+		is_temporary = $9, valid_from = $10, valid_until = $11 WHERE id = $1`
+
+	_, err := r.dbPool.Exec(ctx, query, v.ID, v.Plate, v.Brand, v.Model, v.VehicleType, v.IsElectric, v.CustomerID, v.CreatedAt,
+		v.IsTemporary, v.ValidFrom, v.ValidUntil)
+	return err
+}
+
+func (r *TimescaleVehicleRepository) UpdateVehicleVerification(v *entities.Vehicle) error {
+	ctx := context.Background()
+	query := `UPDATE vehicle SET verification_status = $2, verified_by = $3, verified_at = $4, rejection_reason = $5 WHERE id = $1`
 
-	_, err := r.dbPool.Exec(ctx, query, v.ID, v.Plate, v.Brand, v.Model, v.VehicleType, v.CustomerID, v.CreatedAt)
+	_, err := r.dbPool.Exec(ctx, query, v.ID, v.VerificationStatus, v.VerifiedBy, v.VerifiedAt, v.RejectionReason)
 	return err
 }
 
@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleVehicleDocumentRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleVehicleDocumentRepository(pool *pgxpool.Pool) *TimescaleVehicleDocumentRepository {
+	return &TimescaleVehicleDocumentRepository{
+		dbPool: pool,
+	}
+}
+
+const vehicleDocumentColumns = `id, vehicle_id, document_type, file_name, content_type, size, storage_path, uploaded_by, uploaded_at`
+
+func scanVehicleDocument(row pgx.Row, d *entities.VehicleDocument) error {
+	return row.Scan(&d.ID, &d.VehicleID, &d.DocumentType, &d.FileName, &d.ContentType, &d.Size, &d.StoragePath, &d.UploadedBy, &d.UploadedAt)
+}
+
+func (r *TimescaleVehicleDocumentRepository) SearchVehicleDocumentByID(id int) (*entities.VehicleDocument, error) {
+	ctx := context.Background()
+	query := `SELECT ` + vehicleDocumentColumns + ` FROM vehicle_document WHERE id = $1`
+	var d entities.VehicleDocument
+	if err := scanVehicleDocument(r.dbPool.QueryRow(ctx, query, id), &d); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *TimescaleVehicleDocumentRepository) SearchVehicleDocumentsByVehicleID(vehicleID int) (*entities.VehicleDocuments, error) {
+	ctx := context.Background()
+	query := `SELECT ` + vehicleDocumentColumns + ` FROM vehicle_document WHERE vehicle_id = $1 ORDER BY uploaded_at DESC`
+	rows, err := r.dbPool.Query(ctx, query, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := entities.VehicleDocuments{}
+	for rows.Next() {
+		var d entities.VehicleDocument
+		if err := scanVehicleDocument(rows, &d); err != nil {
+			return nil, err
+		}
+		documents = append(documents, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &documents, nil
+}
+
+func (r *TimescaleVehicleDocumentRepository) CreateVehicleDocument(document *entities.VehicleDocument) error {
+	ctx := context.Background()
+	query := `INSERT INTO vehicle_document (vehicle_id, document_type, file_name, content_type, size, storage_path, uploaded_by, uploaded_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+
+	if document.UploadedAt.IsZero() {
+		document.UploadedAt = time.Now()
+	}
+
+	return r.dbPool.QueryRow(ctx, query, document.VehicleID, document.DocumentType, document.FileName, document.ContentType,
+		document.Size, document.StoragePath, document.UploadedBy, document.UploadedAt).Scan(&document.ID)
+}
+
+func (r *TimescaleVehicleDocumentRepository) DeleteVehicleDocumentByID(id int) error {
+	ctx := context.Background()
+	query := `DELETE FROM vehicle_document WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, id)
+	return err
+}
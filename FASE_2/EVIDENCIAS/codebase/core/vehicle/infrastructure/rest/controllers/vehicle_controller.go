@@ -2,22 +2,36 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
+	"firebase.google.com/go/v4/auth"
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	userRepositories "github.com/gonzalohonorato/servercorego/core/user/domain/repositories"
 	"github.com/gonzalohonorato/servercorego/core/vehicle/application"
 	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
 	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/websocket/infrastructure"
 	"github.com/gorilla/mux"
 )
 
 type VehicleController struct {
 	VehicleUsecase *application.VehicleUsecase
+	FirebaseAuth   *auth.Client
 }
 
-func NewVehicleController(vehicleRepository repositories.VehicleRepository) *VehicleController {
-	vehicleUseCase := application.NewVehicleUsecase(vehicleRepository)
+func NewVehicleController(
+	vehicleRepository repositories.VehicleRepository,
+	plateFormatRepository plateRepositories.PlateFormatRepository,
+	vehicleDocumentRepository repositories.VehicleDocumentRepository,
+	documentStorage repositories.DocumentStorage,
+	userRepository userRepositories.UserRepository,
+	wsService *infrastructure.WebSocketService,
+) *VehicleController {
+	vehicleUseCase := application.NewVehicleUsecase(vehicleRepository, plateFormatRepository, vehicleDocumentRepository, documentStorage, userRepository, wsService)
 
 	return &VehicleController{
 		VehicleUsecase: vehicleUseCase,
@@ -45,7 +59,8 @@ func (uc *VehicleController) GetVehicleByID(w http.ResponseWriter, r *http.Reque
 func (uc *VehicleController) GetVehiclesByCustomerID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	customerID := vars["customerId"]
-	vehicles, err := uc.VehicleUsecase.SearchVehiclesByCustomerID(customerID)
+	includeExpired := r.URL.Query().Get("includeExpired") == "true"
+	vehicles, err := uc.VehicleUsecase.SearchVehiclesByCustomerID(customerID, includeExpired)
 	if err != nil {
 		fmt.Println(err)
 		http.Error(w, "Vehicles not found", http.StatusNotFound)
@@ -74,6 +89,18 @@ func (uc *VehicleController) PostVehicle(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := uc.VehicleUsecase.CreateVehicle(&newVehicle); err != nil {
+		if errors.Is(err, plateApplication.ErrInvalidPlateFormat) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, application.ErrTemporaryVehicleLimit) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, application.ErrInvalidVehicleValidity) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Error creating vehicle", http.StatusInternalServerError)
 		return
 	}
@@ -89,6 +116,18 @@ func (uc *VehicleController) PutVehicle(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := uc.VehicleUsecase.UpdateVehicleById(&newVehicle); err != nil {
+		if errors.Is(err, plateApplication.ErrInvalidPlateFormat) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, application.ErrTemporaryVehicleLimit) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, application.ErrInvalidVehicleValidity) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Error update vehicle", http.StatusInternalServerError)
 		return
 	}
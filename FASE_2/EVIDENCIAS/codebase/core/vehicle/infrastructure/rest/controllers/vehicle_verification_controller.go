@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/gonzalohonorato/servercorego/core/vehicle/application"
+	"github.com/gonzalohonorato/servercorego/core/vehicle/domain/entities"
+	"github.com/gorilla/mux"
+)
+
+const maxDocumentUploadSize = 10 << 20
+
+var errUnauthenticated = errors.New("se requiere un token de Firebase válido en Authorization")
+
+func (uc *VehicleController) SetFirebaseAuth(firebaseAuth *auth.Client) {
+	uc.FirebaseAuth = firebaseAuth
+}
+
+// Identifica al usuario a partir del ID token de Firebase enviado como "Authorization: Bearer <token>"
+func (uc *VehicleController) requesterID(r *http.Request) (string, error) {
+	idToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || idToken == "" || uc.FirebaseAuth == nil {
+		return "", errUnauthenticated
+	}
+	token, err := uc.FirebaseAuth.VerifyIDToken(r.Context(), idToken)
+	if err != nil {
+		return "", errUnauthenticated
+	}
+	return token.UID, nil
+}
+
+func (uc *VehicleController) GetVehicleVerifications(w http.ResponseWriter, r *http.Request) {
+	vehicles, err := uc.VehicleUsecase.SearchVehiclesByVerificationStatus(r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(vehicles)
+}
+
+func (uc *VehicleController) GetVehicleDocuments(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	documents, err := uc.VehicleUsecase.SearchVehicleDocuments(idInt)
+	if err != nil {
+		writeVerificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(documents)
+}
+
+func (uc *VehicleController) PostVehicleDocument(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	uploadedBy, err := uc.requesterID(r)
+	if err != nil {
+		writeVerificationError(w, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxDocumentUploadSize)
+	if err := r.ParseMultipartForm(maxDocumentUploadSize); err != nil {
+		http.Error(w, "Formulario inválido o archivo demasiado grande", http.StatusBadRequest)
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Archivo requerido en el campo file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	contentType, _, _ := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	if contentType == "" || contentType == "application/octet-stream" {
+		sniff := make([]byte, 512)
+		n, _ := io.ReadFull(file, sniff)
+		contentType = http.DetectContentType(sniff[:n])
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			http.Error(w, "Error al leer archivo", http.StatusBadRequest)
+			return
+		}
+	}
+
+	document := entities.VehicleDocument{
+		VehicleID:    idInt,
+		DocumentType: r.FormValue("documentType"),
+		FileName:     header.Filename,
+		ContentType:  contentType,
+		UploadedBy:   uploadedBy,
+	}
+	if err := uc.VehicleUsecase.UploadVehicleDocument(&document, file); err != nil {
+		writeVerificationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(document)
+}
+
+func (uc *VehicleController) GetVehicleDocumentFile(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	document, content, err := uc.VehicleUsecase.OpenVehicleDocument(idInt)
+	if err != nil {
+		writeVerificationError(w, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", document.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": document.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	io.Copy(w, content)
+}
+
+func (uc *VehicleController) DeleteVehicleDocument(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	if err := uc.VehicleUsecase.DeleteVehicleDocument(idInt); err != nil {
+		writeVerificationError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (uc *VehicleController) PostVehicleVerification(w http.ResponseWriter, r *http.Request) {
+	idInt, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	reviewedBy, err := uc.requesterID(r)
+	if err != nil {
+		writeVerificationError(w, err)
+		return
+	}
+
+	var request struct {
+		Approved bool   `json:"approved"`
+		Reason   string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	var vehicle *entities.Vehicle
+	if request.Approved {
+		vehicle, err = uc.VehicleUsecase.VerifyVehicle(idInt, reviewedBy)
+	} else {
+		vehicle, err = uc.VehicleUsecase.RejectVehicle(idInt, reviewedBy, request.Reason)
+	}
+	if err != nil {
+		writeVerificationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(vehicle)
+}
+
+func writeVerificationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errUnauthenticated):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, application.ErrVehicleNotFound), errors.Is(err, application.ErrVehicleDocumentNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, application.ErrReviewerNotEmployee):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, application.ErrInvalidDocument):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		fmt.Println(err)
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	}
+}
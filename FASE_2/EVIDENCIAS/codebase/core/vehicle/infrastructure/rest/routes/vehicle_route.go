@@ -1,16 +1,38 @@
 package routes
 
 import (
+	"log"
+
 	"github.com/gonzalohonorato/servercorego/config/injector"
 	"github.com/gonzalohonorato/servercorego/core/vehicle/infrastructure/rest/controllers"
 	"github.com/gorilla/mux"
 )
 
 func VehicleRoutes(router *mux.Router, container *injector.Container) {
-	controller := controllers.NewVehicleController(container.ProvideVehicleRepository())
+	controller := controllers.NewVehicleController(
+		container.ProvideVehicleRepository(),
+		container.ProvidePlateFormatRepository(),
+		container.ProvideVehicleDocumentRepository(),
+		container.ProvideVehicleDocumentStorage(),
+		container.ProvideUserRepository(),
+		container.ProvideWebSocketService(),
+	)
+	firebaseAuth, err := container.GetFirebaseAuth()
+	if err != nil {
+		log.Printf("Warning: Firebase Auth not initialized, vehicle verifications will reject requests: %v", err)
+	} else {
+		controller.SetFirebaseAuth(firebaseAuth)
+	}
+
 	router.HandleFunc("/vehicles", controller.PutVehicle).Methods("PUT")
 	router.HandleFunc("/vehicles", controller.GetVehicles).Methods("GET")
 	router.HandleFunc("/vehicles-user/{customerId}", controller.GetVehiclesByCustomerID).Methods("GET")
+	router.HandleFunc("/vehicle-verifications", controller.GetVehicleVerifications).Methods("GET")
+	router.HandleFunc("/vehicle-documents/{id}", controller.GetVehicleDocumentFile).Methods("GET")
+	router.HandleFunc("/vehicle-documents/{id}", controller.DeleteVehicleDocument).Methods("DELETE")
+	router.HandleFunc("/vehicles/{id}/documents", controller.GetVehicleDocuments).Methods("GET")
+	router.HandleFunc("/vehicles/{id}/documents", controller.PostVehicleDocument).Methods("POST")
+	router.HandleFunc("/vehicles/{id}/verification", controller.PostVehicleVerification).Methods("POST")
 	router.HandleFunc("/vehicles/{id}", controller.DeleteVehicleByID).Methods("DELETE")
 	router.HandleFunc("/vehicles/{id}", controller.GetVehicleByID).Methods("GET")
 	router.HandleFunc("/vehicles", controller.PostVehicle).Methods("POST")
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultDocumentsDir = "./data/vehicle-documents"
+
+// Guarda los documentos en disco bajo un directorio base; las rutas devueltas son relativas a ese directorio
+type LocalDocumentStorage struct {
+	baseDir string
+}
+
+func NewLocalDocumentStorage(baseDir string) *LocalDocumentStorage {
+	if baseDir == "" {
+		baseDir = defaultDocumentsDir
+	}
+	return &LocalDocumentStorage{
+		baseDir: baseDir,
+	}
+}
+
+func (s *LocalDocumentStorage) Save(name string, content io.Reader) (string, int64, error) {
+	fullPath, err := s.resolve(name)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+		return "", 0, fmt.Errorf("error al crear directorio de documentos: %w", err)
+	}
+
+	file, err := os.OpenFile(fullPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", 0, fmt.Errorf("error al crear documento: %w", err)
+	}
+
+	size, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(fullPath)
+		return "", 0, fmt.Errorf("error al guardar documento: %w", err)
+	}
+	return filepath.ToSlash(name), size, nil
+}
+
+func (s *LocalDocumentStorage) Open(path string) (io.ReadCloser, error) {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(fullPath)
+}
+
+func (s *LocalDocumentStorage) Delete(path string) error {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalDocumentStorage) resolve(name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if cleaned == "." || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("ruta de documento inválida: %s", name)
+	}
+	return filepath.Join(s.baseDir, cleaned), nil
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	plateApplication "github.com/gonzalohonorato/servercorego/core/plate/application"
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/watchlist/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/watchlist/domain/repositories"
+)
+
+type WatchlistUsecase struct {
+	WatchlistRepository repositories.WatchlistRepository
+	PlateFormatUsecase  *plateApplication.PlateFormatUsecase
+}
+
+func NewWatchlistUsecase(watchlistRepo repositories.WatchlistRepository, plateFormatRepo plateRepositories.PlateFormatRepository) *WatchlistUsecase {
+	return &WatchlistUsecase{
+		WatchlistRepository: watchlistRepo,
+		PlateFormatUsecase:  plateApplication.NewPlateFormatUsecase(plateFormatRepo),
+	}
+}
+
+func (uc *WatchlistUsecase) SearchWatchlistEntryByID(id int) (*entities.WatchlistEntry, error) {
+	return uc.WatchlistRepository.SearchWatchlistEntryByID(id)
+}
+
+func (uc *WatchlistUsecase) SearchWatchlistEntries(listType string) (*entities.WatchlistEntries, error) {
+	if listType != "" {
+		return uc.WatchlistRepository.SearchWatchlistEntriesByListType(listType)
+	}
+	return uc.WatchlistRepository.SearchWatchlistEntries()
+}
+
+func (uc *WatchlistUsecase) CreateWatchlistEntry(entry *entities.WatchlistEntry) error {
+	if err := uc.prepareWatchlistEntry(entry); err != nil {
+		return err
+	}
+	return uc.WatchlistRepository.CreateWatchlistEntry(entry)
+}
+
+func (uc *WatchlistUsecase) UpdateWatchlistEntryByID(entry *entities.WatchlistEntry) error {
+	if err := uc.prepareWatchlistEntry(entry); err != nil {
+		return err
+	}
+	return uc.WatchlistRepository.UpdateWatchlistEntryByID(entry)
+}
+
+func (uc *WatchlistUsecase) DeleteWatchlistEntryByID(id int) error {
+	return uc.WatchlistRepository.DeleteWatchlistEntryByID(id)
+}
+
+func (uc *WatchlistUsecase) FindActiveEntry(plate string, at time.Time) (*entities.WatchlistEntry, error) {
+	entries, err := uc.WatchlistRepository.SearchWatchlistEntriesByPlate(uc.PlateFormatUsecase.NormalizePlate(plate))
+	if err != nil {
+		return nil, err
+	}
+
+	var allowEntry *entities.WatchlistEntry
+	for i := range *entries {
+		entry := &(*entries)[i]
+		if !entry.IsValidAt(at) {
+			continue
+		}
+		if entry.ListType == entities.WatchlistBlock {
+			return entry, nil
+		}
+		if allowEntry == nil && entry.ListType == entities.WatchlistAllow {
+			allowEntry = entry
+		}
+	}
+
+	return allowEntry, nil
+}
+
+func (uc *WatchlistUsecase) prepareWatchlistEntry(entry *entities.WatchlistEntry) error {
+	if entry.Plate == "" {
+		return fmt.Errorf("la patente es requerida")
+	}
+	if entry.ListType != entities.WatchlistBlock && entry.ListType != entities.WatchlistAllow {
+		return fmt.Errorf("tipo de lista inválido: %s", entry.ListType)
+	}
+	if entry.Reason == "" || entry.AddedBy == "" {
+		return fmt.Errorf("el motivo y el responsable son requeridos")
+	}
+	if entry.ValidFrom != nil && entry.ValidUntil != nil && entry.ValidUntil.Before(*entry.ValidFrom) {
+		return fmt.Errorf("la fecha de término no puede ser anterior a la de inicio")
+	}
+
+	entry.Plate = uc.PlateFormatUsecase.NormalizePlate(entry.Plate)
+	return nil
+}
@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+const (
+	WatchlistBlock = "block"
+	WatchlistAllow = "allow"
+)
+
+type WatchlistEntry struct {
+	ID         int        `json:"id"`
+	Plate      string     `json:"plate"`
+	ListType   string     `json:"listType"`
+	Reason     string     `json:"reason"`
+	ValidFrom  *time.Time `json:"validFrom"`
+	ValidUntil *time.Time `json:"validUntil"`
+	AddedBy    string     `json:"addedBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type WatchlistEntries []WatchlistEntry
+
+func (e *WatchlistEntry) IsValidAt(t time.Time) bool {
+	if e.ValidFrom != nil && t.Before(*e.ValidFrom) {
+		return false
+	}
+	if e.ValidUntil != nil && t.After(*e.ValidUntil) {
+		return false
+	}
+	return true
+}
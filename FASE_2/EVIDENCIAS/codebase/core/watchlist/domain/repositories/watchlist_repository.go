@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/gonzalohonorato/servercorego/core/watchlist/domain/entities"
+
+type WatchlistRepository interface {
+	SearchWatchlistEntryByID(id int) (*entities.WatchlistEntry, error)
+	SearchWatchlistEntries() (*entities.WatchlistEntries, error)
+	SearchWatchlistEntriesByListType(listType string) (*entities.WatchlistEntries, error)
+	SearchWatchlistEntriesByPlate(plate string) (*entities.WatchlistEntries, error)
+	CreateWatchlistEntry(entry *entities.WatchlistEntry) error
+	UpdateWatchlistEntryByID(entry *entities.WatchlistEntry) error
+	DeleteWatchlistEntryByID(id int) error
+}
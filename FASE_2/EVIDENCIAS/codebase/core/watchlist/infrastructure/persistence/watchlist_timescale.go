@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/gonzalohonorato/servercorego/core/watchlist/domain/entities"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimescaleWatchlistRepository struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTimescaleWatchlistRepository(pool *pgxpool.Pool) *TimescaleWatchlistRepository {
+	return &TimescaleWatchlistRepository{
+		dbPool: pool,
+	}
+}
+
+func (r *TimescaleWatchlistRepository) SearchWatchlistEntryByID(id int) (*entities.WatchlistEntry, error) {
+	ctx := context.Background()
+	query := `SELECT id, plate, list_type, reason, valid_from, valid_until, added_by, created_at FROM plate_watchlist WHERE id = $1`
+	row := r.dbPool.QueryRow(ctx, query, id)
+	var e entities.WatchlistEntry
+	err := row.Scan(&e.ID, &e.Plate, &e.ListType, &e.Reason, &e.ValidFrom, &e.ValidUntil, &e.AddedBy, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *TimescaleWatchlistRepository) SearchWatchlistEntries() (*entities.WatchlistEntries, error) {
+	query := `SELECT id, plate, list_type, reason, valid_from, valid_until, added_by, created_at FROM plate_watchlist ORDER BY created_at DESC`
+	return r.searchWatchlistEntries(query)
+}
+
+func (r *TimescaleWatchlistRepository) SearchWatchlistEntriesByListType(listType string) (*entities.WatchlistEntries, error) {
+	query := `SELECT id, plate, list_type, reason, valid_from, valid_until, added_by, created_at FROM plate_watchlist WHERE list_type = $1 ORDER BY created_at DESC`
+	return r.searchWatchlistEntries(query, listType)
+}
+
+func (r *TimescaleWatchlistRepository) SearchWatchlistEntriesByPlate(plate string) (*entities.WatchlistEntries, error) {
+	query := `SELECT id, plate, list_type, reason, valid_from, valid_until, added_by, created_at FROM plate_watchlist WHERE plate = $1 ORDER BY created_at DESC`
+	return r.searchWatchlistEntries(query, plate)
+}
+
+func (r *TimescaleWatchlistRepository) searchWatchlistEntries(query string, args ...interface{}) (*entities.WatchlistEntries, error) {
+	ctx := context.Background()
+	rows, err := r.dbPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := entities.WatchlistEntries{}
+	for rows.Next() {
+		var e entities.WatchlistEntry
+		if err := rows.Scan(&e.ID, &e.Plate, &e.ListType, &e.Reason, &e.ValidFrom, &e.ValidUntil, &e.AddedBy, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &entries, nil
+}
+
+func (r *TimescaleWatchlistRepository) CreateWatchlistEntry(entry *entities.WatchlistEntry) error {
+	ctx := context.Background()
+	query := `
+	INSERT INTO plate_watchlist (
+		plate, list_type, reason, valid_from, valid_until, added_by, created_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7
+	) RETURNING id;
+`
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	return r.dbPool.QueryRow(ctx, query, entry.Plate, entry.ListType, entry.Reason, entry.ValidFrom,
+		entry.ValidUntil, entry.AddedBy, entry.CreatedAt).Scan(&entry.ID)
+}
+
+func (r *TimescaleWatchlistRepository) UpdateWatchlistEntryByID(e *entities.WatchlistEntry) error {
+	ctx := context.Background()
+	query := `UPDATE plate_watchlist SET plate = $2, list_type = $3, reason = $4, valid_from = $5, valid_until = $6, added_by = $7 WHERE id = $1`
+
+	_, err := r.dbPool.Exec(ctx, query, e.ID, e.Plate, e.ListType, e.Reason, e.ValidFrom, e.ValidUntil, e.AddedBy)
+	return err
+}
+
+func (r *TimescaleWatchlistRepository) DeleteWatchlistEntryByID(id int) error {
+	ctx := context.Background()
+	query := `DELETE FROM plate_watchlist WHERE id = $1`
+	_, err := r.dbPool.Exec(ctx, query, id)
+	return err
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	plateRepositories "github.com/gonzalohonorato/servercorego/core/plate/domain/repositories"
+	"github.com/gonzalohonorato/servercorego/core/watchlist/application"
+	"github.com/gonzalohonorato/servercorego/core/watchlist/domain/entities"
+	"github.com/gonzalohonorato/servercorego/core/watchlist/domain/repositories"
+	"github.com/gorilla/mux"
+)
+
+type WatchlistController struct {
+	WatchlistUsecase *application.WatchlistUsecase
+}
+
+func NewWatchlistController(
+	watchlistRepository repositories.WatchlistRepository,
+	plateFormatRepository plateRepositories.PlateFormatRepository,
+) *WatchlistController {
+	watchlistUseCase := application.NewWatchlistUsecase(watchlistRepository, plateFormatRepository)
+
+	return &WatchlistController{
+		WatchlistUsecase: watchlistUseCase,
+	}
+}
+
+func (uc *WatchlistController) GetWatchlistEntryByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entryID := vars["id"]
+	idInt, err := strconv.Atoi(entryID)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	entry, err := uc.WatchlistUsecase.SearchWatchlistEntryByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Watchlist entry not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (uc *WatchlistController) GetWatchlistEntries(w http.ResponseWriter, r *http.Request) {
+	entries, err := uc.WatchlistUsecase.SearchWatchlistEntries(r.URL.Query().Get("listType"))
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Watchlist entries not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (uc *WatchlistController) GetWatchlistCheck(w http.ResponseWriter, r *http.Request) {
+	plate := r.URL.Query().Get("plate")
+	if plate == "" {
+		http.Error(w, "plate query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := uc.WatchlistUsecase.FindActiveEntry(plate, time.Now())
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Error checking watchlist", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"plate": plate,
+		"entry": entry,
+	})
+}
+
+func (uc *WatchlistController) PostWatchlistEntry(w http.ResponseWriter, r *http.Request) {
+	var newEntry entities.WatchlistEntry
+	if err := json.NewDecoder(r.Body).Decode(&newEntry); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.WatchlistUsecase.CreateWatchlistEntry(&newEntry); err != nil {
+		http.Error(w, "Error creating watchlist entry: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newEntry)
+}
+
+func (uc *WatchlistController) PutWatchlistEntry(w http.ResponseWriter, r *http.Request) {
+	var entry entities.WatchlistEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := uc.WatchlistUsecase.UpdateWatchlistEntryByID(&entry); err != nil {
+		http.Error(w, "Error update watchlist entry: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (uc *WatchlistController) DeleteWatchlistEntryByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entryID := vars["id"]
+	idInt, err := strconv.Atoi(entryID)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	err = uc.WatchlistUsecase.DeleteWatchlistEntryByID(idInt)
+	if err != nil {
+		fmt.Println(err)
+		http.Error(w, "Watchlist entry not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+}
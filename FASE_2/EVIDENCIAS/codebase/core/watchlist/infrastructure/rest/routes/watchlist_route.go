@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gonzalohonorato/servercorego/config/injector"
+	"github.com/gonzalohonorato/servercorego/core/watchlist/infrastructure/rest/controllers"
+	"github.com/gorilla/mux"
+)
+
+func WatchlistRoutes(router *mux.Router, container *injector.Container) {
+	controller := controllers.NewWatchlistController(
+		container.ProvideWatchlistRepository(),
+		container.ProvidePlateFormatRepository(),
+	)
+
+	router.HandleFunc("/watchlist", controller.PutWatchlistEntry).Methods("PUT")
+	router.HandleFunc("/watchlist", controller.GetWatchlistEntries).Methods("GET")
+	router.HandleFunc("/watchlist/check", controller.GetWatchlistCheck).Methods("GET")
+	router.HandleFunc("/watchlist/{id}", controller.DeleteWatchlistEntryByID).Methods("DELETE")
+	router.HandleFunc("/watchlist/{id}", controller.GetWatchlistEntryByID).Methods("GET")
+	router.HandleFunc("/watchlist", controller.PostWatchlistEntry).Methods("POST")
+}
@@ -252,6 +252,24 @@ func (s *WebSocketService) BroadcastParkingUsage(data interface{}) {
 }
 
 
+func (s *WebSocketService) BroadcastAdminAlert(alertType string, priority string, payload interface{}) {
+	alert := map[string]interface{}{
+		"type":      alertType,
+		"priority":  priority,
+		"payload":   payload,
+		"timestamp": time.Now(),
+	}
+
+	jsonData, err := json.Marshal(alert)
+	if err != nil {
+		log.Printf("❌ Error al serializar alerta: %v", err)
+		return
+	}
+
+	log.Printf("🚨 Enviando alerta '%s' (prioridad %s) a %d administrador(es)", alertType, priority, len(s.adminClients))
+	s.broadcast <- jsonData
+}
+
 func (s *WebSocketService) NotifyUser(userID string, notificationType string, payload interface{}) {
 	log.Printf("🔔 Enviando notificación tipo '%s' a usuario %s", notificationType, userID)
 
@@ -2,8 +2,10 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/gonzalohonorato/servercorego/config/server"
+	"github.com/gonzalohonorato/servercorego/core/userimport/infrastructure/cli"
 	"github.com/joho/godotenv"
 )
 
@@ -12,5 +14,8 @@ func main() {
 	if err != nil {
 		log.Println("No se pudo cargar el archivo .env, usando variables de entorno del sistema")
 	}
+	if len(os.Args) > 1 && os.Args[1] == "import-users" {
+		os.Exit(cli.RunImportCommand(os.Args[2:]))
+	}
 	server.RunServer()
 }
@@ -0,0 +1,115 @@
+-- Convierte las columnas TIMESTAMP a TIMESTAMPTZ en bases creadas antes de este cambio.
+-- Los valores guardados se escribieron con la hora del contenedor (UTC por defecto);
+-- si el servidor corría en hora local, cambie 'UTC' por 'America/Santiago' antes de ejecutar.
+
+BEGIN;
+
+DROP VIEW IF EXISTS active_parking_usages;
+DROP VIEW IF EXISTS user_details;
+
+ALTER TABLE "user"
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE vehicle
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE reservation
+  ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE 'UTC',
+  ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE customer_schedule
+  ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE 'UTC',
+  ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE parking_usage
+  ALTER COLUMN entry_time TYPE TIMESTAMPTZ USING entry_time AT TIME ZONE 'UTC',
+  ALTER COLUMN exit_time TYPE TIMESTAMPTZ USING exit_time AT TIME ZONE 'UTC';
+
+ALTER TABLE feedback
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+  ALTER COLUMN response_at TYPE TIMESTAMPTZ USING response_at AT TIME ZONE 'UTC';
+
+ALTER TABLE notification_template
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE user_notification
+  ALTER COLUMN read_at TYPE TIMESTAMPTZ USING read_at AT TIME ZONE 'UTC';
+
+ALTER TABLE parking_access_log
+  ALTER COLUMN detected_at TYPE TIMESTAMPTZ USING detected_at AT TIME ZONE 'UTC';
+
+ALTER TABLE plate_watchlist
+  ALTER COLUMN valid_from TYPE TIMESTAMPTZ USING valid_from AT TIME ZONE 'UTC',
+  ALTER COLUMN valid_until TYPE TIMESTAMPTZ USING valid_until AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE parking_overstay
+  ALTER COLUMN expected_exit_time TYPE TIMESTAMPTZ USING expected_exit_time AT TIME ZONE 'UTC',
+  ALTER COLUMN detected_at TYPE TIMESTAMPTZ USING detected_at AT TIME ZONE 'UTC',
+  ALTER COLUMN exit_time TYPE TIMESTAMPTZ USING exit_time AT TIME ZONE 'UTC';
+
+ALTER TABLE parking_charge
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE payment_ledger
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE quota_movement
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE reservation_no_show
+  ALTER COLUMN reservation_start TYPE TIMESTAMPTZ USING reservation_start AT TIME ZONE 'UTC',
+  ALTER COLUMN recorded_at TYPE TIMESTAMPTZ USING recorded_at AT TIME ZONE 'UTC',
+  ALTER COLUMN waived_at TYPE TIMESTAMPTZ USING waived_at AT TIME ZONE 'UTC';
+
+ALTER TABLE booking_suspension
+  ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE 'UTC',
+  ALTER COLUMN ends_at TYPE TIMESTAMPTZ USING ends_at AT TIME ZONE 'UTC',
+  ALTER COLUMN lifted_at TYPE TIMESTAMPTZ USING lifted_at AT TIME ZONE 'UTC';
+
+ALTER TABLE accessibility_permit
+  ALTER COLUMN valid_from TYPE TIMESTAMPTZ USING valid_from AT TIME ZONE 'UTC',
+  ALTER COLUMN valid_until TYPE TIMESTAMPTZ USING valid_until AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE charging_session
+  ALTER COLUMN started_at TYPE TIMESTAMPTZ USING started_at AT TIME ZONE 'UTC',
+  ALTER COLUMN completed_at TYPE TIMESTAMPTZ USING completed_at AT TIME ZONE 'UTC',
+  ALTER COLUMN ended_at TYPE TIMESTAMPTZ USING ended_at AT TIME ZONE 'UTC',
+  ALTER COLUMN notified_at TYPE TIMESTAMPTZ USING notified_at AT TIME ZONE 'UTC',
+  ALTER COLUMN escalated_at TYPE TIMESTAMPTZ USING escalated_at AT TIME ZONE 'UTC';
+
+ALTER TABLE parking_closure
+  ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE 'UTC',
+  ALTER COLUMN ends_at TYPE TIMESTAMPTZ USING ends_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+  ALTER COLUMN cancelled_at TYPE TIMESTAMPTZ USING cancelled_at AT TIME ZONE 'UTC';
+
+ALTER TABLE calendar_event
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+CREATE OR REPLACE VIEW active_parking_usages AS
+SELECT * FROM
+  parking_usage pu
+WHERE
+  pu.exit_time IS NULL;
+
+CREATE OR REPLACE VIEW user_details AS
+SELECT
+  u.id AS user_id,
+  u.name,
+  u.email,
+  u.rut,
+  u.uid,
+  u.type AS user_type,
+  u.created_at,
+  c.type AS customer_type,
+  e.role AS employee_role
+FROM
+  miappdb.public.user u
+LEFT JOIN customer c ON u.id = c.id
+LEFT JOIN employee e ON u.id = e.id;
+
+COMMIT;
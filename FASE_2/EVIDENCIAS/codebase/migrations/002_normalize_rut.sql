@@ -0,0 +1,88 @@
+-- Normaliza los RUT guardados al formato canónico 12345678-5 (sin puntos, con guion, K en mayúscula).
+-- Solo se modifican los RUT con dígito verificador válido; los inválidos y los RUT de usuario que
+-- quedarían duplicados se informan con NOTICE para revisarlos a mano.
+-- La restricción UNIQUE sobre rut se reemplaza por un índice único parcial para que varios usuarios
+-- puedan quedar sin RUT.
+
+BEGIN;
+
+CREATE FUNCTION pg_temp.canonical_rut(raw TEXT) RETURNS TEXT AS $$
+DECLARE
+  cleaned TEXT := upper(regexp_replace(coalesce(raw, ''), '[.\s-]', '', 'g'));
+  body TEXT;
+  total INT := 0;
+  factor INT := 2;
+  remainder INT;
+  expected TEXT;
+BEGIN
+  IF cleaned !~ '^[0-9]{7,8}[0-9K]$' THEN
+    RETURN NULL;
+  END IF;
+  body := left(cleaned, -1);
+  FOR i IN REVERSE length(body)..1 LOOP
+    total := total + substr(body, i, 1)::INT * factor;
+    factor := CASE WHEN factor = 7 THEN 2 ELSE factor + 1 END;
+  END LOOP;
+  remainder := 11 - total % 11;
+  expected := CASE remainder WHEN 11 THEN '0' WHEN 10 THEN 'K' ELSE remainder::TEXT END;
+  IF expected <> right(cleaned, 1) THEN
+    RETURN NULL;
+  END IF;
+  RETURN body || '-' || expected;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Por cada RUT canónico se conserva el usuario que ya lo tiene en ese formato o, si no hay, el más antiguo
+WITH ranked AS (
+  SELECT id, pg_temp.canonical_rut(rut) AS canonical,
+         row_number() OVER (
+           PARTITION BY pg_temp.canonical_rut(rut)
+           ORDER BY rut = pg_temp.canonical_rut(rut) DESC, created_at, id
+         ) AS position
+  FROM "user"
+  WHERE pg_temp.canonical_rut(rut) IS NOT NULL
+)
+UPDATE "user" u
+SET rut = r.canonical
+FROM ranked r
+WHERE u.id = r.id AND r.position = 1 AND u.rut <> r.canonical;
+
+UPDATE parking_usage SET visitor_rut = pg_temp.canonical_rut(visitor_rut)
+WHERE pg_temp.canonical_rut(visitor_rut) <> visitor_rut;
+
+UPDATE parking_charge SET visitor_rut = pg_temp.canonical_rut(visitor_rut)
+WHERE pg_temp.canonical_rut(visitor_rut) <> visitor_rut;
+
+UPDATE payment_ledger SET visitor_rut = pg_temp.canonical_rut(visitor_rut)
+WHERE pg_temp.canonical_rut(visitor_rut) <> visitor_rut;
+
+UPDATE charging_session SET visitor_rut = pg_temp.canonical_rut(visitor_rut)
+WHERE pg_temp.canonical_rut(visitor_rut) <> visitor_rut;
+
+DO $$
+DECLARE
+  pending RECORD;
+BEGIN
+  FOR pending IN
+    SELECT id, email, rut,
+           CASE WHEN pg_temp.canonical_rut(rut) IS NULL THEN 'RUT inválido' ELSE 'RUT duplicado' END AS reason
+    FROM "user"
+    WHERE rut IS NOT NULL AND rut <> ''
+      AND (pg_temp.canonical_rut(rut) IS NULL OR pg_temp.canonical_rut(rut) <> rut)
+  LOOP
+    RAISE NOTICE 'Usuario % (%) con RUT "%" sin normalizar: %', pending.id, pending.email, pending.rut, pending.reason;
+  END LOOP;
+
+  FOR pending IN
+    SELECT id, visitor_rut FROM parking_usage
+    WHERE visitor_rut IS NOT NULL AND visitor_rut <> '' AND pg_temp.canonical_rut(visitor_rut) IS NULL
+  LOOP
+    RAISE NOTICE 'Ingreso % con RUT de visita inválido "%"', pending.id, pending.visitor_rut;
+  END LOOP;
+END;
+$$;
+
+ALTER TABLE "user" DROP CONSTRAINT IF EXISTS user_rut_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_rut ON "user" (rut) WHERE rut <> '';
+
+COMMIT;
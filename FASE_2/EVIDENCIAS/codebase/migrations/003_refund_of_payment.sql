@@ -0,0 +1,9 @@
+-- Vincula cada reembolso con el pago que devuelve para poder limitar la suma de reembolsos al monto pagado.
+-- Los reembolsos registrados antes de esta migración quedan sin vínculo y no se descuentan del saldo reembolsable.
+
+BEGIN;
+
+ALTER TABLE payment_ledger ADD COLUMN refund_of_id INT REFERENCES payment_ledger(id);
+CREATE INDEX idx_payment_ledger_refund_of ON payment_ledger (refund_of_id);
+
+COMMIT;
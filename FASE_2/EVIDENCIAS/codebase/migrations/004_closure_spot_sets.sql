@@ -0,0 +1,14 @@
+-- Permite que un cierre abarque varios estacionamientos (lista explícita o rango de códigos como P0010-P0020).
+-- Los cierres existentes de un solo estacionamiento pasan a una lista de un elemento.
+
+BEGIN;
+
+ALTER TABLE parking_closure ADD COLUMN parking_ids INT[] NOT NULL DEFAULT '{}';
+ALTER TABLE parking_closure ADD COLUMN code_from TEXT NOT NULL DEFAULT '';
+ALTER TABLE parking_closure ADD COLUMN code_to TEXT NOT NULL DEFAULT '';
+
+UPDATE parking_closure SET parking_ids = ARRAY[parking_id] WHERE parking_id IS NOT NULL;
+
+ALTER TABLE parking_closure DROP COLUMN parking_id;
+
+COMMIT;
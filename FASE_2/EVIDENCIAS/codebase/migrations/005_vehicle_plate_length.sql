@@ -0,0 +1,4 @@
+-- Índice para acotar los candidatos de una lectura OCR a las patentes de largo parecido (±1 carácter)
+-- sin recorrer todos los vehículos. La expresión debe coincidir con plate/application.CleanPlate.
+
+CREATE INDEX idx_vehicle_plate_length ON vehicle (LENGTH(REGEXP_REPLACE(plate, '[^A-Za-z0-9]', '', 'g')));
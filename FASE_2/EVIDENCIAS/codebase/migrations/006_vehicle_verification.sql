@@ -0,0 +1,35 @@
+-- Verificación de vehículos con documento de respaldo y vehículos temporales con vigencia.
+-- Los vehículos registrados antes de esta migración ya estaban en uso, así que quedan verificados
+-- para no bloquear sus reservas ni su ingreso; los nuevos parten pendientes de revisión.
+
+BEGIN;
+
+ALTER TABLE vehicle
+  ADD COLUMN verification_status TEXT NOT NULL DEFAULT 'pending',
+  ADD COLUMN verified_by TEXT,
+  ADD COLUMN verified_at TIMESTAMPTZ,
+  ADD COLUMN rejection_reason TEXT NOT NULL DEFAULT '',
+  ADD COLUMN is_temporary BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN valid_from TIMESTAMPTZ,
+  ADD COLUMN valid_until TIMESTAMPTZ;
+
+UPDATE vehicle
+SET verification_status = 'verified',
+    verified_at = COALESCE(created_at, NOW());
+
+CREATE TABLE vehicle_document (
+  id SERIAL PRIMARY KEY,
+  vehicle_id INT NOT NULL REFERENCES vehicle(id) ON DELETE CASCADE,
+  document_type TEXT NOT NULL DEFAULT 'registration_certificate',
+  file_name TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size BIGINT NOT NULL,
+  storage_path TEXT NOT NULL,
+  uploaded_by TEXT NOT NULL,
+  uploaded_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_vehicle_document_vehicle ON vehicle_document (vehicle_id);
+CREATE INDEX idx_vehicle_verification_status ON vehicle (verification_status);
+
+COMMIT;